@@ -1,26 +1,234 @@
 package main
 
 import (
+	"context"
+	"log"
+
 	"github.com/YugenDev/global-mobility-test/internal/config"
 	"github.com/YugenDev/global-mobility-test/internal/handlers"
 	"github.com/YugenDev/global-mobility-test/internal/repositories"
 	"github.com/YugenDev/global-mobility-test/internal/routes"
 	"github.com/YugenDev/global-mobility-test/internal/services"
+	"github.com/YugenDev/global-mobility-test/internal/utils"
 	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
 )
 
 func main() {
-	config.ConnectDatabase()
-	productRepo := repositories.NewProductRepository()
-	productService := services.NewProductService(productRepo)
+	var productRepo repositories.IProductRepository
+	var auditRepo repositories.IAuditRepository
+	var reservationRepo repositories.IReservationRepository
+	var cartRepo repositories.ICartRepository
+	var orderRepo repositories.IOrderRepository
+	var categoryRepo repositories.ICategoryRepository
+	var priceHistoryRepo repositories.IPriceHistoryRepository
+	var priceScheduleRepo repositories.IPriceScheduleRepository
+	var couponRepo repositories.ICouponRepository
+	var reviewRepo repositories.IReviewRepository
+	var userRepo repositories.IUserRepository
+	var apiKeyRepo repositories.IAPIKeyRepository
+	var idempotencyRepo repositories.IIdempotencyRepository
+	switch config.GetStorageDriver() {
+	case config.StorageDriverMemory:
+		productRepo = repositories.NewMemoryProductRepository()
+		auditRepo = repositories.NewMemoryAuditRepository()
+		reservationRepo = repositories.NewMemoryReservationRepository()
+		cartRepo = repositories.NewMemoryCartRepository()
+		orderRepo = repositories.NewMemoryOrderRepository()
+		categoryRepo = repositories.NewMemoryCategoryRepository()
+		priceHistoryRepo = repositories.NewMemoryPriceHistoryRepository()
+		priceScheduleRepo = repositories.NewMemoryPriceScheduleRepository()
+		couponRepo = repositories.NewMemoryCouponRepository()
+		reviewRepo = repositories.NewMemoryReviewRepository()
+		userRepo = repositories.NewMemoryUserRepository()
+		apiKeyRepo = repositories.NewMemoryAPIKeyRepository()
+		idempotencyRepo = repositories.NewMemoryIdempotencyRepository()
+	case config.StorageDriverMongo:
+		config.ConnectDatabase()
+		productRepo = repositories.NewProductRepository()
+		auditRepo = repositories.NewAuditRepository()
+		reservationRepo = repositories.NewReservationRepository()
+		cartRepo = repositories.NewCartRepository()
+		orderRepo = repositories.NewOrderRepository()
+		categoryRepo = repositories.NewCategoryRepository()
+		priceHistoryRepo = repositories.NewPriceHistoryRepository()
+		priceScheduleRepo = repositories.NewPriceScheduleRepository()
+		couponRepo = repositories.NewCouponRepository()
+		reviewRepo = repositories.NewReviewRepository()
+		userRepo = repositories.NewUserRepository()
+		apiKeyRepo = repositories.NewAPIKeyRepository()
+		idempotencyRepo = repositories.NewIdempotencyRepository()
+	default:
+		log.Fatalf("Unknown STORAGE_DRIVER %q", config.GetStorageDriver())
+	}
+
+	var rateRepo repositories.IExchangeRateRepository = repositories.NewMemoryExchangeRateRepository()
+	if path := config.GetExchangeRatesFile(); path != "" {
+		fileRepo, err := repositories.NewFileExchangeRateRepository(path)
+		if err != nil {
+			log.Fatalf("Error loading EXCHANGE_RATES_FILE: %v", err)
+		}
+		rateRepo = fileRepo
+	}
+
+	productService := services.NewProductService(productRepo, auditRepo, categoryRepo, rateRepo, priceHistoryRepo)
+	if err := productService.EnsureSearchIndex(); err != nil {
+		log.Fatalf("Error creating product search index: %v", err)
+	}
+
 	productHandler := handlers.NewProductHandler(productService)
 
+	maxImageSize, err := config.GetMaxImageSize()
+	if err == nil && maxImageSize <= 0 {
+		err = utils.ErrInvalidMaxImageSize
+	}
+	if err != nil {
+		log.Fatalf("Invalid MAX_IMAGE_SIZE: %v", err)
+	}
+
+	blobStore := repositories.NewLocalBlobStore(config.GetMediaRoot())
+	imageHandler := handlers.NewProductImageHandler(services.NewProductImageService(productRepo, auditRepo, blobStore, maxImageSize), maxImageSize)
+
+	reservationTTL, err := config.GetReservationTTL()
+	if err == nil && reservationTTL <= 0 {
+		err = utils.ErrInvalidReservationDuration
+	}
+	if err != nil {
+		log.Fatalf("Invalid RESERVATION_TTL: %v", err)
+	}
+
+	sweepInterval, err := config.GetReservationSweepInterval()
+	if err == nil && sweepInterval <= 0 {
+		err = utils.ErrInvalidReservationDuration
+	}
+	if err != nil {
+		log.Fatalf("Invalid RESERVATION_SWEEP_INTERVAL: %v", err)
+	}
+
+	reservationService := services.NewReservationService(productRepo, reservationRepo, reservationTTL)
+	go reservationService.RunSweeper(context.Background(), sweepInterval)
+
+	reservationHandler := handlers.NewReservationHandler(reservationService)
+
+	scheduleInterval, err := config.GetPriceScheduleInterval()
+	if err == nil && scheduleInterval <= 0 {
+		err = utils.ErrInvalidScheduleInterval
+	}
+	if err != nil {
+		log.Fatalf("Invalid PRICE_SCHEDULE_INTERVAL: %v", err)
+	}
+
+	priceScheduleService := services.NewPriceScheduleService(priceScheduleRepo, productService)
+	go priceScheduleService.RunScheduler(context.Background(), scheduleInterval)
+
+	priceScheduleHandler := handlers.NewPriceScheduleHandler(priceScheduleService)
+
+	cartTTL, err := config.GetCartTTL()
+	if err == nil && cartTTL <= 0 {
+		err = utils.ErrInvalidCartTTL
+	}
+	if err != nil {
+		log.Fatalf("Invalid CART_TTL: %v", err)
+	}
+
+	cartService := services.NewCartService(cartRepo, productService, cartTTL)
+	cartHandler := handlers.NewCartHandler(cartService)
+
+	couponService := services.NewCouponService(couponRepo, categoryRepo, productService, cartService)
+	couponHandler := handlers.NewCouponHandler(couponService)
+
+	orderHandler := handlers.NewOrderHandler(services.NewOrderService(orderRepo, productService, cartService, couponService))
+	categoryHandler := handlers.NewCategoryHandler(services.NewCategoryService(categoryRepo, productRepo, auditRepo, productService))
+	exchangeRateHandler := handlers.NewExchangeRateHandler(services.NewExchangeRateService(rateRepo))
+	reviewHandler := handlers.NewReviewHandler(services.NewReviewService(reviewRepo, productRepo))
+
+	jwtSecret := config.GetJWTSecret()
+	if len(jwtSecret) < 32 {
+		log.Fatalf("Invalid JWT_SECRET: %v", utils.ErrJWTSecretRequired)
+	}
+
+	accessTokenTTL, err := config.GetAccessTokenTTL()
+	if err == nil && accessTokenTTL <= 0 {
+		err = utils.ErrInvalidTokenTTL
+	}
+	if err != nil {
+		log.Fatalf("Invalid ACCESS_TOKEN_TTL: %v", err)
+	}
+
+	refreshTokenTTL, err := config.GetRefreshTokenTTL()
+	if err == nil && refreshTokenTTL <= 0 {
+		err = utils.ErrInvalidTokenTTL
+	}
+	if err != nil {
+		log.Fatalf("Invalid REFRESH_TOKEN_TTL: %v", err)
+	}
+
+	authService := services.NewAuthService(userRepo, jwtSecret, accessTokenTTL, refreshTokenTTL)
+	if adminEmail := config.GetAdminEmail(); adminEmail != "" {
+		if _, err := authService.EnsureAdmin(adminEmail, config.GetAdminPassword()); err != nil {
+			log.Fatalf("Could not create the ADMIN_EMAIL account: %v", err)
+		}
+	}
+	authHandler := handlers.NewAuthHandler(authService)
+	apiKeyService := services.NewAPIKeyService(apiKeyRepo)
+	apiKeyHandler := handlers.NewAPIKeyHandler(apiKeyService)
+
+	rateLimit, err := config.GetRateLimit()
+	if err != nil {
+		log.Fatalf("Invalid RATE_LIMIT: %v", err)
+	}
+	rateLimitRoutes, err := config.GetRateLimitRoutes()
+	if err != nil {
+		log.Fatalf("Invalid RATE_LIMIT_ROUTES: %v", err)
+	}
+	rateLimiter := services.NewRateLimiter(repositories.NewMemoryRateLimitStore(), rateLimit, rateLimitRoutes)
+
+	idempotencyTTL, err := config.GetIdempotencyTTL()
+	if err == nil && idempotencyTTL <= 0 {
+		err = utils.ErrInvalidIdempotencyTTL
+	}
+	if err != nil {
+		log.Fatalf("Invalid IDEMPOTENCY_TTL: %v", err)
+	}
+
+	idempotencyMaxBodySize, err := config.GetIdempotencyMaxBodySize()
+	if err == nil && idempotencyMaxBodySize <= 0 {
+		err = utils.ErrInvalidIdempotencyBodySize
+	}
+	if err != nil {
+		log.Fatalf("Invalid IDEMPOTENCY_MAX_BODY_SIZE: %v", err)
+	}
+
+	idempotencyService := services.NewIdempotencyService(idempotencyRepo, idempotencyTTL)
+
+	trustedProxies, err := config.GetTrustedProxies()
+	if err != nil {
+		log.Fatalf("Invalid TRUSTED_PROXIES: %v", err)
+	}
+
 	e := echo.New()
+	e.IPExtractor = handlers.IPExtractor(trustedProxies)
+	e.Use(middleware.RequestID())
+	e.Use(handlers.Authenticate(authService, apiKeyService, rateLimiter))
+	e.Use(handlers.RateLimit(rateLimiter))
+	e.Use(handlers.Idempotency(idempotencyService, idempotencyMaxBodySize))
+
 	e.GET("/", func(c echo.Context) error {
 		return c.String(200, "Welcome to Global Mobility Apex ecommerce 🚀")
 	})
 
+	routes.AuthRoutes(e, authHandler)
+	routes.APIKeyRoutes(e, apiKeyHandler)
 	routes.ProductRoutes(e, productHandler)
+	routes.ProductImageRoutes(e, imageHandler)
+	routes.PriceScheduleRoutes(e, priceScheduleHandler)
+	routes.ReviewRoutes(e, reviewHandler)
+	routes.ReservationRoutes(e, reservationHandler)
+	routes.CartRoutes(e, cartHandler)
+	routes.OrderRoutes(e, orderHandler)
+	routes.CouponRoutes(e, couponHandler)
+	routes.CategoryRoutes(e, categoryHandler)
+	routes.ExchangeRateRoutes(e, exchangeRateHandler)
 
 	e.Logger.Fatal(e.Start(":8080"))
 }
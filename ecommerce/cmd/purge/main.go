@@ -0,0 +1,36 @@
+package main
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/YugenDev/global-mobility-test/internal/config"
+	"github.com/YugenDev/global-mobility-test/internal/repositories"
+	"github.com/YugenDev/global-mobility-test/internal/services"
+)
+
+// purge permanently removes soft-deleted products that have been in the
+// trash for longer than TRASH_RETENTION (default 720h), along with their
+// image files below MEDIA_ROOT.
+func main() {
+	if config.GetStorageDriver() != config.StorageDriverMongo {
+		log.Fatalf("Purging requires STORAGE_DRIVER=%s", config.StorageDriverMongo)
+	}
+
+	retention, err := config.GetTrashRetention()
+	if err != nil {
+		log.Fatalf("Invalid TRASH_RETENTION: %v", err)
+	}
+
+	config.ConnectDatabase()
+	productService := services.NewProductService(repositories.NewProductRepository(), repositories.NewAuditRepository(), repositories.NewCategoryRepository(), repositories.NewMemoryExchangeRateRepository(), repositories.NewPriceHistoryRepository())
+
+	blobStore := repositories.NewLocalBlobStore(config.GetMediaRoot())
+
+	purged, err := productService.PurgeDeletedProducts(retention, blobStore)
+	if err != nil {
+		log.Fatalf("Error purging deleted products: %v", err)
+	}
+
+	fmt.Printf("Purged %d products deleted more than %s ago\n", purged, retention)
+}
@@ -0,0 +1,47 @@
+package config
+
+import (
+	"os"
+	"time"
+)
+
+const (
+	defaultAccessTokenTTL  = 15 * time.Minute
+	defaultRefreshTokenTTL = 7 * 24 * time.Hour
+)
+
+var (
+	jwtSecret       = os.Getenv("JWT_SECRET")
+	accessTokenTTL  = os.Getenv("ACCESS_TOKEN_TTL")
+	refreshTokenTTL = os.Getenv("REFRESH_TOKEN_TTL")
+	adminEmail      = os.Getenv("ADMIN_EMAIL")
+	adminPassword   = os.Getenv("ADMIN_PASSWORD")
+)
+
+func GetJWTSecret() string {
+	return jwtSecret
+}
+
+func GetAccessTokenTTL() (time.Duration, error) {
+	if accessTokenTTL == "" {
+		return defaultAccessTokenTTL, nil
+	}
+	return time.ParseDuration(accessTokenTTL)
+}
+
+func GetRefreshTokenTTL() (time.Duration, error) {
+	if refreshTokenTTL == "" {
+		return defaultRefreshTokenTTL, nil
+	}
+	return time.ParseDuration(refreshTokenTTL)
+}
+
+// GetAdminEmail is the email of the admin account created at startup.
+func GetAdminEmail() string {
+	return adminEmail
+}
+
+// GetAdminPassword is the password the admin account is created with.
+func GetAdminPassword() string {
+	return adminPassword
+}
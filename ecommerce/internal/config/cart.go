@@ -0,0 +1,17 @@
+package config
+
+import (
+	"os"
+	"time"
+)
+
+const defaultCartTTL = 24 * time.Hour
+
+var cartTTL = os.Getenv("CART_TTL")
+
+func GetCartTTL() (time.Duration, error) {
+	if cartTTL == "" {
+		return defaultCartTTL, nil
+	}
+	return time.ParseDuration(cartTTL)
+}
@@ -0,0 +1,11 @@
+package config
+
+import "os"
+
+var exchangeRatesFile = os.Getenv("EXCHANGE_RATES_FILE")
+
+// GetExchangeRatesFile returns the JSON file holding the exchange rate table.
+// When it is empty the table is only kept in memory.
+func GetExchangeRatesFile() string {
+	return exchangeRatesFile
+}
@@ -0,0 +1,34 @@
+package config
+
+import (
+	"os"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultIdempotencyTTL         = 24 * time.Hour
+	defaultIdempotencyMaxBodySize = 10 << 20
+)
+
+var (
+	idempotencyTTL         = os.Getenv("IDEMPOTENCY_TTL")
+	idempotencyMaxBodySize = os.Getenv("IDEMPOTENCY_MAX_BODY_SIZE")
+)
+
+// GetIdempotencyTTL is how long a response is kept for replay.
+func GetIdempotencyTTL() (time.Duration, error) {
+	if idempotencyTTL == "" {
+		return defaultIdempotencyTTL, nil
+	}
+	return time.ParseDuration(idempotencyTTL)
+}
+
+// GetIdempotencyMaxBodySize returns the largest request body in bytes that is
+// read to fingerprint an idempotent request.
+func GetIdempotencyMaxBodySize() (int64, error) {
+	if idempotencyMaxBodySize == "" {
+		return defaultIdempotencyMaxBodySize, nil
+	}
+	return strconv.ParseInt(idempotencyMaxBodySize, 10, 64)
+}
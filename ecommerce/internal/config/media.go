@@ -0,0 +1,31 @@
+package config
+
+import (
+	"os"
+	"strconv"
+)
+
+const (
+	defaultMediaRoot    = "media"
+	defaultMaxImageSize = 5 << 20
+)
+
+var (
+	mediaRoot    = os.Getenv("MEDIA_ROOT")
+	maxImageSize = os.Getenv("MAX_IMAGE_SIZE")
+)
+
+func GetMediaRoot() string {
+	if mediaRoot == "" {
+		return defaultMediaRoot
+	}
+	return mediaRoot
+}
+
+// GetMaxImageSize returns the largest accepted image upload in bytes.
+func GetMaxImageSize() (int64, error) {
+	if maxImageSize == "" {
+		return defaultMaxImageSize, nil
+	}
+	return strconv.ParseInt(maxImageSize, 10, 64)
+}
@@ -0,0 +1,19 @@
+package config
+
+import (
+	"os"
+	"time"
+)
+
+const defaultPriceScheduleInterval = time.Minute
+
+var priceScheduleInterval = os.Getenv("PRICE_SCHEDULE_INTERVAL")
+
+// GetPriceScheduleInterval returns how often due price schedules are applied
+// and reverted.
+func GetPriceScheduleInterval() (time.Duration, error) {
+	if priceScheduleInterval == "" {
+		return defaultPriceScheduleInterval, nil
+	}
+	return time.ParseDuration(priceScheduleInterval)
+}
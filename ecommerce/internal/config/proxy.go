@@ -0,0 +1,41 @@
+package config
+
+import (
+	"net"
+	"os"
+	"strings"
+
+	"github.com/YugenDev/global-mobility-test/internal/utils"
+)
+
+var trustedProxies = os.Getenv("TRUSTED_PROXIES")
+
+// GetTrustedProxies are the proxies, as comma-separated IP addresses or CIDR
+// ranges, whose X-Forwarded-For header is believed. None by default.
+func GetTrustedProxies() ([]*net.IPNet, error) {
+	var proxies []*net.IPNet
+	for _, entry := range strings.Split(trustedProxies, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		if !strings.Contains(entry, "/") {
+			ip := net.ParseIP(entry)
+			if ip == nil {
+				return nil, utils.ErrInvalidTrustedProxy
+			}
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 8*net.IPv4len
+			}
+			proxies = append(proxies, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, network, err := net.ParseCIDR(entry)
+		if err != nil {
+			return nil, utils.ErrInvalidTrustedProxy
+		}
+		proxies = append(proxies, network)
+	}
+	return proxies, nil
+}
@@ -0,0 +1,28 @@
+package config
+
+import (
+	"os"
+
+	"github.com/YugenDev/global-mobility-test/internal/models"
+)
+
+const defaultRateLimit = "120/1m"
+
+var (
+	rateLimit       = os.Getenv("RATE_LIMIT")
+	rateLimitRoutes = os.Getenv("RATE_LIMIT_ROUTES")
+)
+
+// GetRateLimit is each client's limit on routes without their own.
+func GetRateLimit() (models.RateLimit, error) {
+	if rateLimit == "" {
+		return models.ParseRateLimit(defaultRateLimit)
+	}
+	return models.ParseRateLimit(rateLimit)
+}
+
+// GetRateLimitRoutes are the routes with their own limit, counted apart from
+// the default one.
+func GetRateLimitRoutes() (map[string]models.RateLimit, error) {
+	return models.ParseRateLimitRoutes(rateLimitRoutes)
+}
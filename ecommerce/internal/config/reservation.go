@@ -0,0 +1,30 @@
+package config
+
+import (
+	"os"
+	"time"
+)
+
+const (
+	defaultReservationTTL           = 15 * time.Minute
+	defaultReservationSweepInterval = time.Minute
+)
+
+var (
+	reservationTTL           = os.Getenv("RESERVATION_TTL")
+	reservationSweepInterval = os.Getenv("RESERVATION_SWEEP_INTERVAL")
+)
+
+func GetReservationTTL() (time.Duration, error) {
+	if reservationTTL == "" {
+		return defaultReservationTTL, nil
+	}
+	return time.ParseDuration(reservationTTL)
+}
+
+func GetReservationSweepInterval() (time.Duration, error) {
+	if reservationSweepInterval == "" {
+		return defaultReservationSweepInterval, nil
+	}
+	return time.ParseDuration(reservationSweepInterval)
+}
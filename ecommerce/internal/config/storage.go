@@ -0,0 +1,19 @@
+package config
+
+import (
+	"os"
+)
+
+const (
+	StorageDriverMongo  = "mongo"
+	StorageDriverMemory = "memory"
+)
+
+var storageDriver = os.Getenv("STORAGE_DRIVER")
+
+func GetStorageDriver() string {
+	if storageDriver == "" {
+		return StorageDriverMongo
+	}
+	return storageDriver
+}
@@ -0,0 +1,17 @@
+package config
+
+import (
+	"os"
+	"time"
+)
+
+const defaultTrashRetention = 30 * 24 * time.Hour
+
+var trashRetention = os.Getenv("TRASH_RETENTION")
+
+func GetTrashRetention() (time.Duration, error) {
+	if trashRetention == "" {
+		return defaultTrashRetention, nil
+	}
+	return time.ParseDuration(trashRetention)
+}
@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/YugenDev/global-mobility-test/internal/models"
+	"github.com/YugenDev/global-mobility-test/internal/services"
+	"github.com/YugenDev/global-mobility-test/internal/utils"
+	"github.com/labstack/echo/v4"
+)
+
+type APIKeyHandler struct {
+	Service services.IAPIKeyService
+}
+
+func NewAPIKeyHandler(service services.IAPIKeyService) *APIKeyHandler {
+	return &APIKeyHandler{
+		Service: service,
+	}
+}
+
+func (h *APIKeyHandler) CreateAPIKey(c echo.Context) error {
+	var request models.APIKeyRequest
+	if err := c.Bind(&request); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"message": utils.ErrInvalidRequestPayload.Error()})
+	}
+
+	key, err := h.Service.Create(request, utils.ActorFromContext(c))
+	if err != nil {
+		return apiKeyError(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, key)
+}
+
+func (h *APIKeyHandler) GetAPIKeys(c echo.Context) error {
+	keys, err := h.Service.GetAll()
+	if err != nil {
+		return apiKeyError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, keys)
+}
+
+func (h *APIKeyHandler) RevokeAPIKey(c echo.Context) error {
+	if err := h.Service.Revoke(c.Param("id")); err != nil {
+		return apiKeyError(c, err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func apiKeyError(c echo.Context, err error) error {
+	switch err {
+	case utils.ErrAPIKeyNameRequired, utils.ErrInvalidAPIKeyScope, utils.ErrInvalidAPIKeyExpiry:
+		return c.JSON(http.StatusBadRequest, map[string]string{"message": err.Error()})
+	case utils.ErrAPIKeyNotFound, utils.ErrAPIKeyIDRequired:
+		return c.JSON(http.StatusNotFound, map[string]string{"message": utils.ErrAPIKeyNotFound.Error()})
+	}
+	return c.JSON(http.StatusInternalServerError, map[string]string{"message": utils.ErrInternalServer.Error()})
+}
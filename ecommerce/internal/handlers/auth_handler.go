@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/YugenDev/global-mobility-test/internal/models"
+	"github.com/YugenDev/global-mobility-test/internal/services"
+	"github.com/YugenDev/global-mobility-test/internal/utils"
+	"github.com/labstack/echo/v4"
+)
+
+type AuthHandler struct {
+	Service services.IAuthService
+}
+
+func NewAuthHandler(service services.IAuthService) *AuthHandler {
+	return &AuthHandler{
+		Service: service,
+	}
+}
+
+func (h *AuthHandler) Register(c echo.Context) error {
+	var request models.RegisterRequest
+	if err := c.Bind(&request); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"message": utils.ErrInvalidRequestPayload.Error()})
+	}
+
+	user, err := h.Service.Register(request)
+	if err != nil {
+		return authError(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, user)
+}
+
+func (h *AuthHandler) Login(c echo.Context) error {
+	var request models.LoginRequest
+	if err := c.Bind(&request); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"message": utils.ErrInvalidRequestPayload.Error()})
+	}
+
+	tokens, err := h.Service.Login(request)
+	if err != nil {
+		return authError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, tokens)
+}
+
+func (h *AuthHandler) Refresh(c echo.Context) error {
+	var request models.RefreshRequest
+	if err := c.Bind(&request); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"message": utils.ErrInvalidRequestPayload.Error()})
+	}
+
+	tokens, err := h.Service.Refresh(request.RefreshToken)
+	if err != nil {
+		return authError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, tokens)
+}
+
+func (h *AuthHandler) Me(c echo.Context) error {
+	identity, _ := services.IdentityFromContext(c)
+
+	user, err := h.Service.GetUser(identity.UserID)
+	if err != nil {
+		return authError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, user)
+}
+
+func (h *AuthHandler) GetUsers(c echo.Context) error {
+	users, err := h.Service.GetUsers()
+	if err != nil {
+		return authError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, users)
+}
+
+func (h *AuthHandler) SetRoles(c echo.Context) error {
+	var request models.RolesRequest
+	if err := c.Bind(&request); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"message": utils.ErrInvalidRequestPayload.Error()})
+	}
+
+	user, err := h.Service.SetRoles(c.Param("id"), request.Roles)
+	if err != nil {
+		return authError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, user)
+}
+
+func authError(c echo.Context, err error) error {
+	switch err {
+	case utils.ErrInvalidEmail, utils.ErrInvalidPassword, utils.ErrInvalidRole:
+		return c.JSON(http.StatusBadRequest, map[string]string{"message": err.Error()})
+	case utils.ErrInvalidCredentials, utils.ErrInvalidToken:
+		return c.JSON(http.StatusUnauthorized, map[string]string{"message": err.Error()})
+	case utils.ErrUserNotFound, utils.ErrUserIDRequired:
+		return c.JSON(http.StatusNotFound, map[string]string{"message": utils.ErrUserNotFound.Error()})
+	case utils.ErrEmailTaken:
+		return c.JSON(http.StatusConflict, map[string]string{"message": err.Error()})
+	}
+	return c.JSON(http.StatusInternalServerError, map[string]string{"message": utils.ErrInternalServer.Error()})
+}
@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/YugenDev/global-mobility-test/internal/services"
+	"github.com/YugenDev/global-mobility-test/internal/utils"
+	"github.com/labstack/echo/v4"
+)
+
+const bearerScheme = "Bearer"
+
+// Authenticate identifies the caller from an Authorization: Bearer access
+// token or an X-API-Key header and stores the identity, and the caller's
+// email or API key as the actor, on the context. Requests without
+// credentials carry on anonymously; bad credentials are rejected with 401.
+// Rejected requests are charged to their IP address in limiter, so guessing
+// credentials is throttled like any anonymous traffic and gets 429 once the
+// bucket is empty.
+func Authenticate(service services.IAuthService, apiKeys services.IAPIKeyService, limiter services.IRateLimiter) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			reject := func(err error) error {
+				if limited, err := takeRateLimit(c, limiter, anonymousClient(c)); limited {
+					return err
+				}
+				return unauthorized(c, err)
+			}
+
+			header := c.Request().Header.Get(echo.HeaderAuthorization)
+			key := c.Request().Header.Get(utils.HeaderAPIKey)
+			if header != "" && key != "" {
+				return reject(utils.ErrMultipleCredentials)
+			}
+
+			if key != "" {
+				identity, err := apiKeys.Authenticate(strings.TrimSpace(key))
+				if err != nil {
+					return reject(err)
+				}
+
+				c.Set(utils.ContextKeyIdentity, identity)
+				c.Set(utils.ContextKeyActor, "api_key:"+identity.APIKeyID)
+				return next(c)
+			}
+
+			if header == "" {
+				return next(c)
+			}
+
+			scheme, token, ok := strings.Cut(header, " ")
+			if !ok || !strings.EqualFold(scheme, bearerScheme) {
+				return reject(utils.ErrInvalidToken)
+			}
+
+			identity, err := service.Authenticate(strings.TrimSpace(token))
+			if err != nil {
+				return reject(err)
+			}
+
+			c.Set(utils.ContextKeyIdentity, identity)
+			c.Set(utils.ContextKeyActor, identity.Email)
+			return next(c)
+		}
+	}
+}
+
+// RequireUser rejects requests that Authenticate did not identify.
+func RequireUser(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if _, ok := services.IdentityFromContext(c); !ok {
+			return unauthorized(c, utils.ErrAuthenticationRequired)
+		}
+		return next(c)
+	}
+}
+
+// RequirePermission lets through only callers granted permission. Anonymous
+// callers get 401; identified callers without the permission get 403 naming
+// it.
+func RequirePermission(permission string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			identity, ok := services.IdentityFromContext(c)
+			if !ok {
+				return unauthorized(c, utils.ErrAuthenticationRequired)
+			}
+			if !identity.Can(permission) {
+				return c.JSON(http.StatusForbidden, map[string]string{
+					"message":    fmt.Errorf("%w: %s", utils.ErrPermissionDenied, permission).Error(),
+					"permission": permission,
+				})
+			}
+			return next(c)
+		}
+	}
+}
+
+func unauthorized(c echo.Context, err error) error {
+	switch err {
+	case utils.ErrInvalidToken, utils.ErrInvalidAPIKey, utils.ErrMultipleCredentials, utils.ErrAuthenticationRequired:
+		c.Response().Header().Set(echo.HeaderWWWAuthenticate, bearerScheme)
+		return c.JSON(http.StatusUnauthorized, map[string]string{"message": err.Error()})
+	}
+	return c.JSON(http.StatusInternalServerError, map[string]string{"message": utils.ErrInternalServer.Error()})
+}
@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/YugenDev/global-mobility-test/internal/models"
+	"github.com/YugenDev/global-mobility-test/internal/services"
+	"github.com/YugenDev/global-mobility-test/internal/utils"
+	"github.com/labstack/echo/v4"
+)
+
+type CartHandler struct {
+	Service services.ICartService
+}
+
+func NewCartHandler(service services.ICartService) *CartHandler {
+	return &CartHandler{
+		Service: service,
+	}
+}
+
+func (h *CartHandler) CreateCart(c echo.Context) error {
+	cart, err := h.Service.CreateCart(c)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"message": utils.ErrInternalServer.Error()})
+	}
+
+	return c.JSON(http.StatusCreated, cart)
+}
+
+func (h *CartHandler) GetCart(c echo.Context) error {
+	cart, err := h.Service.GetCart(c.Param("id"))
+	if err != nil {
+		return cartError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, cart)
+}
+
+func (h *CartHandler) AddItem(c echo.Context) error {
+	var item models.CartItemRequest
+	if err := c.Bind(&item); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"message": utils.ErrInvalidRequestPayload.Error()})
+	}
+
+	cart, err := h.Service.AddItem(c, c.Param("id"), item)
+	if err != nil {
+		return cartError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, cart)
+}
+
+func (h *CartHandler) UpdateItem(c echo.Context) error {
+	var item models.CartItemRequest
+	if err := c.Bind(&item); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"message": utils.ErrInvalidRequestPayload.Error()})
+	}
+
+	cart, err := h.Service.UpdateItem(c, c.Param("id"), c.Param("product_id"), c.QueryParam("sku"), item.Quantity)
+	if err != nil {
+		return cartError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, cart)
+}
+
+func (h *CartHandler) RemoveItem(c echo.Context) error {
+	cart, err := h.Service.RemoveItem(c, c.Param("id"), c.Param("product_id"), c.QueryParam("sku"))
+	if err != nil {
+		return cartError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, cart)
+}
+
+func cartError(c echo.Context, err error) error {
+	switch err {
+	case utils.ErrCartIDRequired, utils.ErrProductIDRequired, utils.ErrInvalidCartQuantity, utils.ErrVariantRequired:
+		return c.JSON(http.StatusBadRequest, map[string]string{"message": err.Error()})
+	case utils.ErrCartNotFound, utils.ErrCartItemNotFound, utils.ErrNoProductsFound, utils.ErrVariantNotFound:
+		return c.JSON(http.StatusNotFound, map[string]string{"message": err.Error()})
+	case utils.ErrInsufficientStock, utils.ErrCartVersionMismatch, utils.ErrCurrencyMismatch:
+		return c.JSON(http.StatusConflict, map[string]string{"message": err.Error()})
+	}
+	return c.JSON(http.StatusInternalServerError, map[string]string{"message": utils.ErrInternalServer.Error()})
+}
@@ -0,0 +1,120 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/YugenDev/global-mobility-test/internal/models"
+	"github.com/YugenDev/global-mobility-test/internal/services"
+	"github.com/YugenDev/global-mobility-test/internal/utils"
+	"github.com/labstack/echo/v4"
+)
+
+type CategoryHandler struct {
+	Service services.ICategoryService
+}
+
+func NewCategoryHandler(service services.ICategoryService) *CategoryHandler {
+	return &CategoryHandler{
+		Service: service,
+	}
+}
+
+func (h *CategoryHandler) CreateCategory(c echo.Context) error {
+	var category models.Category
+	if err := c.Bind(&category); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"message": utils.ErrInvalidRequestPayload.Error()})
+	}
+
+	if err := h.Service.CreateCategory(&category); err != nil {
+		return categoryError(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, category)
+}
+
+func (h *CategoryHandler) GetCategories(c echo.Context) error {
+	categories, err := h.Service.GetAll()
+	if err != nil {
+		return categoryError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, categories)
+}
+
+func (h *CategoryHandler) GetCategoryTree(c echo.Context) error {
+	tree, err := h.Service.GetTree()
+	if err != nil {
+		return categoryError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, tree)
+}
+
+func (h *CategoryHandler) GetCategoryByID(c echo.Context) error {
+	category, err := h.Service.GetByID(c.Param("id"))
+	if err != nil {
+		return categoryError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, category)
+}
+
+func (h *CategoryHandler) UpdateCategory(c echo.Context) error {
+	var category models.Category
+	if err := c.Bind(&category); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"message": utils.ErrInvalidRequestPayload.Error()})
+	}
+
+	if err := h.Service.UpdateCategory(c.Param("id"), &category); err != nil {
+		return categoryError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, category)
+}
+
+func (h *CategoryHandler) DeleteCategory(c echo.Context) error {
+	if err := h.Service.DeleteCategory(c, c.Param("id"), c.QueryParam("reassign_to")); err != nil {
+		return categoryError(c, err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (h *CategoryHandler) GetCategoryProducts(c echo.Context) error {
+	query, err := parseProductQuery(c, "include_descendants")
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"message": err.Error()})
+	}
+
+	includeDescendants := false
+	if value := c.QueryParam("include_descendants"); value != "" {
+		includeDescendants, err = strconv.ParseBool(value)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"message": fmt.Errorf("%w: include_descendants", utils.ErrInvalidFilterValue).Error()})
+		}
+	}
+
+	page, err := h.Service.GetProducts(c.Param("id"), includeDescendants, query)
+	if err != nil {
+		return categoryError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, page)
+}
+
+func categoryError(c echo.Context, err error) error {
+	switch err {
+	case utils.ErrCategoryIDRequired, utils.ErrCategoryNameRequired, utils.ErrInvalidCategorySlug,
+		utils.ErrParentCategoryNotFound, utils.ErrCategoryCycle, utils.ErrInvalidReassignCategory,
+		utils.ErrInvalidPageLimit, utils.ErrInvalidCursor, utils.ErrInvalidSortField, utils.ErrInvalidPriceRange,
+		utils.ErrUnsupportedCurrency, utils.ErrExchangeRateNotFound:
+		return c.JSON(http.StatusBadRequest, map[string]string{"message": err.Error()})
+	case utils.ErrCategoryNotFound:
+		return c.JSON(http.StatusNotFound, map[string]string{"message": err.Error()})
+	case utils.ErrCategorySlugExists, utils.ErrCategoryHasChildren, utils.ErrCategoryHasProducts:
+		return c.JSON(http.StatusConflict, map[string]string{"message": err.Error()})
+	}
+	return c.JSON(http.StatusInternalServerError, map[string]string{"message": utils.ErrInternalServer.Error()})
+}
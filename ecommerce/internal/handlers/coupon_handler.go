@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/YugenDev/global-mobility-test/internal/models"
+	"github.com/YugenDev/global-mobility-test/internal/services"
+	"github.com/YugenDev/global-mobility-test/internal/utils"
+	"github.com/labstack/echo/v4"
+)
+
+type CouponHandler struct {
+	Service services.ICouponService
+}
+
+func NewCouponHandler(service services.ICouponService) *CouponHandler {
+	return &CouponHandler{
+		Service: service,
+	}
+}
+
+func (h *CouponHandler) CreateCoupon(c echo.Context) error {
+	var coupon models.Coupon
+	if err := c.Bind(&coupon); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"message": utils.ErrInvalidRequestPayload.Error()})
+	}
+
+	if err := h.Service.CreateCoupon(&coupon); err != nil {
+		return couponError(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, coupon)
+}
+
+func (h *CouponHandler) GetCoupons(c echo.Context) error {
+	coupons, err := h.Service.GetAll()
+	if err != nil {
+		return couponError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, coupons)
+}
+
+func (h *CouponHandler) GetCouponByID(c echo.Context) error {
+	coupon, err := h.Service.GetByID(c.Param("id"))
+	if err != nil {
+		return couponError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, coupon)
+}
+
+func (h *CouponHandler) UpdateCoupon(c echo.Context) error {
+	var coupon models.Coupon
+	if err := c.Bind(&coupon); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"message": utils.ErrInvalidRequestPayload.Error()})
+	}
+
+	if err := h.Service.UpdateCoupon(c.Param("id"), &coupon); err != nil {
+		return couponError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, coupon)
+}
+
+func (h *CouponHandler) DeleteCoupon(c echo.Context) error {
+	if err := h.Service.DeleteCoupon(c.Param("id")); err != nil {
+		return couponError(c, err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (h *CouponHandler) EvaluateCoupon(c echo.Context) error {
+	var request models.CouponEvaluationRequest
+	if err := c.Bind(&request); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"message": utils.ErrInvalidRequestPayload.Error()})
+	}
+
+	evaluation, err := h.Service.Evaluate(request)
+	if err != nil {
+		return couponError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, evaluation)
+}
+
+func couponError(c echo.Context, err error) error {
+	switch err {
+	case utils.ErrCouponIDRequired, utils.ErrCouponCodeRequired, utils.ErrInvalidCouponCode, utils.ErrInvalidCouponType,
+		utils.ErrInvalidCouponDiscount, utils.ErrInvalidCouponRule, utils.ErrInvalidCouponWindow,
+		utils.ErrUnsupportedCurrency, utils.ErrUnknownCategory, utils.ErrInvalidCheckoutRequest,
+		utils.ErrProductIDRequired, utils.ErrInvalidCartQuantity, utils.ErrVariantRequired, utils.ErrCurrencyMismatch:
+		return c.JSON(http.StatusBadRequest, map[string]string{"message": err.Error()})
+	case utils.ErrCouponNotFound, utils.ErrCartNotFound, utils.ErrNoProductsFound, utils.ErrVariantNotFound:
+		return c.JSON(http.StatusNotFound, map[string]string{"message": err.Error()})
+	case utils.ErrCouponCodeExists, utils.ErrCouponNotActive, utils.ErrCouponExpired, utils.ErrCouponUsageLimitReached,
+		utils.ErrCouponMinimumNotMet, utils.ErrCouponNotApplicable:
+		return c.JSON(http.StatusConflict, map[string]string{"message": err.Error()})
+	}
+	return c.JSON(http.StatusInternalServerError, map[string]string{"message": utils.ErrInternalServer.Error()})
+}
@@ -0,0 +1,34 @@
+package handlers
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	HeaderETag    = "ETag"
+	HeaderIfMatch = "If-Match"
+)
+
+func setETag(c echo.Context, version int) {
+	c.Response().Header().Set(HeaderETag, strconv.Quote(strconv.Itoa(version)))
+}
+
+// parseIfMatch returns the product version requested by the If-Match header.
+// A missing header or "*" yields 0, meaning any version is acceptable. An
+// entity tag that is not a version can never match, so it yields -1.
+func parseIfMatch(c echo.Context) int {
+	value := strings.TrimSpace(c.Request().Header.Get(HeaderIfMatch))
+	if value == "" || value == "*" {
+		return 0
+	}
+
+	value = strings.TrimPrefix(value, "W/")
+	version, err := strconv.Atoi(strings.Trim(value, `"`))
+	if err != nil || version <= 0 {
+		return -1
+	}
+	return version
+}
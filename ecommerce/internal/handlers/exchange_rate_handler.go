@@ -0,0 +1,46 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/YugenDev/global-mobility-test/internal/models"
+	"github.com/YugenDev/global-mobility-test/internal/services"
+	"github.com/YugenDev/global-mobility-test/internal/utils"
+	"github.com/labstack/echo/v4"
+)
+
+type ExchangeRateHandler struct {
+	Service services.IExchangeRateService
+}
+
+func NewExchangeRateHandler(service services.IExchangeRateService) *ExchangeRateHandler {
+	return &ExchangeRateHandler{
+		Service: service,
+	}
+}
+
+func (h *ExchangeRateHandler) GetRates(c echo.Context) error {
+	rates, err := h.Service.GetRates()
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"message": utils.ErrInternalServer.Error()})
+	}
+
+	return c.JSON(http.StatusOK, rates)
+}
+
+func (h *ExchangeRateHandler) UpdateRates(c echo.Context) error {
+	var rates models.ExchangeRates
+	if err := c.Bind(&rates); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"message": utils.ErrInvalidRequestPayload.Error()})
+	}
+
+	if err := h.Service.UpdateRates(&rates); err != nil {
+		switch err {
+		case utils.ErrUnsupportedCurrency, utils.ErrInvalidExchangeRate, utils.ErrInvalidRoundingRule:
+			return c.JSON(http.StatusBadRequest, map[string]string{"message": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"message": utils.ErrInternalServer.Error()})
+	}
+
+	return c.JSON(http.StatusOK, rates)
+}
@@ -0,0 +1,183 @@
+package handlers
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/YugenDev/global-mobility-test/internal/services"
+	"github.com/YugenDev/global-mobility-test/internal/utils"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	HeaderIdempotentReplayed = "Idempotent-Replayed"
+
+	maxIdempotencyKeyLength = 255
+
+	// maxIdempotencyMemoryBody is the largest request body kept in memory
+	// for the handler; larger ones are spooled to a temporary file.
+	maxIdempotencyMemoryBody = 64 << 10
+)
+
+// Idempotency makes POST requests carrying an Idempotency-Key safe to
+// retry. The first successful response is stored and replayed byte for byte,
+// with its Content-Type, ETag and Location, to repeats of the request; any
+// other response releases the key so that the request can be retried.
+// Requests are compared by a hash of their method, URL and body, which is
+// computed while the body is read. Reusing the key for a different request
+// gets 422, and bodies larger than maxBodySize get 413. Keys are scoped to the client, like rate limits, and
+// it must run after Authenticate. Auth routes are left alone so that tokens
+// are never stored.
+func Idempotency(service services.IIdempotencyService, maxBodySize int64) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			request := c.Request()
+			key := request.Header.Get(utils.HeaderIdempotency)
+			if request.Method != http.MethodPost || key == "" || strings.HasPrefix(c.Path(), "/auth/") {
+				return next(c)
+			}
+			if len(key) > maxIdempotencyKeyLength {
+				return c.JSON(http.StatusBadRequest, map[string]string{"message": utils.ErrInvalidIdempotencyKey.Error()})
+			}
+
+			hash := sha256.New()
+			io.WriteString(hash, request.Method+" "+request.URL.RequestURI()+"\n")
+			body := &spooledBody{}
+			defer body.Close()
+			_, err := io.Copy(io.MultiWriter(hash, body), http.MaxBytesReader(c.Response(), request.Body, maxBodySize))
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				return c.JSON(http.StatusRequestEntityTooLarge, map[string]string{"message": utils.ErrIdempotentBodyTooLarge.Error()})
+			}
+			if body.err != nil {
+				log.Println("Error spooling idempotent request body: ", body.err)
+				return c.JSON(http.StatusInternalServerError, map[string]string{"message": utils.ErrInternalServer.Error()})
+			}
+			if err != nil {
+				return c.JSON(http.StatusBadRequest, map[string]string{"message": utils.ErrInvalidRequestPayload.Error()})
+			}
+			if request.Body, err = body.Reader(); err != nil {
+				log.Println("Error spooling idempotent request body: ", err)
+				return c.JSON(http.StatusInternalServerError, map[string]string{"message": utils.ErrInternalServer.Error()})
+			}
+
+			scoped := rateLimitClient(c) + "|" + key
+			fingerprint := hex.EncodeToString(hash.Sum(nil))
+
+			record, replay, err := service.Begin(scoped, fingerprint)
+			if err != nil {
+				return idempotencyError(c, err)
+			}
+			if replay {
+				header := c.Response().Header()
+				header.Set(HeaderIdempotentReplayed, "true")
+				if record.ETag != "" {
+					header.Set(HeaderETag, record.ETag)
+				}
+				if record.Location != "" {
+					header.Set(echo.HeaderLocation, record.Location)
+				}
+				return c.Blob(record.Status, record.ContentType, record.Body)
+			}
+
+			recorder := &responseRecorder{ResponseWriter: c.Response().Writer}
+			c.Response().Writer = recorder
+			err = next(c)
+			c.Response().Writer = recorder.ResponseWriter
+
+			status := c.Response().Status
+			if err != nil || status < http.StatusOK || status >= http.StatusMultipleChoices {
+				service.Release(scoped)
+				return err
+			}
+			err = service.Complete(scoped, fingerprint, status, c.Response().Header(), recorder.body.Bytes())
+			if err != nil {
+				log.Println("Error storing idempotent response: ", err)
+			}
+			// A lost claim may belong to another request by now, so it is
+			// left alone.
+			if err != nil && err != utils.ErrIdempotencyClaimLost {
+				service.Release(scoped)
+			}
+			return nil
+		}
+	}
+}
+
+func idempotencyError(c echo.Context, err error) error {
+	switch err {
+	case utils.ErrIdempotencyKeyReused:
+		return c.JSON(http.StatusUnprocessableEntity, map[string]string{"message": err.Error()})
+	case utils.ErrIdempotencyKeyInProgress:
+		return c.JSON(http.StatusConflict, map[string]string{"message": err.Error()})
+	}
+	return c.JSON(http.StatusInternalServerError, map[string]string{"message": utils.ErrInternalServer.Error()})
+}
+
+// spooledBody keeps a request body in memory up to maxIdempotencyMemoryBody
+// and in a temporary file beyond, so that it can be handed to the handler
+// after it was read to be hashed. The first write error is kept in err.
+type spooledBody struct {
+	memory bytes.Buffer
+	file   *os.File
+	err    error
+}
+
+func (b *spooledBody) Write(p []byte) (int, error) {
+	if b.err != nil {
+		return 0, b.err
+	}
+	if b.file == nil && b.memory.Len()+len(p) <= maxIdempotencyMemoryBody {
+		return b.memory.Write(p)
+	}
+	if b.file == nil {
+		if b.file, b.err = os.CreateTemp("", "idempotent-body-*"); b.err != nil {
+			return 0, b.err
+		}
+		if _, b.err = b.file.Write(b.memory.Bytes()); b.err != nil {
+			return 0, b.err
+		}
+		b.memory.Reset()
+	}
+	var n int
+	n, b.err = b.file.Write(p)
+	return n, b.err
+}
+
+// Reader returns the body from its start. It stays readable until Close.
+func (b *spooledBody) Reader() (io.ReadCloser, error) {
+	if b.file == nil {
+		return io.NopCloser(bytes.NewReader(b.memory.Bytes())), nil
+	}
+	if _, err := b.file.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	return io.NopCloser(b.file), nil
+}
+
+// Close removes the temporary file, if any.
+func (b *spooledBody) Close() error {
+	if b.file == nil {
+		return nil
+	}
+	b.file.Close()
+	return os.Remove(b.file.Name())
+}
+
+// responseRecorder keeps a copy of everything written to the response.
+type responseRecorder struct {
+	http.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *responseRecorder) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/YugenDev/global-mobility-test/internal/models"
+	"github.com/YugenDev/global-mobility-test/internal/services"
+	"github.com/YugenDev/global-mobility-test/internal/utils"
+	"github.com/labstack/echo/v4"
+)
+
+type OrderHandler struct {
+	Service services.IOrderService
+}
+
+func NewOrderHandler(service services.IOrderService) *OrderHandler {
+	return &OrderHandler{
+		Service: service,
+	}
+}
+
+func (h *OrderHandler) Checkout(c echo.Context) error {
+	var request models.CheckoutRequest
+	if err := c.Bind(&request); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"message": utils.ErrInvalidRequestPayload.Error()})
+	}
+
+	order, err := h.Service.Checkout(c, request)
+	if err != nil {
+		return orderError(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, order)
+}
+
+func (h *OrderHandler) GetOrder(c echo.Context) error {
+	order, err := h.Service.GetByID(c.Param("id"))
+	if err != nil {
+		return orderError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, order)
+}
+
+func (h *OrderHandler) UpdateStatus(c echo.Context) error {
+	var request models.OrderStatusRequest
+	if err := c.Bind(&request); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"message": utils.ErrInvalidRequestPayload.Error()})
+	}
+
+	order, err := h.Service.UpdateStatus(c, c.Param("id"), request.Status)
+	if err != nil {
+		return orderError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, order)
+}
+
+func orderError(c echo.Context, err error) error {
+	switch err {
+	case utils.ErrInvalidCheckoutRequest, utils.ErrEmptyOrder, utils.ErrOrderIDRequired, utils.ErrInvalidOrderStatus,
+		utils.ErrProductIDRequired, utils.ErrInvalidCartQuantity, utils.ErrVariantRequired, utils.ErrCurrencyMismatch:
+		return c.JSON(http.StatusBadRequest, map[string]string{"message": err.Error()})
+	case utils.ErrOrderNotFound, utils.ErrCartNotFound, utils.ErrNoProductsFound, utils.ErrVariantNotFound, utils.ErrCouponNotFound:
+		return c.JSON(http.StatusNotFound, map[string]string{"message": err.Error()})
+	case utils.ErrInsufficientStock, utils.ErrInvalidOrderTransition, utils.ErrCouponNotActive, utils.ErrCouponExpired,
+		utils.ErrCouponUsageLimitReached, utils.ErrCouponMinimumNotMet, utils.ErrCouponNotApplicable:
+		return c.JSON(http.StatusConflict, map[string]string{"message": err.Error()})
+	}
+	return c.JSON(http.StatusInternalServerError, map[string]string{"message": utils.ErrInternalServer.Error()})
+}
@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/YugenDev/global-mobility-test/internal/models"
+	"github.com/YugenDev/global-mobility-test/internal/services"
+	"github.com/YugenDev/global-mobility-test/internal/utils"
+	"github.com/labstack/echo/v4"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type PriceScheduleHandler struct {
+	Service services.IPriceScheduleService
+}
+
+func NewPriceScheduleHandler(service services.IPriceScheduleService) *PriceScheduleHandler {
+	return &PriceScheduleHandler{
+		Service: service,
+	}
+}
+
+func (h *PriceScheduleHandler) CreatePriceSchedule(c echo.Context) error {
+	id := c.Param("id")
+	if id == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{"message": utils.ErrProductIDRequired.Error()})
+	}
+
+	var request models.PriceScheduleRequest
+	if err := c.Bind(&request); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"message": utils.ErrInvalidRequestPayload.Error()})
+	}
+
+	schedule, err := h.Service.Create(c, id, request)
+	if err != nil {
+		switch err {
+		case utils.ErrInvalidPriceSchedule, utils.ErrProductPriceInvalid, utils.ErrUnsupportedCurrency, utils.ErrCurrencyMismatch:
+			return c.JSON(http.StatusBadRequest, map[string]string{"message": err.Error()})
+		case utils.ErrPriceScheduleOverlap:
+			return c.JSON(http.StatusConflict, map[string]string{"message": err.Error()})
+		case mongo.ErrNoDocuments:
+			return c.JSON(http.StatusNotFound, map[string]string{"message": utils.ErrNoProductsFound.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"message": utils.ErrInternalServer.Error()})
+	}
+
+	return c.JSON(http.StatusCreated, schedule)
+}
+
+func (h *PriceScheduleHandler) GetPriceSchedules(c echo.Context) error {
+	id := c.Param("id")
+	if id == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{"message": utils.ErrProductIDRequired.Error()})
+	}
+
+	schedules, err := h.Service.GetByProductID(id)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"message": utils.ErrInternalServer.Error()})
+	}
+
+	return c.JSON(http.StatusOK, schedules)
+}
+
+func (h *PriceScheduleHandler) CancelPriceSchedule(c echo.Context) error {
+	schedule, err := h.Service.Cancel(c, c.Param("id"), c.Param("schedule_id"))
+	if err != nil {
+		switch err {
+		case utils.ErrPriceScheduleIDRequired:
+			return c.JSON(http.StatusBadRequest, map[string]string{"message": err.Error()})
+		case utils.ErrPriceScheduleNotFound:
+			return c.JSON(http.StatusNotFound, map[string]string{"message": err.Error()})
+		case utils.ErrPriceScheduleFinished, utils.ErrProductVersionMismatch:
+			return c.JSON(http.StatusConflict, map[string]string{"message": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"message": utils.ErrInternalServer.Error()})
+	}
+
+	return c.JSON(http.StatusOK, schedule)
+}
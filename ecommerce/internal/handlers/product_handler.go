@@ -2,11 +2,13 @@ package handlers
 
 import (
 	"net/http"
+	"strconv"
 
 	"github.com/YugenDev/global-mobility-test/internal/models"
 	"github.com/YugenDev/global-mobility-test/internal/services"
 	"github.com/YugenDev/global-mobility-test/internal/utils"
 	"github.com/labstack/echo/v4"
+	"go.mongodb.org/mongo-driver/mongo"
 )
 
 type ProductHandler struct {
@@ -20,15 +22,68 @@ func NewProductHandler(service services.IProductService) *ProductHandler {
 }
 
 func (h *ProductHandler) GetAllProducts(c echo.Context) error {
-	products, err := h.Service.GetAll()
+	query, err := parseProductQuery(c)
 	if err != nil {
-		if err == utils.ErrNoProductsFound {
+		return c.JSON(http.StatusBadRequest, map[string]string{"message": err.Error()})
+	}
+
+	page, err := h.Service.GetAll(query)
+	if err != nil {
+		switch err {
+		case utils.ErrNoProductsFound:
 			return c.JSON(http.StatusNotFound, map[string]string{"message": err.Error()})
+		case utils.ErrInvalidPageLimit, utils.ErrInvalidCursor, utils.ErrInvalidSortField, utils.ErrInvalidPriceRange,
+			utils.ErrUnsupportedCurrency, utils.ErrExchangeRateNotFound:
+			return c.JSON(http.StatusBadRequest, map[string]string{"message": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"message": utils.ErrInternalServer.Error()})
+	}
+
+	return c.JSON(http.StatusOK, page)
+}
+
+func (h *ProductHandler) GetTrash(c echo.Context) error {
+	query, err := parseProductQuery(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"message": err.Error()})
+	}
+
+	page, err := h.Service.GetTrash(query)
+	if err != nil {
+		switch err {
+		case utils.ErrInvalidPageLimit, utils.ErrInvalidCursor, utils.ErrInvalidSortField, utils.ErrInvalidPriceRange,
+			utils.ErrUnsupportedCurrency, utils.ErrExchangeRateNotFound:
+			return c.JSON(http.StatusBadRequest, map[string]string{"message": err.Error()})
 		}
 		return c.JSON(http.StatusInternalServerError, map[string]string{"message": utils.ErrInternalServer.Error()})
 	}
 
-	return c.JSON(http.StatusOK, products)
+	return c.JSON(http.StatusOK, page)
+}
+
+func (h *ProductHandler) SearchProducts(c echo.Context) error {
+	query := models.ProductSearchQuery{
+		Text:   c.QueryParam("q"),
+		Cursor: c.QueryParam("cursor"),
+	}
+	if limit := c.QueryParam("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil || n <= 0 {
+			return c.JSON(http.StatusBadRequest, map[string]string{"message": utils.ErrInvalidPageLimit.Error()})
+		}
+		query.Limit = n
+	}
+
+	page, err := h.Service.SearchProducts(query)
+	if err != nil {
+		switch err {
+		case utils.ErrSearchQueryRequired, utils.ErrInvalidPageLimit, utils.ErrInvalidCursor:
+			return c.JSON(http.StatusBadRequest, map[string]string{"message": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"message": utils.ErrInternalServer.Error()})
+	}
+
+	return c.JSON(http.StatusOK, page)
 }
 
 func (h *ProductHandler) GetProductByID(c echo.Context) error {
@@ -42,6 +97,18 @@ func (h *ProductHandler) GetProductByID(c echo.Context) error {
 		return c.JSON(http.StatusNotFound, map[string]string{"message": utils.ErrNoProductsFound.Error()})
 	}
 
+	if currency := c.QueryParam("currency"); currency != "" {
+		product, err = h.Service.PriceIn(product, currency)
+		if err != nil {
+			switch err {
+			case utils.ErrUnsupportedCurrency, utils.ErrExchangeRateNotFound:
+				return c.JSON(http.StatusBadRequest, map[string]string{"message": err.Error()})
+			}
+			return c.JSON(http.StatusInternalServerError, map[string]string{"message": utils.ErrInternalServer.Error()})
+		}
+	}
+
+	setETag(c, product.Version)
 	return c.JSON(http.StatusOK, product)
 }
 
@@ -57,7 +124,7 @@ func (h *ProductHandler) CreateProduct(c echo.Context) error {
 	if product.Description == "" {
 		return c.JSON(http.StatusBadRequest, map[string]string{"message": utils.ErrProductDescriptionRequired.Error()})
 	}
-	if product.Price <= 0 {
+	if product.Price.Amount <= 0 {
 		return c.JSON(http.StatusBadRequest, map[string]string{"message": utils.ErrProductPriceInvalid.Error()})
 	}
 	if product.Stock < 0 {
@@ -73,9 +140,17 @@ func (h *ProductHandler) CreateProduct(c echo.Context) error {
 
 	err := h.Service.CreateProduct(c, &product)
 	if err != nil {
+		switch err {
+		case utils.ErrProductIDAlreadyExists, utils.ErrUnknownCategory, utils.ErrVariantSKURequired,
+			utils.ErrVariantOptionsRequired, utils.ErrVariantPriceInvalid, utils.ErrVariantStockInvalid,
+			utils.ErrVariantSKUExists, utils.ErrDuplicateVariantOptions, utils.ErrImagesNotEditable,
+			utils.ErrUnsupportedCurrency, utils.ErrCurrencyMismatch, utils.ErrInvalidPriceOverride:
+			return c.JSON(http.StatusBadRequest, map[string]string{"message": err.Error()})
+		}
 		return c.JSON(http.StatusInternalServerError, map[string]string{"message": utils.ErrInternalServer.Error()})
 	}
 
+	setETag(c, product.Version)
 	return c.JSON(http.StatusCreated, product)
 }
 
@@ -95,7 +170,7 @@ func (h *ProductHandler) UpdateProduct(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, map[string]string{"message": utils.ErrInvalidRequestPayload.Error()})
 	}
 
-	if product.Price < 0 {
+	if product.Price.Amount < 0 {
 		return c.JSON(http.StatusBadRequest, map[string]string{"message": utils.ErrProductPriceInvalid.Error()})
 	}
 	if product.Stock < 0 {
@@ -106,11 +181,25 @@ func (h *ProductHandler) UpdateProduct(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, map[string]string{"message": utils.ErrProductIDCannotBeChanged.Error()})
 	}
 
+	if version := parseIfMatch(c); version != 0 {
+		product.Version = version
+	}
+
 	err = h.Service.UpdateProduct(c, id, &product)
 	if err != nil {
+		switch err {
+		case utils.ErrProductVersionMismatch:
+			return c.JSON(http.StatusPreconditionFailed, map[string]string{"message": err.Error()})
+		case utils.ErrUnknownCategory, utils.ErrVariantsNotEditable, utils.ErrImagesNotEditable,
+			utils.ErrProductPriceInvalid, utils.ErrUnsupportedCurrency, utils.ErrCurrencyMismatch, utils.ErrInvalidPriceOverride:
+			return c.JSON(http.StatusBadRequest, map[string]string{"message": err.Error()})
+		case utils.ErrProductHasVariants, utils.ErrStockBelowReserved:
+			return c.JSON(http.StatusConflict, map[string]string{"message": err.Error()})
+		}
 		return c.JSON(http.StatusInternalServerError, map[string]string{"message": utils.ErrInternalServer.Error()})
 	}
 
+	setETag(c, product.Version)
 	return c.JSON(http.StatusOK, product)
 }
 
@@ -124,10 +213,131 @@ func (h *ProductHandler) DeleteProduct(c echo.Context) error {
 		return c.JSON(http.StatusNotFound, map[string]string{"message": utils.ErrNoProductsFound.Error()})
 	}
 
-	err := h.Service.DeleteProduct(c, id)
+	err := h.Service.DeleteProduct(c, id, parseIfMatch(c))
+	if err != nil {
+		if err == utils.ErrProductVersionMismatch {
+			return c.JSON(http.StatusPreconditionFailed, map[string]string{"message": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"message": utils.ErrInternalServer.Error()})
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (h *ProductHandler) RestoreProduct(c echo.Context) error {
+	id := c.Param("id")
+	if id == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{"message": utils.ErrProductIDRequired.Error()})
+	}
+
+	product, err := h.Service.RestoreProduct(c, id)
+	if err != nil {
+		if err == utils.ErrProductNotInTrash {
+			return c.JSON(http.StatusNotFound, map[string]string{"message": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"message": utils.ErrInternalServer.Error()})
+	}
+
+	setETag(c, product.Version)
+	return c.JSON(http.StatusOK, product)
+}
+
+func (h *ProductHandler) AdjustStock(c echo.Context) error {
+	id := c.Param("id")
+	if id == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{"message": utils.ErrProductIDRequired.Error()})
+	}
+
+	var adjustment models.StockAdjustment
+	if err := c.Bind(&adjustment); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"message": utils.ErrInvalidRequestPayload.Error()})
+	}
+
+	product, err := h.Service.AdjustStock(c, id, adjustment.Delta)
+	if err != nil {
+		switch err {
+		case utils.ErrInvalidStockDelta:
+			return c.JSON(http.StatusBadRequest, map[string]string{"message": err.Error()})
+		case utils.ErrInsufficientStock, utils.ErrProductHasVariants:
+			return c.JSON(http.StatusConflict, map[string]string{"message": err.Error()})
+		case mongo.ErrNoDocuments:
+			return c.JSON(http.StatusNotFound, map[string]string{"message": utils.ErrNoProductsFound.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"message": utils.ErrInternalServer.Error()})
+	}
+
+	setETag(c, product.Version)
+	return c.JSON(http.StatusOK, product)
+}
+
+func (h *ProductHandler) GetHistory(c echo.Context) error {
+	id := c.Param("id")
+	if id == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{"message": utils.ErrProductIDRequired.Error()})
+	}
+
+	query := models.AuditQuery{ProductID: id, Cursor: c.QueryParam("cursor")}
+	if limit := c.QueryParam("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil || n <= 0 {
+			return c.JSON(http.StatusBadRequest, map[string]string{"message": utils.ErrInvalidPageLimit.Error()})
+		}
+		query.Limit = n
+	}
+
+	page, err := h.Service.GetHistory(query)
 	if err != nil {
+		switch err {
+		case utils.ErrInvalidPageLimit, utils.ErrInvalidCursor:
+			return c.JSON(http.StatusBadRequest, map[string]string{"message": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"message": utils.ErrInternalServer.Error()})
+	}
+
+	return c.JSON(http.StatusOK, page)
+}
+
+func (h *ProductHandler) GetPriceHistory(c echo.Context) error {
+	id := c.Param("id")
+	if id == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{"message": utils.ErrProductIDRequired.Error()})
+	}
+
+	query := models.PriceHistoryQuery{ProductID: id, Cursor: c.QueryParam("cursor")}
+	if limit := c.QueryParam("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil || n <= 0 {
+			return c.JSON(http.StatusBadRequest, map[string]string{"message": utils.ErrInvalidPageLimit.Error()})
+		}
+		query.Limit = n
+	}
+
+	page, err := h.Service.GetPriceHistory(query)
+	if err != nil {
+		switch err {
+		case utils.ErrInvalidPageLimit, utils.ErrInvalidCursor:
+			return c.JSON(http.StatusBadRequest, map[string]string{"message": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"message": utils.ErrInternalServer.Error()})
+	}
+
+	return c.JSON(http.StatusOK, page)
+}
+
+func (h *ProductHandler) BulkProducts(c echo.Context) error {
+	var ops []models.BulkOperation
+	if err := c.Bind(&ops); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"message": utils.ErrInvalidRequestPayload.Error()})
+	}
+
+	response, err := h.Service.BulkProducts(c, ops)
+	if err != nil {
+		switch err {
+		case utils.ErrBulkOperationsRequired, utils.ErrTooManyBulkOperations:
+			return c.JSON(http.StatusBadRequest, map[string]string{"message": err.Error()})
+		}
 		return c.JSON(http.StatusInternalServerError, map[string]string{"message": utils.ErrInternalServer.Error()})
 	}
 
-	return c.JSON(http.StatusNoContent, nil)
+	return c.JSON(http.StatusOK, response)
 }
@@ -0,0 +1,119 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/YugenDev/global-mobility-test/internal/models"
+	"github.com/YugenDev/global-mobility-test/internal/services"
+	"github.com/YugenDev/global-mobility-test/internal/utils"
+	"github.com/labstack/echo/v4"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// imageUploadOverhead is the room left in an upload for the multipart
+// headers and the other form fields next to the image itself.
+const imageUploadOverhead = 64 << 10
+
+type ProductImageHandler struct {
+	Service      services.IProductImageService
+	MaxImageSize int64
+}
+
+func NewProductImageHandler(service services.IProductImageService, maxImageSize int64) *ProductImageHandler {
+	return &ProductImageHandler{
+		Service:      service,
+		MaxImageSize: maxImageSize,
+	}
+}
+
+// UploadImage limits the request body before the multipart form is parsed, so
+// that an oversized upload is rejected before it is written to disk.
+func (h *ProductImageHandler) UploadImage(c echo.Context) error {
+	request := c.Request()
+	request.Body = http.MaxBytesReader(c.Response(), request.Body, h.MaxImageSize+imageUploadOverhead)
+
+	header, err := c.FormFile("file")
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		return c.JSON(http.StatusRequestEntityTooLarge, map[string]string{"message": utils.ErrImageTooLarge.Error()})
+	}
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"message": utils.ErrImageRequired.Error()})
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"message": utils.ErrInternalServer.Error()})
+	}
+	defer file.Close()
+
+	upload := models.ImageUpload{
+		ContentType: header.Header.Get(echo.HeaderContentType),
+		AltText:     c.FormValue("alt_text"),
+		Content:     file,
+	}
+
+	product, err := h.Service.AddImage(c, c.Param("id"), parseIfMatch(c), upload)
+	if err != nil {
+		return imageError(c, err)
+	}
+
+	setETag(c, product.Version)
+	return c.JSON(http.StatusCreated, product)
+}
+
+func (h *ProductImageHandler) ReorderImages(c echo.Context) error {
+	var request models.ImageOrderRequest
+	if err := c.Bind(&request); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"message": utils.ErrInvalidRequestPayload.Error()})
+	}
+
+	product, err := h.Service.ReorderImages(c, c.Param("id"), parseIfMatch(c), request.ImageIDs)
+	if err != nil {
+		return imageError(c, err)
+	}
+
+	setETag(c, product.Version)
+	return c.JSON(http.StatusOK, product)
+}
+
+func (h *ProductImageHandler) DeleteImage(c echo.Context) error {
+	product, err := h.Service.DeleteImage(c, c.Param("id"), c.Param("image_id"), parseIfMatch(c))
+	if err != nil {
+		return imageError(c, err)
+	}
+
+	setETag(c, product.Version)
+	return c.JSON(http.StatusOK, product)
+}
+
+func (h *ProductImageHandler) GetImage(c echo.Context) error {
+	image, content, err := h.Service.OpenImage(c.Param("id"), c.Param("image_id"))
+	if err != nil {
+		return imageError(c, err)
+	}
+	defer content.Close()
+
+	// Image IDs are never reused, so a stored file never changes.
+	c.Response().Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+	return c.Stream(http.StatusOK, image.ContentType, content)
+}
+
+func imageError(c echo.Context, err error) error {
+	switch err {
+	case utils.ErrProductIDRequired, utils.ErrImageRequired, utils.ErrUnsupportedImageType, utils.ErrInvalidImageOrder:
+		return c.JSON(http.StatusBadRequest, map[string]string{"message": err.Error()})
+	case utils.ErrImageTooLarge:
+		return c.JSON(http.StatusRequestEntityTooLarge, map[string]string{"message": err.Error()})
+	case mongo.ErrNoDocuments:
+		return c.JSON(http.StatusNotFound, map[string]string{"message": utils.ErrNoProductsFound.Error()})
+	case utils.ErrImageNotFound:
+		return c.JSON(http.StatusNotFound, map[string]string{"message": err.Error()})
+	case utils.ErrTooManyImages:
+		return c.JSON(http.StatusConflict, map[string]string{"message": err.Error()})
+	case utils.ErrProductVersionMismatch:
+		return c.JSON(http.StatusPreconditionFailed, map[string]string{"message": err.Error()})
+	}
+	return c.JSON(http.StatusInternalServerError, map[string]string{"message": utils.ErrInternalServer.Error()})
+}
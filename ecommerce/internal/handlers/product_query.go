@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/YugenDev/global-mobility-test/internal/models"
+	"github.com/YugenDev/global-mobility-test/internal/utils"
+	"github.com/labstack/echo/v4"
+)
+
+var productQueryParams = map[string]bool{
+	"limit":         true,
+	"cursor":        true,
+	"sort":          true,
+	"min_price":     true,
+	"max_price":     true,
+	"in_stock":      true,
+	"name":          true,
+	"created_after": true,
+	"category_id":   true,
+	"currency":      true,
+}
+
+// parseProductQuery reads the list parameters from the request. Any parameter
+// outside productQueryParams and extra is rejected.
+func parseProductQuery(c echo.Context, extra ...string) (models.ProductQuery, error) {
+	params := c.QueryParams()
+	for key := range params {
+		if !productQueryParams[key] && !slices.Contains(extra, key) {
+			return models.ProductQuery{}, fmt.Errorf("%w: %s", utils.ErrUnknownFilterField, key)
+		}
+	}
+
+	query := models.ProductQuery{
+		Cursor:   params.Get("cursor"),
+		Sort:     params.Get("sort"),
+		Currency: strings.ToUpper(params.Get("currency")),
+		Filter: models.ProductFilter{
+			NamePrefix: params.Get("name"),
+		},
+	}
+
+	if categoryID := params.Get("category_id"); categoryID != "" {
+		query.Filter.CategoryIDs = []string{categoryID}
+	}
+
+	if limit := params.Get("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil || n <= 0 {
+			return models.ProductQuery{}, utils.ErrInvalidPageLimit
+		}
+		query.Limit = n
+	}
+
+	// Price bounds are in the currency the prices are shown in.
+	priceCurrency := query.Currency
+	if priceCurrency == "" {
+		priceCurrency = models.DefaultCurrency
+	}
+
+	if minPrice := params.Get("min_price"); minPrice != "" {
+		value, err := models.ParseMoney(minPrice, priceCurrency)
+		if err == utils.ErrUnsupportedCurrency {
+			return models.ProductQuery{}, err
+		}
+		if err != nil {
+			return models.ProductQuery{}, fmt.Errorf("%w: min_price", utils.ErrInvalidFilterValue)
+		}
+		query.Filter.MinPrice = &value
+	}
+
+	if maxPrice := params.Get("max_price"); maxPrice != "" {
+		value, err := models.ParseMoney(maxPrice, priceCurrency)
+		if err == utils.ErrUnsupportedCurrency {
+			return models.ProductQuery{}, err
+		}
+		if err != nil {
+			return models.ProductQuery{}, fmt.Errorf("%w: max_price", utils.ErrInvalidFilterValue)
+		}
+		query.Filter.MaxPrice = &value
+	}
+
+	if inStock := params.Get("in_stock"); inStock != "" {
+		value, err := strconv.ParseBool(inStock)
+		if err != nil {
+			return models.ProductQuery{}, fmt.Errorf("%w: in_stock", utils.ErrInvalidFilterValue)
+		}
+		query.Filter.InStock = &value
+	}
+
+	if createdAfter := params.Get("created_after"); createdAfter != "" {
+		value, err := time.Parse(time.RFC3339, createdAfter)
+		if err != nil {
+			return models.ProductQuery{}, fmt.Errorf("%w: created_after", utils.ErrInvalidFilterValue)
+		}
+		query.Filter.CreatedAfter = &value
+	}
+
+	return query, nil
+}
@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/YugenDev/global-mobility-test/internal/models"
+	"github.com/YugenDev/global-mobility-test/internal/utils"
+	"github.com/labstack/echo/v4"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func (h *ProductHandler) AddVariant(c echo.Context) error {
+	var variant models.Variant
+	if err := c.Bind(&variant); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"message": utils.ErrInvalidRequestPayload.Error()})
+	}
+
+	product, err := h.Service.AddVariant(c, c.Param("id"), parseIfMatch(c), variant)
+	if err != nil {
+		return variantError(c, err)
+	}
+
+	setETag(c, product.Version)
+	return c.JSON(http.StatusCreated, product)
+}
+
+func (h *ProductHandler) UpdateVariant(c echo.Context) error {
+	var variant models.Variant
+	if err := c.Bind(&variant); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"message": utils.ErrInvalidRequestPayload.Error()})
+	}
+
+	product, err := h.Service.UpdateVariant(c, c.Param("id"), c.Param("sku"), parseIfMatch(c), variant)
+	if err != nil {
+		return variantError(c, err)
+	}
+
+	setETag(c, product.Version)
+	return c.JSON(http.StatusOK, product)
+}
+
+func (h *ProductHandler) RemoveVariant(c echo.Context) error {
+	product, err := h.Service.RemoveVariant(c, c.Param("id"), c.Param("sku"), parseIfMatch(c))
+	if err != nil {
+		return variantError(c, err)
+	}
+
+	setETag(c, product.Version)
+	return c.JSON(http.StatusOK, product)
+}
+
+func (h *ProductHandler) AdjustVariantStock(c echo.Context) error {
+	var adjustment models.StockAdjustment
+	if err := c.Bind(&adjustment); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"message": utils.ErrInvalidRequestPayload.Error()})
+	}
+
+	product, err := h.Service.AdjustVariantStock(c, c.Param("id"), c.Param("sku"), adjustment.Delta)
+	if err != nil {
+		return variantError(c, err)
+	}
+
+	setETag(c, product.Version)
+	return c.JSON(http.StatusOK, product)
+}
+
+func variantError(c echo.Context, err error) error {
+	switch err {
+	case utils.ErrProductIDRequired, utils.ErrVariantSKURequired, utils.ErrVariantSKUCannotBeChanged,
+		utils.ErrVariantOptionsRequired, utils.ErrVariantPriceInvalid, utils.ErrVariantStockInvalid,
+		utils.ErrInvalidStockDelta, utils.ErrCurrencyMismatch:
+		return c.JSON(http.StatusBadRequest, map[string]string{"message": err.Error()})
+	case mongo.ErrNoDocuments:
+		return c.JSON(http.StatusNotFound, map[string]string{"message": utils.ErrNoProductsFound.Error()})
+	case utils.ErrVariantNotFound:
+		return c.JSON(http.StatusNotFound, map[string]string{"message": err.Error()})
+	case utils.ErrVariantSKUExists, utils.ErrDuplicateVariantOptions, utils.ErrInsufficientStock:
+		return c.JSON(http.StatusConflict, map[string]string{"message": err.Error()})
+	case utils.ErrProductVersionMismatch:
+		return c.JSON(http.StatusPreconditionFailed, map[string]string{"message": err.Error()})
+	}
+	return c.JSON(http.StatusInternalServerError, map[string]string{"message": utils.ErrInternalServer.Error()})
+}
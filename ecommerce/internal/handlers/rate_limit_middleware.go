@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"log"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/YugenDev/global-mobility-test/internal/models"
+	"github.com/YugenDev/global-mobility-test/internal/services"
+	"github.com/YugenDev/global-mobility-test/internal/utils"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	HeaderRateLimitLimit     = "RateLimit-Limit"
+	HeaderRateLimitRemaining = "RateLimit-Remaining"
+	HeaderRateLimitReset     = "RateLimit-Reset"
+)
+
+// RateLimit limits each client, identified by its API key, its user or
+// else its IP address, and answers 429 once the client's bucket is empty.
+// It must run after Authenticate, which charges the requests it rejects to
+// their IP address. If the limiter fails, the request is let through rather
+// than failing it.
+func RateLimit(limiter services.IRateLimiter) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if limited, err := takeRateLimit(c, limiter, rateLimitClient(c)); limited {
+				return err
+			}
+			return next(c)
+		}
+	}
+}
+
+// takeRateLimit takes a token from client's bucket for the request's route
+// and sets the rate limit headers. When the bucket is empty it answers 429
+// and reports the request as limited.
+func takeRateLimit(c echo.Context, limiter services.IRateLimiter, client string) (bool, error) {
+	route := models.RateLimitRoute(c.Request().Method, c.Path())
+	result, err := limiter.Allow(client, route)
+	if err != nil {
+		log.Println("Error checking rate limit: ", err)
+		return false, nil
+	}
+	if result.Limit == 0 {
+		return false, nil
+	}
+
+	header := c.Response().Header()
+	header.Set(HeaderRateLimitLimit, strconv.Itoa(result.Limit))
+	header.Set(HeaderRateLimitRemaining, strconv.Itoa(result.Remaining))
+	header.Set(HeaderRateLimitReset, strconv.Itoa(ceilSeconds(result.Reset)))
+
+	if !result.Allowed {
+		header.Set(echo.HeaderRetryAfter, strconv.Itoa(ceilSeconds(result.RetryAfter)))
+		return true, c.JSON(http.StatusTooManyRequests, map[string]string{"message": utils.ErrRateLimitExceeded.Error()})
+	}
+	return false, nil
+}
+
+// IPExtractor finds the client's IP address for rate limits. Without trusted
+// proxies it is the address the request came from; otherwise
+// X-Forwarded-For is read, but only hops added by a trusted proxy are
+// believed, so that clients can't pick a fresh address for every request.
+func IPExtractor(trustedProxies []*net.IPNet) echo.IPExtractor {
+	if len(trustedProxies) == 0 {
+		return echo.ExtractIPDirect()
+	}
+	options := []echo.TrustOption{echo.TrustLoopback(false), echo.TrustLinkLocal(false), echo.TrustPrivateNet(false)}
+	for _, proxy := range trustedProxies {
+		options = append(options, echo.TrustIPRange(proxy))
+	}
+	return echo.ExtractIPFromXFFHeader(options...)
+}
+
+func rateLimitClient(c echo.Context) string {
+	if identity, ok := services.IdentityFromContext(c); ok {
+		return identity.Principal()
+	}
+	return anonymousClient(c)
+}
+
+func anonymousClient(c echo.Context) string {
+	return "ip:" + c.RealIP()
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/YugenDev/global-mobility-test/internal/models"
+	"github.com/YugenDev/global-mobility-test/internal/services"
+	"github.com/YugenDev/global-mobility-test/internal/utils"
+	"github.com/labstack/echo/v4"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type ReservationHandler struct {
+	Service services.IReservationService
+}
+
+func NewReservationHandler(service services.IReservationService) *ReservationHandler {
+	return &ReservationHandler{
+		Service: service,
+	}
+}
+
+func (h *ReservationHandler) CreateReservation(c echo.Context) error {
+	id := c.Param("id")
+	if id == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{"message": utils.ErrProductIDRequired.Error()})
+	}
+
+	var request models.ReservationRequest
+	if err := c.Bind(&request); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"message": utils.ErrInvalidRequestPayload.Error()})
+	}
+
+	reservation, err := h.Service.Reserve(c, id, request.Quantity)
+	if err != nil {
+		switch err {
+		case utils.ErrInvalidReservationQuantity:
+			return c.JSON(http.StatusBadRequest, map[string]string{"message": err.Error()})
+		case utils.ErrInsufficientStock, utils.ErrProductHasVariants:
+			return c.JSON(http.StatusConflict, map[string]string{"message": err.Error()})
+		case mongo.ErrNoDocuments:
+			return c.JSON(http.StatusNotFound, map[string]string{"message": utils.ErrNoProductsFound.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"message": utils.ErrInternalServer.Error()})
+	}
+
+	return c.JSON(http.StatusCreated, reservation)
+}
+
+func (h *ReservationHandler) GetReservation(c echo.Context) error {
+	reservation, err := h.Service.GetByID(c, c.Param("id"))
+	if err != nil {
+		return reservationError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, reservation)
+}
+
+func (h *ReservationHandler) ConfirmReservation(c echo.Context) error {
+	reservation, err := h.Service.Confirm(c, c.Param("id"))
+	if err != nil {
+		return reservationError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, reservation)
+}
+
+func (h *ReservationHandler) CancelReservation(c echo.Context) error {
+	reservation, err := h.Service.Cancel(c, c.Param("id"))
+	if err != nil {
+		return reservationError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, reservation)
+}
+
+func reservationError(c echo.Context, err error) error {
+	switch err {
+	case utils.ErrReservationIDRequired:
+		return c.JSON(http.StatusBadRequest, map[string]string{"message": err.Error()})
+	case utils.ErrReservationNotFound:
+		return c.JSON(http.StatusNotFound, map[string]string{"message": err.Error()})
+	case utils.ErrReservationNotActive, utils.ErrReservationExpired:
+		return c.JSON(http.StatusConflict, map[string]string{"message": err.Error()})
+	}
+	return c.JSON(http.StatusInternalServerError, map[string]string{"message": utils.ErrInternalServer.Error()})
+}
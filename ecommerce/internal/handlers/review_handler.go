@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/YugenDev/global-mobility-test/internal/models"
+	"github.com/YugenDev/global-mobility-test/internal/services"
+	"github.com/YugenDev/global-mobility-test/internal/utils"
+	"github.com/labstack/echo/v4"
+)
+
+type ReviewHandler struct {
+	Service services.IReviewService
+}
+
+func NewReviewHandler(service services.IReviewService) *ReviewHandler {
+	return &ReviewHandler{
+		Service: service,
+	}
+}
+
+func (h *ReviewHandler) CreateReview(c echo.Context) error {
+	var review models.Review
+	if err := c.Bind(&review); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"message": utils.ErrInvalidRequestPayload.Error()})
+	}
+
+	if err := h.Service.Create(c.Param("id"), &review); err != nil {
+		return reviewError(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, review)
+}
+
+// GetProductReviews lists a product's approved reviews. The status can't be
+// chosen here, so that unmoderated and rejected reviews stay private.
+func (h *ReviewHandler) GetProductReviews(c echo.Context) error {
+	id := c.Param("id")
+	if id == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{"message": utils.ErrProductIDRequired.Error()})
+	}
+	return h.getReviews(c, id, models.ReviewStatusApproved)
+}
+
+// GetReviews is the moderation queue: pending reviews of every product, or
+// those in the status given.
+func (h *ReviewHandler) GetReviews(c echo.Context) error {
+	status := c.QueryParam("status")
+	if status == "" {
+		status = models.ReviewStatusPending
+	}
+	return h.getReviews(c, "", status)
+}
+
+func (h *ReviewHandler) ModerateReview(c echo.Context) error {
+	var request models.ReviewStatusRequest
+	if err := c.Bind(&request); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"message": utils.ErrInvalidRequestPayload.Error()})
+	}
+
+	review, err := h.Service.Moderate(c.Param("id"), request.Status)
+	if err != nil {
+		return reviewError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, review)
+}
+
+func (h *ReviewHandler) getReviews(c echo.Context, productID string, status string) error {
+	query := models.ReviewQuery{ProductID: productID, Status: status, Cursor: c.QueryParam("cursor")}
+	if limit := c.QueryParam("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil || n <= 0 {
+			return c.JSON(http.StatusBadRequest, map[string]string{"message": utils.ErrInvalidPageLimit.Error()})
+		}
+		query.Limit = n
+	}
+
+	page, err := h.Service.GetReviews(query)
+	if err != nil {
+		return reviewError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, page)
+}
+
+func reviewError(c echo.Context, err error) error {
+	switch err {
+	case utils.ErrProductIDRequired, utils.ErrReviewIDRequired, utils.ErrInvalidReviewRating, utils.ErrReviewTextRequired,
+		utils.ErrReviewTextTooLong, utils.ErrInvalidReviewStatus, utils.ErrInvalidPageLimit, utils.ErrInvalidCursor:
+		return c.JSON(http.StatusBadRequest, map[string]string{"message": err.Error()})
+	case utils.ErrNoProductsFound, utils.ErrReviewNotFound:
+		return c.JSON(http.StatusNotFound, map[string]string{"message": err.Error()})
+	case utils.ErrReviewStatusChanged:
+		return c.JSON(http.StatusConflict, map[string]string{"message": err.Error()})
+	}
+	return c.JSON(http.StatusInternalServerError, map[string]string{"message": utils.ErrInternalServer.Error()})
+}
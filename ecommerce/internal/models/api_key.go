@@ -0,0 +1,38 @@
+package models
+
+import (
+	"time"
+)
+
+// APIKeyScopes are the permissions an API key may be granted.
+var APIKeyScopes = map[string]bool{
+	PermissionProductsWrite: true,
+	PermissionStockAdjust:   true,
+}
+
+// APIKey lets a service call the API without a user. Only a hash of the key
+// is stored; the key itself is shown once, when it is created.
+type APIKey struct {
+	KeyID      string     `bson:"key_id" json:"key_id"`
+	Name       string     `bson:"name" json:"name"`
+	Prefix     string     `bson:"prefix" json:"prefix"`
+	KeyHash    string     `bson:"key_hash" json:"-"`
+	Scopes     []string   `bson:"scopes" json:"scopes"`
+	ExpiresAt  *time.Time `bson:"expires_at,omitempty" json:"expires_at,omitempty"`
+	LastUsedAt *time.Time `bson:"last_used_at,omitempty" json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `bson:"revoked_at,omitempty" json:"revoked_at,omitempty"`
+	CreatedBy  string     `bson:"created_by" json:"created_by"`
+	CreatedAt  time.Time  `bson:"created_at" json:"created_at"`
+}
+
+type APIKeyRequest struct {
+	Name      string     `json:"name"`
+	Scopes    []string   `json:"scopes"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// CreatedAPIKey is an API key together with the key itself.
+type CreatedAPIKey struct {
+	APIKey
+	Key string `json:"key"`
+}
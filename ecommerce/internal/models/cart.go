@@ -0,0 +1,48 @@
+package models
+
+import (
+	"time"
+)
+
+type Cart struct {
+	CartID    string     `bson:"cart_id" json:"cart_id"`
+	Items     []CartItem `bson:"items" json:"items"`
+	ItemCount int        `bson:"item_count" json:"item_count"`
+	Total     Money      `bson:"total" json:"total"`
+	Version   int        `bson:"version" json:"version"`
+	CreatedAt time.Time  `bson:"created_at" json:"created_at"`
+	UpdatedAt time.Time  `bson:"updated_at" json:"updated_at"`
+	ExpiresAt time.Time  `bson:"expires_at" json:"expires_at"`
+}
+
+// CartItem keeps the product's name and price as they were when the item was
+// added, so later price changes do not alter the cart.
+type CartItem struct {
+	ProductID string `bson:"product_id" json:"product_id"`
+	SKU       string `bson:"sku,omitempty" json:"sku,omitempty"`
+	Name      string `bson:"name" json:"name"`
+	UnitPrice Money  `bson:"unit_price" json:"unit_price"`
+	Quantity  int    `bson:"quantity" json:"quantity"`
+	LineTotal Money  `bson:"line_total" json:"line_total"`
+}
+
+type CartItemRequest struct {
+	ProductID string `json:"product_id"`
+	SKU       string `json:"sku,omitempty"`
+	Quantity  int    `json:"quantity"`
+}
+
+// Recalculate refreshes the line totals and the cart totals from the items,
+// which all share one currency.
+func (c *Cart) Recalculate() {
+	c.ItemCount = 0
+	c.Total = Money{Currency: DefaultCurrency}
+	if len(c.Items) > 0 {
+		c.Total.Currency = c.Items[0].UnitPrice.Currency
+	}
+	for i := range c.Items {
+		c.Items[i].LineTotal = c.Items[i].UnitPrice.Times(c.Items[i].Quantity)
+		c.ItemCount += c.Items[i].Quantity
+		c.Total = c.Total.Plus(c.Items[i].LineTotal)
+	}
+}
@@ -0,0 +1,20 @@
+package models
+
+import (
+	"time"
+)
+
+type Category struct {
+	CategoryID string    `bson:"category_id" json:"category_id"`
+	Name       string    `bson:"name" json:"name"`
+	Slug       string    `bson:"slug" json:"slug"`
+	ParentID   string    `bson:"parent_id,omitempty" json:"parent_id,omitempty"`
+	Position   int       `bson:"position" json:"position"`
+	CreatedAt  time.Time `bson:"created_at" json:"created_at"`
+	UpdatedAt  time.Time `bson:"updated_at" json:"updated_at"`
+}
+
+type CategoryNode struct {
+	Category
+	Children []CategoryNode `json:"children"`
+}
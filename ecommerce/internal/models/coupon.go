@@ -0,0 +1,59 @@
+package models
+
+import (
+	"time"
+)
+
+const (
+	CouponTypePercentage = "percentage"
+	CouponTypeFixed      = "fixed"
+	CouponTypeBuyXGetY   = "buy_x_get_y"
+)
+
+var CouponTypes = map[string]bool{
+	CouponTypePercentage: true,
+	CouponTypeFixed:      true,
+	CouponTypeBuyXGetY:   true,
+}
+
+// Coupon is a promotion applied by code. Percentage coupons take Percent off
+// every covered line; fixed coupons take Amount off the covered lines
+// together; buy_x_get_y coupons take Percent (all of it when unset) off the
+// cheapest GetQuantity units of every BuyQuantity+GetQuantity covered units.
+// A coupon with no ProductIDs and no CategoryIDs covers every line;
+// CategoryIDs also cover their descendants. UsageLimit 0 means unlimited.
+type Coupon struct {
+	CouponID    string     `bson:"coupon_id" json:"coupon_id"`
+	Code        string     `bson:"code" json:"code"`
+	Type        string     `bson:"type" json:"type"`
+	Percent     int        `bson:"percent,omitempty" json:"percent,omitempty"`
+	Amount      *Money     `bson:"amount,omitempty" json:"amount,omitempty"`
+	BuyQuantity int        `bson:"buy_quantity,omitempty" json:"buy_quantity,omitempty"`
+	GetQuantity int        `bson:"get_quantity,omitempty" json:"get_quantity,omitempty"`
+	MinSubtotal *Money     `bson:"min_subtotal,omitempty" json:"min_subtotal,omitempty"`
+	ProductIDs  []string   `bson:"product_ids,omitempty" json:"product_ids,omitempty"`
+	CategoryIDs []string   `bson:"category_ids,omitempty" json:"category_ids,omitempty"`
+	UsageLimit  int        `bson:"usage_limit" json:"usage_limit"`
+	UsageCount  int        `bson:"usage_count" json:"usage_count"`
+	StartsAt    *time.Time `bson:"starts_at,omitempty" json:"starts_at,omitempty"`
+	EndsAt      *time.Time `bson:"ends_at,omitempty" json:"ends_at,omitempty"`
+	CreatedAt   time.Time  `bson:"created_at" json:"created_at"`
+	UpdatedAt   time.Time  `bson:"updated_at" json:"updated_at"`
+}
+
+type CouponEvaluationRequest struct {
+	Code   string            `json:"code"`
+	CartID string            `json:"cart_id,omitempty"`
+	Items  []CartItemRequest `json:"items,omitempty"`
+}
+
+// CouponEvaluation is the result of applying a coupon: every item with its
+// discount, and the totals before and after it.
+type CouponEvaluation struct {
+	CouponID string      `json:"coupon_id"`
+	Code     string      `json:"code"`
+	Items    []OrderItem `json:"items"`
+	Subtotal Money       `json:"subtotal"`
+	Discount Money       `json:"discount"`
+	Total    Money       `json:"total"`
+}
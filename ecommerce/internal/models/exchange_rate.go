@@ -0,0 +1,179 @@
+package models
+
+import (
+	"encoding/json"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/YugenDev/global-mobility-test/internal/utils"
+)
+
+const (
+	RoundHalfUp   = "half_up"
+	RoundHalfEven = "half_even"
+	RoundDown     = "down"
+	RoundUp       = "up"
+)
+
+var RoundingModes = map[string]bool{
+	RoundHalfUp:   true,
+	RoundHalfEven: true,
+	RoundDown:     true,
+	RoundUp:       true,
+}
+
+// RoundingRule says how converted amounts are rounded in a currency. Down and
+// up round toward and away from zero. Increment is the step, in minor units,
+// that amounts are rounded to, such as 5 for Swiss cash prices; it defaults
+// to 1.
+type RoundingRule struct {
+	Mode      string `json:"mode"`
+	Increment int64  `json:"increment,omitempty"`
+}
+
+// ExchangeRates holds how many units of each currency one unit of Base is
+// worth, and the rounding rules for amounts converted into each currency.
+// Currencies without a rule round half up to the minor unit.
+type ExchangeRates struct {
+	Base      string                  `json:"base"`
+	Rates     map[string]json.Number  `json:"rates"`
+	Rounding  map[string]RoundingRule `json:"rounding,omitempty"`
+	UpdatedAt time.Time               `json:"updated_at,omitempty"`
+}
+
+// Normalize upper-cases the currency codes, defaults the base to USD and
+// rounding modes to half up, and checks that every currency is supported,
+// every rate is positive and every rounding rule is valid.
+func (r *ExchangeRates) Normalize() error {
+	r.Base = strings.ToUpper(strings.TrimSpace(r.Base))
+	if r.Base == "" {
+		r.Base = DefaultCurrency
+	}
+	if !SupportedCurrency(r.Base) {
+		return utils.ErrUnsupportedCurrency
+	}
+
+	normalized := make(map[string]RoundingRule, len(r.Rounding))
+	for currency, rule := range r.Rounding {
+		currency = strings.ToUpper(currency)
+		if !SupportedCurrency(currency) {
+			return utils.ErrUnsupportedCurrency
+		}
+		if rule.Mode == "" {
+			rule.Mode = RoundHalfUp
+		}
+		if !RoundingModes[rule.Mode] || rule.Increment < 0 {
+			return utils.ErrInvalidRoundingRule
+		}
+		normalized[currency] = rule
+	}
+	r.Rounding = normalized
+
+	values := make(map[string]json.Number, len(r.Rates))
+	for currency, value := range r.Rates {
+		currency = strings.ToUpper(currency)
+		if !SupportedCurrency(currency) {
+			return utils.ErrUnsupportedCurrency
+		}
+		rate, ok := new(big.Rat).SetString(value.String())
+		if !ok || rate.Sign() <= 0 || currency == r.Base {
+			return utils.ErrInvalidExchangeRate
+		}
+		values[currency] = value
+	}
+	r.Rates = values
+
+	return nil
+}
+
+// Rate returns the value of one unit of Base in currency.
+func (r ExchangeRates) Rate(currency string) (*big.Rat, bool) {
+	if currency == r.Base {
+		return big.NewRat(1, 1), true
+	}
+	value, ok := r.Rates[currency]
+	if !ok {
+		return nil, false
+	}
+	rate, ok := new(big.Rat).SetString(value.String())
+	if !ok || rate.Sign() <= 0 {
+		return nil, false
+	}
+	return rate, true
+}
+
+// Convert expresses m in currency, going through Base when neither side is
+// Base. The result is rounded once, with currency's rounding rule.
+func (r ExchangeRates) Convert(m Money, currency string) (Money, error) {
+	if m.Currency == currency {
+		return m, nil
+	}
+	if !SupportedCurrency(currency) || !SupportedCurrency(m.Currency) {
+		return Money{}, utils.ErrUnsupportedCurrency
+	}
+
+	from, ok := r.Rate(m.Currency)
+	if !ok {
+		return Money{}, utils.ErrExchangeRateNotFound
+	}
+	to, ok := r.Rate(currency)
+	if !ok {
+		return Money{}, utils.ErrExchangeRateNotFound
+	}
+
+	value := new(big.Rat).SetInt64(m.Amount)
+	value.Mul(value, to)
+	value.Quo(value, from)
+
+	shift := CurrencyExponents[currency] - CurrencyExponents[m.Currency]
+	scale := new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(abs(shift))), nil))
+	if shift >= 0 {
+		value.Mul(value, scale)
+	} else {
+		value.Quo(value, scale)
+	}
+
+	return Money{Amount: r.Rounding[currency].Round(value), Currency: currency}, nil
+}
+
+// Round rounds value, an amount in minor units, to a multiple of the rule's
+// increment.
+func (rule RoundingRule) Round(value *big.Rat) int64 {
+	increment := rule.Increment
+	if increment <= 0 {
+		increment = 1
+	}
+
+	steps := new(big.Rat).Quo(value, new(big.Rat).SetInt64(increment))
+	quotient, remainder := new(big.Int).QuoRem(steps.Num(), steps.Denom(), new(big.Int))
+
+	if remainder.Sign() != 0 {
+		half := new(big.Int).Lsh(new(big.Int).Abs(remainder), 1).Cmp(steps.Denom())
+
+		var away bool
+		switch rule.Mode {
+		case RoundUp:
+			away = true
+		case RoundDown:
+			away = false
+		case RoundHalfEven:
+			away = half > 0 || (half == 0 && quotient.Bit(0) == 1)
+		default:
+			away = half >= 0
+		}
+
+		if away {
+			quotient.Add(quotient, big.NewInt(int64(steps.Sign())))
+		}
+	}
+
+	return quotient.Int64() * increment
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,26 @@
+package models
+
+import (
+	"time"
+)
+
+// IdempotencyRecord is the response to the first request sent with an
+// Idempotency-Key. Status is 0 while that request is still being handled.
+// Fingerprint identifies the request, so that a key reused for a different
+// one can be told apart from a retry. ETag and Location are replayed along
+// with the body.
+type IdempotencyRecord struct {
+	Key         string    `bson:"key" json:"key"`
+	Fingerprint string    `bson:"fingerprint" json:"fingerprint"`
+	Status      int       `bson:"status" json:"status"`
+	ContentType string    `bson:"content_type,omitempty" json:"content_type,omitempty"`
+	ETag        string    `bson:"etag,omitempty" json:"etag,omitempty"`
+	Location    string    `bson:"location,omitempty" json:"location,omitempty"`
+	Body        []byte    `bson:"body,omitempty" json:"body,omitempty"`
+	CreatedAt   time.Time `bson:"created_at" json:"created_at"`
+	ExpiresAt   time.Time `bson:"expires_at" json:"expires_at"`
+}
+
+func (r IdempotencyRecord) Completed() bool {
+	return r.Status != 0
+}
@@ -0,0 +1,205 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/YugenDev/global-mobility-test/internal/utils"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+)
+
+// DefaultCurrency is assumed for amounts given without a currency and for
+// prices stored before amounts carried one.
+const DefaultCurrency = "USD"
+
+// CurrencyExponents maps the supported ISO 4217 currencies to the number of
+// decimal places of their minor unit.
+var CurrencyExponents = map[string]int{
+	"USD": 2,
+	"EUR": 2,
+	"GBP": 2,
+	"CAD": 2,
+	"AUD": 2,
+	"CHF": 2,
+	"MXN": 2,
+	"BRL": 2,
+	"COP": 2,
+	"JPY": 0,
+	"KRW": 0,
+	"CLP": 0,
+	"KWD": 3,
+	"BHD": 3,
+}
+
+// Money is an exact amount in the minor unit of its currency, such as cents
+// for USD. It is stored as {amount, currency} and rendered in JSON with the
+// amount as a decimal string.
+type Money struct {
+	Amount   int64  `bson:"amount"`
+	Currency string `bson:"currency"`
+}
+
+// moneyDocument has Money's fields without its BSON decoding method.
+type moneyDocument Money
+
+func SupportedCurrency(currency string) bool {
+	_, ok := CurrencyExponents[currency]
+	return ok
+}
+
+// ParseMoney reads a decimal amount such as "29.99" in currency. The amount
+// may not have more decimal places than the currency's minor unit.
+func ParseMoney(value string, currency string) (Money, error) {
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	exponent, ok := CurrencyExponents[currency]
+	if !ok {
+		return Money{}, utils.ErrUnsupportedCurrency
+	}
+
+	value = strings.TrimSpace(value)
+	negative := strings.HasPrefix(value, "-")
+	value = strings.TrimPrefix(value, "-")
+
+	whole, fraction, hasPoint := strings.Cut(value, ".")
+	if whole == "" || (hasPoint && fraction == "") || len(fraction) > exponent || !isDigits(whole) || !isDigits(fraction) {
+		return Money{}, utils.ErrInvalidMoneyAmount
+	}
+
+	amount, err := strconv.ParseInt(whole+fraction+strings.Repeat("0", exponent-len(fraction)), 10, 64)
+	if err != nil {
+		return Money{}, utils.ErrInvalidMoneyAmount
+	}
+	if negative {
+		amount = -amount
+	}
+
+	return Money{Amount: amount, Currency: currency}, nil
+}
+
+// String renders the amount with the currency's decimal places, without the
+// currency code.
+func (m Money) String() string {
+	exponent := CurrencyExponents[m.Currency]
+
+	sign := ""
+	amount := uint64(m.Amount)
+	if m.Amount < 0 {
+		sign = "-"
+		amount = uint64(-m.Amount)
+	}
+
+	digits := strconv.FormatUint(amount, 10)
+	if exponent == 0 {
+		return sign + digits
+	}
+	if len(digits) <= exponent {
+		digits = strings.Repeat("0", exponent-len(digits)+1) + digits
+	}
+	return sign + digits[:len(digits)-exponent] + "." + digits[len(digits)-exponent:]
+}
+
+// IsZero reports whether the money is unset, which lets omitempty skip it.
+func (m Money) IsZero() bool {
+	return m.Amount == 0 && m.Currency == ""
+}
+
+func (m Money) Times(quantity int) Money {
+	return Money{Amount: m.Amount * int64(quantity), Currency: m.Currency}
+}
+
+// Plus adds other, which the caller must have checked is in the same
+// currency.
+func (m Money) Plus(other Money) Money {
+	return Money{Amount: m.Amount + other.Amount, Currency: m.Currency}
+}
+
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Amount   string `json:"amount"`
+		Currency string `json:"currency"`
+	}{m.String(), m.Currency})
+}
+
+// UnmarshalJSON accepts {"amount": "29.99", "currency": "EUR"}, or a bare
+// amount in the default currency. Amounts may be strings or numbers; the
+// number's literal text is parsed, so no float rounding happens.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+
+	amount := json.RawMessage(data)
+	currency := DefaultCurrency
+	if bytes.HasPrefix(data, []byte("{")) {
+		var object struct {
+			Amount   json.RawMessage `json:"amount"`
+			Currency string          `json:"currency"`
+		}
+		if err := json.Unmarshal(data, &object); err != nil {
+			return err
+		}
+		amount = object.Amount
+		if object.Currency != "" {
+			currency = object.Currency
+		}
+	}
+
+	var text string
+	if bytes.HasPrefix(amount, []byte(`"`)) {
+		if err := json.Unmarshal(amount, &text); err != nil {
+			return err
+		}
+	} else {
+		var number json.Number
+		if err := json.Unmarshal(amount, &number); err != nil {
+			return utils.ErrInvalidMoneyAmount
+		}
+		text = number.String()
+	}
+
+	parsed, err := ParseMoney(text, currency)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+// UnmarshalBSONValue also reads prices stored as plain numbers before they
+// carried a currency, treating them as amounts in the default currency.
+func (m *Money) UnmarshalBSONValue(t bsontype.Type, data []byte) error {
+	value := bson.RawValue{Type: t, Value: data}
+	scale := math.Pow10(CurrencyExponents[DefaultCurrency])
+
+	switch t {
+	case bson.TypeEmbeddedDocument:
+		*m = Money{}
+		return bson.Unmarshal(data, (*moneyDocument)(m))
+	case bson.TypeDouble:
+		*m = Money{Amount: int64(math.Round(value.Double() * scale)), Currency: DefaultCurrency}
+	case bson.TypeInt32:
+		*m = Money{Amount: int64(value.Int32()) * int64(scale), Currency: DefaultCurrency}
+	case bson.TypeInt64:
+		*m = Money{Amount: value.Int64() * int64(scale), Currency: DefaultCurrency}
+	case bson.TypeNull:
+		*m = Money{}
+	default:
+		return fmt.Errorf("cannot decode BSON %s as money", t)
+	}
+	return nil
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,105 @@
+package models
+
+import (
+	"time"
+)
+
+const (
+	OrderStatusPending   = "pending"
+	OrderStatusPaid      = "paid"
+	OrderStatusShipped   = "shipped"
+	OrderStatusDelivered = "delivered"
+	OrderStatusCancelled = "cancelled"
+)
+
+var OrderStatuses = map[string]bool{
+	OrderStatusPending:   true,
+	OrderStatusPaid:      true,
+	OrderStatusShipped:   true,
+	OrderStatusDelivered: true,
+	OrderStatusCancelled: true,
+}
+
+// OrderTransitions lists the statuses an order may move to from each status.
+// Delivered and cancelled orders are final.
+var OrderTransitions = map[string][]string{
+	OrderStatusPending: {OrderStatusPaid, OrderStatusCancelled},
+	OrderStatusPaid:    {OrderStatusShipped, OrderStatusCancelled},
+	OrderStatusShipped: {OrderStatusDelivered},
+}
+
+func CanTransitionOrder(from, to string) bool {
+	for _, status := range OrderTransitions[from] {
+		if status == to {
+			return true
+		}
+	}
+	return false
+}
+
+type Order struct {
+	OrderID    string              `bson:"order_id" json:"order_id"`
+	CartID     string              `bson:"cart_id,omitempty" json:"cart_id,omitempty"`
+	Items      []OrderItem         `bson:"items" json:"items"`
+	ItemCount  int                 `bson:"item_count" json:"item_count"`
+	CouponID   string              `bson:"coupon_id,omitempty" json:"coupon_id,omitempty"`
+	CouponCode string              `bson:"coupon_code,omitempty" json:"coupon_code,omitempty"`
+	Discount   *Money              `bson:"discount,omitempty" json:"discount,omitempty"`
+	Total      Money               `bson:"total" json:"total"`
+	Status     string              `bson:"status" json:"status"`
+	History    []OrderStatusChange `bson:"history" json:"history"`
+	CreatedAt  time.Time           `bson:"created_at" json:"created_at"`
+	UpdatedAt  time.Time           `bson:"updated_at" json:"updated_at"`
+}
+
+// OrderItem freezes the product's name and price at checkout. Discount is
+// the part of LineTotal taken off by the order's coupon.
+type OrderItem struct {
+	ProductID string `bson:"product_id" json:"product_id"`
+	SKU       string `bson:"sku,omitempty" json:"sku,omitempty"`
+	Name      string `bson:"name" json:"name"`
+	UnitPrice Money  `bson:"unit_price" json:"unit_price"`
+	Quantity  int    `bson:"quantity" json:"quantity"`
+	LineTotal Money  `bson:"line_total" json:"line_total"`
+	Discount  *Money `bson:"discount,omitempty" json:"discount,omitempty"`
+}
+
+type OrderStatusChange struct {
+	Status string    `bson:"status" json:"status"`
+	At     time.Time `bson:"at" json:"at"`
+}
+
+type CheckoutRequest struct {
+	CartID     string            `json:"cart_id,omitempty"`
+	Items      []CartItemRequest `json:"items,omitempty"`
+	CouponCode string            `json:"coupon_code,omitempty"`
+}
+
+type OrderStatusRequest struct {
+	Status string `json:"status"`
+}
+
+// Recalculate refreshes the line totals and the order totals from the items,
+// which all share one currency. The total is net of the items' discounts.
+func (o *Order) Recalculate() {
+	o.ItemCount = 0
+	o.Total = Money{Currency: DefaultCurrency}
+	if len(o.Items) > 0 {
+		o.Total.Currency = o.Items[0].UnitPrice.Currency
+	}
+	discount := Money{Currency: o.Total.Currency}
+	for i := range o.Items {
+		o.Items[i].LineTotal = o.Items[i].UnitPrice.Times(o.Items[i].Quantity)
+		o.ItemCount += o.Items[i].Quantity
+		o.Total = o.Total.Plus(o.Items[i].LineTotal)
+		if o.Items[i].Discount != nil {
+			discount = discount.Plus(*o.Items[i].Discount)
+		}
+	}
+
+	o.Discount = nil
+	if o.CouponID != "" {
+		o.Discount = &discount
+		o.Total.Amount -= discount.Amount
+	}
+}
@@ -0,0 +1,39 @@
+package models
+
+import (
+	"time"
+)
+
+const (
+	PriceSourceManual   = "manual"
+	PriceSourceSchedule = "schedule"
+)
+
+// PriceHistoryEntry records a price that became effective for a product.
+type PriceHistoryEntry struct {
+	EntryID     string    `bson:"entry_id" json:"entry_id"`
+	ProductID   string    `bson:"product_id" json:"product_id"`
+	Price       Money     `bson:"price" json:"price"`
+	Source      string    `bson:"source" json:"source"`
+	ScheduleID  string    `bson:"schedule_id,omitempty" json:"schedule_id,omitempty"`
+	Actor       string    `bson:"actor" json:"actor"`
+	EffectiveAt time.Time `bson:"effective_at" json:"effective_at"`
+}
+
+type PriceHistoryQuery struct {
+	ProductID string
+	Limit     int
+	Cursor    string
+	After     *PriceHistoryCursor
+}
+
+type PriceHistoryCursor struct {
+	EffectiveAt time.Time `json:"effective_at"`
+	EntryID     string    `json:"entry_id"`
+}
+
+type PriceHistoryPage struct {
+	Entries    []PriceHistoryEntry `json:"entries"`
+	NextCursor string              `json:"next_cursor,omitempty"`
+	HasMore    bool                `json:"has_more"`
+}
@@ -0,0 +1,34 @@
+package models
+
+import (
+	"time"
+)
+
+const (
+	PriceScheduleStatusPending   = "pending"
+	PriceScheduleStatusActive    = "active"
+	PriceScheduleStatusEnded     = "ended"
+	PriceScheduleStatusCancelled = "cancelled"
+)
+
+// PriceSchedule sets a product's price at StartsAt and, when EndsAt is set,
+// puts back RevertPrice, the price it replaced, at EndsAt. A schedule without
+// EndsAt is a permanent price change.
+type PriceSchedule struct {
+	ScheduleID  string     `bson:"schedule_id" json:"schedule_id"`
+	ProductID   string     `bson:"product_id" json:"product_id"`
+	Price       Money      `bson:"price" json:"price"`
+	StartsAt    time.Time  `bson:"starts_at" json:"starts_at"`
+	EndsAt      *time.Time `bson:"ends_at,omitempty" json:"ends_at,omitempty"`
+	Status      string     `bson:"status" json:"status"`
+	RevertPrice *Money     `bson:"revert_price,omitempty" json:"revert_price,omitempty"`
+	CreatedBy   string     `bson:"created_by" json:"created_by"`
+	CreatedAt   time.Time  `bson:"created_at" json:"created_at"`
+	UpdatedAt   time.Time  `bson:"updated_at" json:"updated_at"`
+}
+
+type PriceScheduleRequest struct {
+	Price    Money      `json:"price"`
+	StartsAt time.Time  `json:"starts_at"`
+	EndsAt   *time.Time `json:"ends_at"`
+}
@@ -1,15 +1,64 @@
 package models
 
 import (
+	"encoding/json"
 	"time"
 )
 
 type Product struct {
-    ProductID   string    `bson:"product_id,omitempty" json:"product_id"`
-    Name        string    `bson:"name,omitempty" json:"name"`
-    Description string    `bson:"description,omitempty" json:"description"`
-    Price       float64   `bson:"price,omitempty" json:"price"`
-    Stock       int       `bson:"stock,omitempty" json:"stock"`
-    CreatedAt   time.Time `bson:"created_at,omitempty" json:"created_at"`
-    UpdatedAt   time.Time `bson:"updated_at,omitempty" json:"updated_at"`
-}
\ No newline at end of file
+	ProductID   string         `bson:"product_id,omitempty" json:"product_id"`
+	Name        string         `bson:"name,omitempty" json:"name"`
+	Description string         `bson:"description,omitempty" json:"description"`
+	Price       Money          `bson:"price,omitempty" json:"price"`
+	Prices      []Money        `bson:"prices,omitempty" json:"prices,omitempty"`
+	Stock       int            `bson:"stock,omitempty" json:"stock"`
+	Reserved    int            `bson:"reserved,omitempty" json:"reserved"`
+	CategoryIDs []string       `bson:"category_ids,omitempty" json:"category_ids,omitempty"`
+	Variants    []Variant      `bson:"variants,omitempty" json:"variants,omitempty"`
+	Images      []ProductImage `bson:"images,omitempty" json:"images,omitempty"`
+	Version     int            `bson:"version,omitempty" json:"version"`
+	CreatedAt   time.Time      `bson:"created_at,omitempty" json:"created_at"`
+	UpdatedAt   time.Time      `bson:"updated_at,omitempty" json:"updated_at"`
+	DeletedAt   *time.Time     `bson:"deleted_at,omitempty" json:"deleted_at,omitempty"`
+
+	ProductRating `bson:",inline"`
+}
+
+// ProductRating aggregates the product's approved reviews. It is only ever
+// changed by adding or removing a single review's rating, never recomputed
+// from the reviews.
+type ProductRating struct {
+	RatingAverage float64 `bson:"rating_average,omitempty" json:"rating_average"`
+	RatingCount   int     `bson:"rating_count,omitempty" json:"rating_count"`
+	RatingTotal   int     `bson:"rating_total,omitempty" json:"-"`
+}
+
+// PriceOverride returns the product's explicit price in currency, if it has
+// one.
+func (p Product) PriceOverride(currency string) (Money, bool) {
+	for _, price := range p.Prices {
+		if price.Currency == currency {
+			return price, true
+		}
+	}
+	return Money{}, false
+}
+
+// Available is the stock that is not held by an active reservation.
+func (p Product) Available() int {
+	return p.Stock - p.Reserved
+}
+
+// productJSON has Product's fields without its MarshalJSON method.
+type productJSON Product
+
+func (p Product) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		productJSON
+		Available int `json:"available"`
+	}{productJSON(p), p.Available()})
+}
+
+type StockAdjustment struct {
+	Delta int `json:"delta"`
+}
@@ -0,0 +1,47 @@
+package models
+
+import (
+	"time"
+)
+
+const (
+	AuditActionCreate  = "create"
+	AuditActionUpdate  = "update"
+	AuditActionDelete  = "delete"
+	AuditActionRestore = "restore"
+	AuditActionStock   = "adjust_stock"
+)
+
+type ProductAuditEntry struct {
+	AuditID   string        `bson:"audit_id" json:"audit_id"`
+	ProductID string        `bson:"product_id" json:"product_id"`
+	Action    string        `bson:"action" json:"action"`
+	Changes   []FieldChange `bson:"changes" json:"changes"`
+	Actor     string        `bson:"actor" json:"actor"`
+	RequestID string        `bson:"request_id,omitempty" json:"request_id,omitempty"`
+	CreatedAt time.Time     `bson:"created_at" json:"created_at"`
+}
+
+type FieldChange struct {
+	Field  string      `bson:"field" json:"field"`
+	Before interface{} `bson:"before" json:"before"`
+	After  interface{} `bson:"after" json:"after"`
+}
+
+type AuditQuery struct {
+	ProductID string
+	Limit     int
+	Cursor    string
+	After     *AuditCursor
+}
+
+type AuditCursor struct {
+	CreatedAt time.Time `json:"created_at"`
+	AuditID   string    `json:"audit_id"`
+}
+
+type AuditPage struct {
+	Entries    []ProductAuditEntry `json:"entries"`
+	NextCursor string              `json:"next_cursor,omitempty"`
+	HasMore    bool                `json:"has_more"`
+}
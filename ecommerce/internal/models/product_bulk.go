@@ -0,0 +1,43 @@
+package models
+
+const (
+	BulkOpCreate = "create"
+	BulkOpUpdate = "update"
+	BulkOpDelete = "delete"
+
+	BulkStatusCreated = "created"
+	BulkStatusUpdated = "updated"
+	BulkStatusDeleted = "deleted"
+	BulkStatusError   = "error"
+	BulkStatusSkipped = "skipped"
+
+	MaxBulkOperations = 500
+)
+
+type BulkOperation struct {
+	Op        string   `json:"op"`
+	ProductID string   `json:"product_id,omitempty"`
+	Version   int      `json:"version,omitempty"`
+	Product   *Product `json:"product,omitempty"`
+}
+
+type BulkResult struct {
+	Index     int    `json:"index"`
+	Op        string `json:"op"`
+	ProductID string `json:"product_id,omitempty"`
+	Version   int    `json:"version,omitempty"`
+	Status    string `json:"status"`
+	Code      string `json:"code,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+type BulkResponse struct {
+	Results []BulkResult `json:"results"`
+}
+
+// ProductWrite is a single validated write handed to the repository. For
+// updates and deletes Product.Version holds the version the write expects.
+type ProductWrite struct {
+	Op      string
+	Product Product
+}
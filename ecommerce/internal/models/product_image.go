@@ -0,0 +1,40 @@
+package models
+
+import (
+	"io"
+	"time"
+)
+
+const MaxProductImages = 20
+
+// ImageContentTypes maps the accepted image types to the file extension they
+// are stored under.
+var ImageContentTypes = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+	"image/webp": ".webp",
+}
+
+type ProductImage struct {
+	ImageID     string    `bson:"image_id" json:"image_id"`
+	URL         string    `bson:"url" json:"url"`
+	AltText     string    `bson:"alt_text,omitempty" json:"alt_text,omitempty"`
+	Position    int       `bson:"position" json:"position"`
+	ContentType string    `bson:"content_type" json:"content_type"`
+	Size        int64     `bson:"size" json:"size"`
+	Key         string    `bson:"key" json:"-"`
+	CreatedAt   time.Time `bson:"created_at" json:"created_at"`
+}
+
+type ImageOrderRequest struct {
+	ImageIDs []string `json:"image_ids"`
+}
+
+// ImageUpload is an image file as received from the client. ContentType is
+// the type the client declared, which may be empty.
+type ImageUpload struct {
+	ContentType string
+	AltText     string
+	Content     io.Reader
+}
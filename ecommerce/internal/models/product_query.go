@@ -0,0 +1,59 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+const DefaultProductSort = "created_at"
+
+var ProductSortFields = map[string]bool{
+	"price":      true,
+	"name":       true,
+	"created_at": true,
+}
+
+type ProductQuery struct {
+	Limit    int
+	Cursor   string
+	Sort     string
+	Filter   ProductFilter
+	Trashed  bool
+	Currency string
+	After    *ProductCursor
+}
+
+type ProductFilter struct {
+	MinPrice     *Money
+	MaxPrice     *Money
+	InStock      *bool
+	NamePrefix   string
+	CreatedAfter *time.Time
+	CategoryIDs  []string
+}
+
+// SortSpec splits Sort into the field to order by and whether the order is
+// descending. A leading "-" selects descending order.
+func (q ProductQuery) SortSpec() (string, bool) {
+	if q.Sort == "" {
+		return DefaultProductSort, false
+	}
+	if strings.HasPrefix(q.Sort, "-") {
+		return strings.TrimPrefix(q.Sort, "-"), true
+	}
+	return q.Sort, false
+}
+
+type ProductCursor struct {
+	Sort      string    `json:"sort,omitempty"`
+	Price     int64     `json:"price,omitempty"`
+	Name      string    `json:"name,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	ProductID string    `json:"product_id"`
+}
+
+type ProductPage struct {
+	Products   []Product `json:"products"`
+	NextCursor string    `json:"next_cursor,omitempty"`
+	HasMore    bool      `json:"has_more"`
+}
@@ -0,0 +1,38 @@
+package models
+
+import (
+	"encoding/json"
+)
+
+type ProductSearchQuery struct {
+	Text   string
+	Limit  int
+	Cursor string
+	Offset int
+}
+
+type ProductSearchCursor struct {
+	Text   string `json:"q"`
+	Offset int    `json:"offset"`
+}
+
+type ProductSearchResult struct {
+	Product `bson:",inline"`
+	Score   float64 `bson:"score" json:"score"`
+}
+
+// MarshalJSON is needed because the embedded Product's MarshalJSON would
+// otherwise be promoted and drop the score.
+func (r ProductSearchResult) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		productJSON
+		Available int     `json:"available"`
+		Score     float64 `json:"score"`
+	}{productJSON(r.Product), r.Available(), r.Score})
+}
+
+type ProductSearchPage struct {
+	Results    []ProductSearchResult `json:"results"`
+	NextCursor string                `json:"next_cursor,omitempty"`
+	HasMore    bool                  `json:"has_more"`
+}
@@ -0,0 +1,91 @@
+package models
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/YugenDev/global-mobility-test/internal/utils"
+)
+
+// RateLimitOff turns rate limiting off, globally or for a route.
+const RateLimitOff = "off"
+
+// RateLimit is a token bucket that holds Requests tokens and refills at
+// Requests per Period, so a client may burst up to Requests and then keeps
+// that average. The zero RateLimit does not limit.
+type RateLimit struct {
+	Requests int
+	Period   time.Duration
+}
+
+func (l RateLimit) Unlimited() bool {
+	return l.Requests == 0
+}
+
+// RateLimitResult is the state of a client's bucket after a request.
+type RateLimitResult struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	Reset      time.Duration
+	RetryAfter time.Duration
+}
+
+// ParseRateLimit reads a limit written as requests/period, such as 120/1m,
+// or "off".
+func ParseRateLimit(value string) (RateLimit, error) {
+	value = strings.TrimSpace(value)
+	if value == RateLimitOff {
+		return RateLimit{}, nil
+	}
+
+	requests, period, ok := strings.Cut(value, "/")
+	if !ok {
+		return RateLimit{}, utils.ErrInvalidRateLimit
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(requests))
+	if err != nil || n <= 0 {
+		return RateLimit{}, utils.ErrInvalidRateLimit
+	}
+	d, err := time.ParseDuration(strings.TrimSpace(period))
+	if err != nil || d <= 0 {
+		return RateLimit{}, utils.ErrInvalidRateLimit
+	}
+
+	return RateLimit{Requests: n, Period: d}, nil
+}
+
+// ParseRateLimitRoutes reads comma-separated per-route limits such as
+// "GET /products=20/1m,POST /auth/login=5/1m". Routes are the method and the
+// route's path pattern, e.g. "GET /products/:id".
+func ParseRateLimitRoutes(value string) (map[string]RateLimit, error) {
+	routes := make(map[string]RateLimit)
+	for _, entry := range strings.Split(value, ",") {
+		if strings.TrimSpace(entry) == "" {
+			continue
+		}
+
+		route, limit, ok := strings.Cut(entry, "=")
+		if !ok {
+			return nil, utils.ErrInvalidRateLimit
+		}
+		method, path, ok := strings.Cut(strings.TrimSpace(route), " ")
+		path = strings.TrimSpace(path)
+		if !ok || !strings.HasPrefix(path, "/") {
+			return nil, utils.ErrInvalidRateLimit
+		}
+
+		parsed, err := ParseRateLimit(limit)
+		if err != nil {
+			return nil, err
+		}
+		routes[RateLimitRoute(method, path)] = parsed
+	}
+	return routes, nil
+}
+
+// RateLimitRoute names a route for per-route limits.
+func RateLimitRoute(method string, path string) string {
+	return strings.ToUpper(method) + " " + path
+}
@@ -0,0 +1,27 @@
+package models
+
+import (
+	"time"
+)
+
+const (
+	ReservationStatusActive    = "active"
+	ReservationStatusConfirmed = "confirmed"
+	ReservationStatusCancelled = "cancelled"
+	ReservationStatusExpired   = "expired"
+)
+
+type Reservation struct {
+	ReservationID string    `bson:"reservation_id" json:"reservation_id"`
+	ProductID     string    `bson:"product_id" json:"product_id"`
+	OwnerID       string    `bson:"owner_id" json:"owner_id"`
+	Quantity      int       `bson:"quantity" json:"quantity"`
+	Status        string    `bson:"status" json:"status"`
+	ExpiresAt     time.Time `bson:"expires_at" json:"expires_at"`
+	CreatedAt     time.Time `bson:"created_at" json:"created_at"`
+	UpdatedAt     time.Time `bson:"updated_at" json:"updated_at"`
+}
+
+type ReservationRequest struct {
+	Quantity int `json:"quantity"`
+}
@@ -0,0 +1,55 @@
+package models
+
+import (
+	"time"
+)
+
+const (
+	ReviewStatusPending  = "pending"
+	ReviewStatusApproved = "approved"
+	ReviewStatusRejected = "rejected"
+)
+
+var ReviewStatuses = map[string]bool{
+	ReviewStatusPending:  true,
+	ReviewStatusApproved: true,
+	ReviewStatusRejected: true,
+}
+
+// Review is a customer's rating of a product. New reviews are pending and
+// only approved reviews count towards the product's rating.
+type Review struct {
+	ReviewID  string    `bson:"review_id" json:"review_id"`
+	ProductID string    `bson:"product_id" json:"product_id"`
+	Rating    int       `bson:"rating" json:"rating"`
+	Text      string    `bson:"text" json:"text"`
+	Author    string    `bson:"author,omitempty" json:"author,omitempty"`
+	Status    string    `bson:"status" json:"status"`
+	CreatedAt time.Time `bson:"created_at" json:"created_at"`
+	UpdatedAt time.Time `bson:"updated_at" json:"updated_at"`
+}
+
+type ReviewStatusRequest struct {
+	Status string `json:"status"`
+}
+
+// ReviewQuery lists reviews newest first. An empty ProductID lists the
+// reviews of every product.
+type ReviewQuery struct {
+	ProductID string
+	Status    string
+	Limit     int
+	Cursor    string
+	After     *ReviewCursor
+}
+
+type ReviewCursor struct {
+	CreatedAt time.Time `json:"created_at"`
+	ReviewID  string    `json:"review_id"`
+}
+
+type ReviewPage struct {
+	Reviews    []Review `json:"reviews"`
+	NextCursor string   `json:"next_cursor,omitempty"`
+	HasMore    bool     `json:"has_more"`
+}
@@ -0,0 +1,47 @@
+package models
+
+import (
+	"slices"
+)
+
+const (
+	RoleAdmin         = "admin"
+	RoleCatalogEditor = "catalog_editor"
+	RoleViewer        = "viewer"
+)
+
+const (
+	PermissionProductsWrite   = "products:write"
+	PermissionStockAdjust     = "stock:adjust"
+	PermissionUsersManage     = "users:manage"
+	PermissionAPIKeysManage   = "api_keys:manage"
+	PermissionCouponsManage   = "coupons:manage"
+	PermissionOrdersManage    = "orders:manage"
+	PermissionReviewsModerate = "reviews:moderate"
+)
+
+// RolePermissions lists what each role may do. Reads need no permission, so
+// viewers have none.
+var RolePermissions = map[string][]string{
+	RoleAdmin:         {PermissionProductsWrite, PermissionStockAdjust, PermissionUsersManage, PermissionAPIKeysManage, PermissionCouponsManage, PermissionOrdersManage, PermissionReviewsModerate},
+	RoleCatalogEditor: {PermissionProductsWrite, PermissionStockAdjust, PermissionReviewsModerate},
+	RoleViewer:        {},
+}
+
+// PermissionsFor returns the permissions granted by roles, without
+// duplicates. Unknown roles grant nothing.
+func PermissionsFor(roles []string) []string {
+	var permissions []string
+	for _, role := range roles {
+		for _, permission := range RolePermissions[role] {
+			if !slices.Contains(permissions, permission) {
+				permissions = append(permissions, permission)
+			}
+		}
+	}
+	return permissions
+}
+
+type RolesRequest struct {
+	Roles []string `json:"roles"`
+}
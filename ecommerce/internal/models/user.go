@@ -0,0 +1,66 @@
+package models
+
+import (
+	"slices"
+	"time"
+)
+
+type User struct {
+	UserID       string    `bson:"user_id" json:"user_id"`
+	Email        string    `bson:"email" json:"email"`
+	Name         string    `bson:"name,omitempty" json:"name,omitempty"`
+	Roles        []string  `bson:"roles" json:"roles"`
+	PasswordHash string    `bson:"password_hash" json:"-"`
+	CreatedAt    time.Time `bson:"created_at" json:"created_at"`
+	UpdatedAt    time.Time `bson:"updated_at" json:"updated_at"`
+}
+
+type RegisterRequest struct {
+	Email    string `json:"email"`
+	Name     string `json:"name"`
+	Password string `json:"password"`
+}
+
+type LoginRequest struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+// TokenPair is issued on login and refresh. ExpiresIn is the access token's
+// lifetime in seconds.
+type TokenPair struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int    `json:"expires_in"`
+}
+
+// Identity is the authenticated caller of a request and what it may do: a
+// user with the permissions of their roles, or an API key with its scopes.
+type Identity struct {
+	UserID      string   `json:"user_id"`
+	Email       string   `json:"email"`
+	APIKeyID    string   `json:"api_key_id,omitempty"`
+	Roles       []string `json:"roles"`
+	Permissions []string `json:"permissions"`
+}
+
+func (i Identity) Can(permission string) bool {
+	return slices.Contains(i.Permissions, permission)
+}
+
+// Principal names the caller for ownership and rate limits: its API key or
+// else its user. The zero Identity has no principal.
+func (i Identity) Principal() string {
+	if i.APIKeyID != "" {
+		return "api_key:" + i.APIKeyID
+	}
+	if i.UserID != "" {
+		return "user:" + i.UserID
+	}
+	return ""
+}
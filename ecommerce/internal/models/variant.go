@@ -0,0 +1,85 @@
+package models
+
+import (
+	"sort"
+	"strings"
+)
+
+// Variant is one purchasable combination of a product's options, such as a
+// size and color. A nil Price means the variant sells at the product's price.
+type Variant struct {
+	SKU     string            `bson:"sku" json:"sku"`
+	Options map[string]string `bson:"options" json:"options"`
+	Price   *Money            `bson:"price,omitempty" json:"price,omitempty"`
+	Stock   int               `bson:"stock" json:"stock"`
+}
+
+// EffectivePrice is the variant's override, or base when it has none.
+func (v Variant) EffectivePrice(base Money) Money {
+	if v.Price != nil {
+		return *v.Price
+	}
+	return base
+}
+
+// OptionsKey renders the options in a stable order so two variants with the
+// same option values compare equal.
+func (v Variant) OptionsKey() string {
+	keys := make([]string, 0, len(v.Options))
+	for key := range v.Options {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	for _, key := range keys {
+		b.WriteString(key)
+		b.WriteByte('=')
+		b.WriteString(v.Options[key])
+		b.WriteByte(';')
+	}
+	return b.String()
+}
+
+// Variant returns the product's variant with the given SKU.
+func (p Product) Variant(sku string) (Variant, bool) {
+	for _, variant := range p.Variants {
+		if variant.SKU == sku {
+			return variant, true
+		}
+	}
+	return Variant{}, false
+}
+
+// VariantStock is the combined stock of variants. Products with variants keep
+// their own stock equal to it.
+func VariantStock(variants []Variant) int {
+	total := 0
+	for _, variant := range variants {
+		total += variant.Stock
+	}
+	return total
+}
+
+// CloneVariants copies variants deeply enough that changing the copy leaves
+// the original untouched.
+func CloneVariants(variants []Variant) []Variant {
+	if variants == nil {
+		return nil
+	}
+	clone := make([]Variant, len(variants))
+	for i, variant := range variants {
+		clone[i] = variant
+		if variant.Price != nil {
+			price := *variant.Price
+			clone[i].Price = &price
+		}
+		if variant.Options != nil {
+			clone[i].Options = make(map[string]string, len(variant.Options))
+			for key, value := range variant.Options {
+				clone[i].Options[key] = value
+			}
+		}
+	}
+	return clone
+}
@@ -0,0 +1,108 @@
+package repositories
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/YugenDev/global-mobility-test/internal/models"
+	"github.com/YugenDev/global-mobility-test/internal/utils"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type MemoryAPIKeyRepository struct {
+	mu   sync.RWMutex
+	keys map[string]models.APIKey
+}
+
+var _ IAPIKeyRepository = (*MemoryAPIKeyRepository)(nil)
+
+func NewMemoryAPIKeyRepository() *MemoryAPIKeyRepository {
+	return &MemoryAPIKeyRepository{
+		keys: make(map[string]models.APIKey),
+	}
+}
+
+func (r *MemoryAPIKeyRepository) CreateAPIKey(key *models.APIKey) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.keys[key.KeyID] = *key
+	return nil
+}
+
+func (r *MemoryAPIKeyRepository) GetAPIKeyByHash(hash string) (models.APIKey, error) {
+	if hash == "" {
+		return models.APIKey{}, utils.ErrAPIKeyNotFound
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, key := range r.keys {
+		if key.KeyHash == hash {
+			return key, nil
+		}
+	}
+
+	return models.APIKey{}, utils.ErrAPIKeyNotFound
+}
+
+func (r *MemoryAPIKeyRepository) GetAllAPIKeys() ([]models.APIKey, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var keys []models.APIKey
+	for _, key := range r.keys {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if !keys[i].CreatedAt.Equal(keys[j].CreatedAt) {
+			return keys[i].CreatedAt.After(keys[j].CreatedAt)
+		}
+		return keys[i].KeyID < keys[j].KeyID
+	})
+
+	return keys, nil
+}
+
+func (r *MemoryAPIKeyRepository) RevokeAPIKey(id string, at time.Time) (*mongo.UpdateResult, error) {
+	if id == "" {
+		return nil, utils.ErrAPIKeyIDRequired
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key, ok := r.keys[id]
+	if !ok || key.RevokedAt != nil {
+		return &mongo.UpdateResult{}, nil
+	}
+
+	key.RevokedAt = &at
+	r.keys[id] = key
+
+	return &mongo.UpdateResult{MatchedCount: 1, ModifiedCount: 1}, nil
+}
+
+func (r *MemoryAPIKeyRepository) TouchAPIKey(id string, at time.Time) (*mongo.UpdateResult, error) {
+	if id == "" {
+		return nil, utils.ErrAPIKeyIDRequired
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key, ok := r.keys[id]
+	if !ok {
+		return &mongo.UpdateResult{}, nil
+	}
+	if key.LastUsedAt != nil && !at.After(*key.LastUsedAt) {
+		return &mongo.UpdateResult{MatchedCount: 1}, nil
+	}
+
+	key.LastUsedAt = &at
+	r.keys[id] = key
+
+	return &mongo.UpdateResult{MatchedCount: 1, ModifiedCount: 1}, nil
+}
@@ -0,0 +1,144 @@
+package repositories
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/YugenDev/global-mobility-test/internal/config"
+	"github.com/YugenDev/global-mobility-test/internal/models"
+	"github.com/YugenDev/global-mobility-test/internal/utils"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type IAPIKeyRepository interface {
+	CreateAPIKey(key *models.APIKey) error
+	GetAPIKeyByHash(hash string) (models.APIKey, error)
+	GetAllAPIKeys() ([]models.APIKey, error)
+	RevokeAPIKey(id string, at time.Time) (*mongo.UpdateResult, error)
+	TouchAPIKey(id string, at time.Time) (*mongo.UpdateResult, error)
+}
+
+type APIKeyRepository struct {
+	Collection MongoCollection
+}
+
+var _ IAPIKeyRepository = (*APIKeyRepository)(nil)
+
+func NewAPIKeyRepository() *APIKeyRepository {
+	return &APIKeyRepository{
+		Collection: config.GetCollection("api_keys"),
+	}
+}
+
+func (r *APIKeyRepository) CreateAPIKey(key *models.APIKey) error {
+	if r.Collection == nil {
+		return utils.ErrDatabaseNotInitialized
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if _, err := r.Collection.InsertOne(ctx, key); err != nil {
+		log.Println("Error creating API key: ", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *APIKeyRepository) GetAPIKeyByHash(hash string) (models.APIKey, error) {
+	if hash == "" {
+		return models.APIKey{}, utils.ErrAPIKeyNotFound
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var key models.APIKey
+	if err := r.Collection.FindOne(ctx, bson.M{"key_hash": hash}).Decode(&key); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return models.APIKey{}, utils.ErrAPIKeyNotFound
+		}
+		log.Println("Error getting API key: ", err)
+		return models.APIKey{}, err
+	}
+
+	return key, nil
+}
+
+func (r *APIKeyRepository) GetAllAPIKeys() ([]models.APIKey, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "key_id", Value: 1}})
+
+	var keys []models.APIKey
+	cursor, err := r.Collection.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		log.Println("Error getting API keys: ", err)
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var key models.APIKey
+		if err := cursor.Decode(&key); err != nil {
+			log.Println("Error decoding API key: ", err)
+			continue
+		}
+		keys = append(keys, key)
+	}
+
+	if err := cursor.Err(); err != nil {
+		log.Println("Cursor error: ", err)
+		return nil, err
+	}
+
+	return keys, nil
+}
+
+// RevokeAPIKey only matches a key that is not revoked yet, so the first
+// revocation time is kept.
+func (r *APIKeyRepository) RevokeAPIKey(id string, at time.Time) (*mongo.UpdateResult, error) {
+	if id == "" {
+		return nil, utils.ErrAPIKeyIDRequired
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{"key_id": id, "revoked_at": bson.M{"$exists": false}}
+	update := bson.M{"$set": bson.M{"revoked_at": at}}
+
+	result, err := r.Collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		log.Println("Error revoking API key: ", err)
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// TouchAPIKey records that the key was used at at. An earlier time never
+// overwrites a later one.
+func (r *APIKeyRepository) TouchAPIKey(id string, at time.Time) (*mongo.UpdateResult, error) {
+	if id == "" {
+		return nil, utils.ErrAPIKeyIDRequired
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	update := bson.M{"$max": bson.M{"last_used_at": at}}
+
+	result, err := r.Collection.UpdateOne(ctx, bson.M{"key_id": id}, update)
+	if err != nil {
+		log.Println("Error recording API key use: ", err)
+		return nil, err
+	}
+
+	return result, nil
+}
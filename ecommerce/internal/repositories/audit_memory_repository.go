@@ -0,0 +1,71 @@
+package repositories
+
+import (
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/YugenDev/global-mobility-test/internal/models"
+	"github.com/YugenDev/global-mobility-test/internal/utils"
+)
+
+type MemoryAuditRepository struct {
+	mu      sync.RWMutex
+	entries []models.ProductAuditEntry
+}
+
+var _ IAuditRepository = (*MemoryAuditRepository)(nil)
+
+func NewMemoryAuditRepository() *MemoryAuditRepository {
+	return &MemoryAuditRepository{}
+}
+
+func (r *MemoryAuditRepository) CreateEntry(entry *models.ProductAuditEntry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.entries = append(r.entries, *entry)
+	return nil
+}
+
+func (r *MemoryAuditRepository) GetEntriesByProductID(query models.AuditQuery) ([]models.ProductAuditEntry, error) {
+	if query.ProductID == "" {
+		return nil, utils.ErrProductIDRequired
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var matches []models.ProductAuditEntry
+	for _, entry := range r.entries {
+		if entry.ProductID == query.ProductID {
+			matches = append(matches, entry)
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		return compareAuditEntries(matches[i], matches[j]) > 0
+	})
+
+	var entries []models.ProductAuditEntry
+	for _, entry := range matches {
+		if query.After != nil {
+			after := models.ProductAuditEntry{CreatedAt: query.After.CreatedAt, AuditID: query.After.AuditID}
+			if compareAuditEntries(entry, after) >= 0 {
+				continue
+			}
+		}
+		entries = append(entries, entry)
+		if query.Limit > 0 && len(entries) == query.Limit {
+			break
+		}
+	}
+
+	return entries, nil
+}
+
+func compareAuditEntries(a, b models.ProductAuditEntry) int {
+	if result := a.CreatedAt.Compare(b.CreatedAt); result != 0 {
+		return result
+	}
+	return strings.Compare(a.AuditID, b.AuditID)
+}
@@ -0,0 +1,92 @@
+package repositories
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/YugenDev/global-mobility-test/internal/config"
+	"github.com/YugenDev/global-mobility-test/internal/models"
+	"github.com/YugenDev/global-mobility-test/internal/utils"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type IAuditRepository interface {
+	CreateEntry(entry *models.ProductAuditEntry) error
+	GetEntriesByProductID(query models.AuditQuery) ([]models.ProductAuditEntry, error)
+}
+
+type AuditRepository struct {
+	Collection MongoCollection
+}
+
+var _ IAuditRepository = (*AuditRepository)(nil)
+
+func NewAuditRepository() *AuditRepository {
+	return &AuditRepository{
+		Collection: config.GetCollection("product_audit"),
+	}
+}
+
+func (r *AuditRepository) CreateEntry(entry *models.ProductAuditEntry) error {
+	if r.Collection == nil {
+		return utils.ErrDatabaseNotInitialized
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if _, err := r.Collection.InsertOne(ctx, entry); err != nil {
+		log.Println("Error creating audit entry: ", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *AuditRepository) GetEntriesByProductID(query models.AuditQuery) ([]models.ProductAuditEntry, error) {
+	if query.ProductID == "" {
+		return nil, utils.ErrProductIDRequired
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{"product_id": query.ProductID}
+	if query.After != nil {
+		filter["$or"] = bson.A{
+			bson.M{"created_at": bson.M{"$lt": query.After.CreatedAt}},
+			bson.M{"created_at": query.After.CreatedAt, "audit_id": bson.M{"$lt": query.After.AuditID}},
+		}
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "audit_id", Value: -1}})
+	if query.Limit > 0 {
+		opts.SetLimit(int64(query.Limit))
+	}
+
+	var entries []models.ProductAuditEntry
+	cursor, err := r.Collection.Find(ctx, filter, opts)
+	if err != nil {
+		log.Println("Error getting audit entries: ", err)
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var entry models.ProductAuditEntry
+		if err := cursor.Decode(&entry); err != nil {
+			log.Println("Error decoding audit entry: ", err)
+			continue
+		}
+		entries = append(entries, entry)
+	}
+
+	if err := cursor.Err(); err != nil {
+		log.Println("Cursor error: ", err)
+		return nil, err
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,78 @@
+package repositories
+
+import (
+	"sync"
+	"time"
+
+	"github.com/YugenDev/global-mobility-test/internal/models"
+	"github.com/YugenDev/global-mobility-test/internal/utils"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// MemoryCartRepository drops expired carts lazily, when they are next read.
+type MemoryCartRepository struct {
+	mu    sync.RWMutex
+	carts map[string]models.Cart
+}
+
+var _ ICartRepository = (*MemoryCartRepository)(nil)
+
+func NewMemoryCartRepository() *MemoryCartRepository {
+	return &MemoryCartRepository{
+		carts: make(map[string]models.Cart),
+	}
+}
+
+func (r *MemoryCartRepository) CreateCart(cart *models.Cart) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	cart.Version = 1
+	r.carts[cart.CartID] = copyCart(*cart)
+	return nil
+}
+
+func (r *MemoryCartRepository) GetCartByID(id string) (models.Cart, error) {
+	if id == "" {
+		return models.Cart{}, utils.ErrCartIDRequired
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	cart, ok := r.carts[id]
+	if !ok {
+		return models.Cart{}, utils.ErrCartNotFound
+	}
+	if !cart.ExpiresAt.After(time.Now()) {
+		delete(r.carts, id)
+		return models.Cart{}, utils.ErrCartNotFound
+	}
+
+	return copyCart(cart), nil
+}
+
+func (r *MemoryCartRepository) UpdateCart(cart *models.Cart) (*mongo.UpdateResult, error) {
+	if cart.CartID == "" {
+		return nil, utils.ErrCartIDRequired
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, ok := r.carts[cart.CartID]
+	if !ok || existing.Version != cart.Version {
+		return &mongo.UpdateResult{}, nil
+	}
+
+	cart.Version++
+	r.carts[cart.CartID] = copyCart(*cart)
+
+	return &mongo.UpdateResult{MatchedCount: 1, ModifiedCount: 1}, nil
+}
+
+// copyCart keeps callers from sharing the stored cart's items slice.
+func copyCart(cart models.Cart) models.Cart {
+	cart.Items = append([]models.CartItem{}, cart.Items...)
+	return cart
+}
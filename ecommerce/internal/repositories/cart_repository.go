@@ -0,0 +1,106 @@
+package repositories
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/YugenDev/global-mobility-test/internal/config"
+	"github.com/YugenDev/global-mobility-test/internal/models"
+	"github.com/YugenDev/global-mobility-test/internal/utils"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type ICartRepository interface {
+	CreateCart(cart *models.Cart) error
+	GetCartByID(id string) (models.Cart, error)
+	UpdateCart(cart *models.Cart) (*mongo.UpdateResult, error)
+}
+
+type CartRepository struct {
+	Collection MongoCollection
+}
+
+var _ ICartRepository = (*CartRepository)(nil)
+
+func NewCartRepository() *CartRepository {
+	return &CartRepository{
+		Collection: config.GetCollection("carts"),
+	}
+}
+
+func (r *CartRepository) CreateCart(cart *models.Cart) error {
+	if r.Collection == nil {
+		return utils.ErrDatabaseNotInitialized
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	cart.Version = 1
+	if _, err := r.Collection.InsertOne(ctx, cart); err != nil {
+		log.Println("Error creating cart: ", err)
+		return err
+	}
+
+	return nil
+}
+
+// GetCartByID ignores carts past their expiry that the TTL index has not
+// removed yet.
+func (r *CartRepository) GetCartByID(id string) (models.Cart, error) {
+	if id == "" {
+		return models.Cart{}, utils.ErrCartIDRequired
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var cart models.Cart
+	filter := bson.M{"cart_id": id, "expires_at": bson.M{"$gt": time.Now()}}
+	if err := r.Collection.FindOne(ctx, filter).Decode(&cart); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return models.Cart{}, utils.ErrCartNotFound
+		}
+		log.Println("Error getting cart by ID: ", err)
+		return models.Cart{}, err
+	}
+
+	return cart, nil
+}
+
+// UpdateCart replaces the cart's items and totals while it is still at
+// cart.Version, and bumps the version on success.
+func (r *CartRepository) UpdateCart(cart *models.Cart) (*mongo.UpdateResult, error) {
+	if cart.CartID == "" {
+		return nil, utils.ErrCartIDRequired
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{"cart_id": cart.CartID, "version": cart.Version}
+	update := bson.M{
+		"$set": bson.M{
+			"items":      cart.Items,
+			"item_count": cart.ItemCount,
+			"total":      cart.Total,
+			"updated_at": cart.UpdatedAt,
+			"expires_at": cart.ExpiresAt,
+		},
+		"$inc": bson.M{"version": 1},
+	}
+
+	result, err := r.Collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		log.Println("Error updating cart: ", err)
+		return nil, err
+	}
+
+	if result.MatchedCount > 0 {
+		cart.Version++
+	}
+
+	return result, nil
+}
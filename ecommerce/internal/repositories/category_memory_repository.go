@@ -0,0 +1,121 @@
+package repositories
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/YugenDev/global-mobility-test/internal/models"
+	"github.com/YugenDev/global-mobility-test/internal/utils"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type MemoryCategoryRepository struct {
+	mu         sync.RWMutex
+	categories map[string]models.Category
+}
+
+var _ ICategoryRepository = (*MemoryCategoryRepository)(nil)
+
+func NewMemoryCategoryRepository() *MemoryCategoryRepository {
+	return &MemoryCategoryRepository{
+		categories: make(map[string]models.Category),
+	}
+}
+
+func (r *MemoryCategoryRepository) CreateCategory(category *models.Category) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.slugTaken(category.Slug, category.CategoryID) {
+		return utils.ErrCategorySlugExists
+	}
+
+	r.categories[category.CategoryID] = *category
+	return nil
+}
+
+func (r *MemoryCategoryRepository) GetCategoryByID(id string) (models.Category, error) {
+	if id == "" {
+		return models.Category{}, utils.ErrCategoryIDRequired
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	category, ok := r.categories[id]
+	if !ok {
+		return models.Category{}, utils.ErrCategoryNotFound
+	}
+
+	return category, nil
+}
+
+func (r *MemoryCategoryRepository) GetAllCategories() ([]models.Category, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var categories []models.Category
+	for _, category := range r.categories {
+		categories = append(categories, category)
+	}
+	sort.Slice(categories, func(i, j int) bool {
+		if categories[i].Position != categories[j].Position {
+			return categories[i].Position < categories[j].Position
+		}
+		return categories[i].Name < categories[j].Name
+	})
+
+	return categories, nil
+}
+
+func (r *MemoryCategoryRepository) UpdateCategory(category *models.Category) (*mongo.UpdateResult, error) {
+	if category.CategoryID == "" {
+		return nil, utils.ErrCategoryIDRequired
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, ok := r.categories[category.CategoryID]
+	if !ok {
+		return &mongo.UpdateResult{}, nil
+	}
+	if r.slugTaken(category.Slug, category.CategoryID) {
+		return nil, utils.ErrCategorySlugExists
+	}
+
+	existing.Name = category.Name
+	existing.Slug = category.Slug
+	existing.ParentID = category.ParentID
+	existing.Position = category.Position
+	existing.UpdatedAt = category.UpdatedAt
+	r.categories[category.CategoryID] = existing
+
+	return &mongo.UpdateResult{MatchedCount: 1, ModifiedCount: 1}, nil
+}
+
+func (r *MemoryCategoryRepository) DeleteCategory(id string) (*mongo.DeleteResult, error) {
+	if id == "" {
+		return nil, utils.ErrCategoryIDRequired
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.categories[id]; !ok {
+		return &mongo.DeleteResult{}, nil
+	}
+	delete(r.categories, id)
+
+	return &mongo.DeleteResult{DeletedCount: 1}, nil
+}
+
+// slugTaken mirrors the unique slug index. The caller must hold the lock.
+func (r *MemoryCategoryRepository) slugTaken(slug string, exceptID string) bool {
+	for id, category := range r.categories {
+		if id != exceptID && category.Slug == slug {
+			return true
+		}
+	}
+	return false
+}
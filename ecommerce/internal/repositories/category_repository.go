@@ -0,0 +1,153 @@
+package repositories
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/YugenDev/global-mobility-test/internal/config"
+	"github.com/YugenDev/global-mobility-test/internal/models"
+	"github.com/YugenDev/global-mobility-test/internal/utils"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type ICategoryRepository interface {
+	CreateCategory(category *models.Category) error
+	GetCategoryByID(id string) (models.Category, error)
+	GetAllCategories() ([]models.Category, error)
+	UpdateCategory(category *models.Category) (*mongo.UpdateResult, error)
+	DeleteCategory(id string) (*mongo.DeleteResult, error)
+}
+
+type CategoryRepository struct {
+	Collection MongoCollection
+}
+
+var _ ICategoryRepository = (*CategoryRepository)(nil)
+
+func NewCategoryRepository() *CategoryRepository {
+	return &CategoryRepository{
+		Collection: config.GetCollection("categories"),
+	}
+}
+
+func (r *CategoryRepository) CreateCategory(category *models.Category) error {
+	if r.Collection == nil {
+		return utils.ErrDatabaseNotInitialized
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if _, err := r.Collection.InsertOne(ctx, category); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return utils.ErrCategorySlugExists
+		}
+		log.Println("Error creating category: ", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *CategoryRepository) GetCategoryByID(id string) (models.Category, error) {
+	if id == "" {
+		return models.Category{}, utils.ErrCategoryIDRequired
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var category models.Category
+	if err := r.Collection.FindOne(ctx, bson.M{"category_id": id}).Decode(&category); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return models.Category{}, utils.ErrCategoryNotFound
+		}
+		log.Println("Error getting category by ID: ", err)
+		return models.Category{}, err
+	}
+
+	return category, nil
+}
+
+func (r *CategoryRepository) GetAllCategories() ([]models.Category, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "position", Value: 1}, {Key: "name", Value: 1}})
+
+	var categories []models.Category
+	cursor, err := r.Collection.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		log.Println("Error getting categories: ", err)
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var category models.Category
+		if err := cursor.Decode(&category); err != nil {
+			log.Println("Error decoding category: ", err)
+			continue
+		}
+		categories = append(categories, category)
+	}
+
+	if err := cursor.Err(); err != nil {
+		log.Println("Cursor error: ", err)
+		return nil, err
+	}
+
+	return categories, nil
+}
+
+func (r *CategoryRepository) UpdateCategory(category *models.Category) (*mongo.UpdateResult, error) {
+	if category.CategoryID == "" {
+		return nil, utils.ErrCategoryIDRequired
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	update := bson.M{"$set": bson.M{
+		"name":       category.Name,
+		"slug":       category.Slug,
+		"position":   category.Position,
+		"updated_at": category.UpdatedAt,
+	}}
+	if category.ParentID == "" {
+		update["$unset"] = bson.M{"parent_id": ""}
+	} else {
+		update["$set"].(bson.M)["parent_id"] = category.ParentID
+	}
+
+	result, err := r.Collection.UpdateOne(ctx, bson.M{"category_id": category.CategoryID}, update)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return nil, utils.ErrCategorySlugExists
+		}
+		log.Println("Error updating category: ", err)
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (r *CategoryRepository) DeleteCategory(id string) (*mongo.DeleteResult, error) {
+	if id == "" {
+		return nil, utils.ErrCategoryIDRequired
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	result, err := r.Collection.DeleteOne(ctx, bson.M{"category_id": id})
+	if err != nil {
+		log.Println("Error deleting category: ", err)
+		return nil, err
+	}
+
+	return result, nil
+}
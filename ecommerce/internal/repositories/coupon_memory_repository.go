@@ -0,0 +1,165 @@
+package repositories
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/YugenDev/global-mobility-test/internal/models"
+	"github.com/YugenDev/global-mobility-test/internal/utils"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type MemoryCouponRepository struct {
+	mu      sync.RWMutex
+	coupons map[string]models.Coupon
+}
+
+var _ ICouponRepository = (*MemoryCouponRepository)(nil)
+
+func NewMemoryCouponRepository() *MemoryCouponRepository {
+	return &MemoryCouponRepository{
+		coupons: make(map[string]models.Coupon),
+	}
+}
+
+func (r *MemoryCouponRepository) CreateCoupon(coupon *models.Coupon) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.codeTaken(coupon.Code, coupon.CouponID) {
+		return utils.ErrCouponCodeExists
+	}
+
+	r.coupons[coupon.CouponID] = *coupon
+	return nil
+}
+
+func (r *MemoryCouponRepository) GetCouponByID(id string) (models.Coupon, error) {
+	if id == "" {
+		return models.Coupon{}, utils.ErrCouponIDRequired
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	coupon, ok := r.coupons[id]
+	if !ok {
+		return models.Coupon{}, utils.ErrCouponNotFound
+	}
+
+	return coupon, nil
+}
+
+func (r *MemoryCouponRepository) GetCouponByCode(code string) (models.Coupon, error) {
+	if code == "" {
+		return models.Coupon{}, utils.ErrCouponCodeRequired
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, coupon := range r.coupons {
+		if coupon.Code == code {
+			return coupon, nil
+		}
+	}
+
+	return models.Coupon{}, utils.ErrCouponNotFound
+}
+
+func (r *MemoryCouponRepository) GetAllCoupons() ([]models.Coupon, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var coupons []models.Coupon
+	for _, coupon := range r.coupons {
+		coupons = append(coupons, coupon)
+	}
+	sort.Slice(coupons, func(i, j int) bool {
+		return coupons[i].Code < coupons[j].Code
+	})
+
+	return coupons, nil
+}
+
+func (r *MemoryCouponRepository) UpdateCoupon(coupon *models.Coupon) (*mongo.UpdateResult, error) {
+	if coupon.CouponID == "" {
+		return nil, utils.ErrCouponIDRequired
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, ok := r.coupons[coupon.CouponID]
+	if !ok {
+		return &mongo.UpdateResult{}, nil
+	}
+	if r.codeTaken(coupon.Code, coupon.CouponID) {
+		return nil, utils.ErrCouponCodeExists
+	}
+
+	updated := *coupon
+	updated.UsageCount = existing.UsageCount
+	updated.CreatedAt = existing.CreatedAt
+	r.coupons[coupon.CouponID] = updated
+
+	return &mongo.UpdateResult{MatchedCount: 1, ModifiedCount: 1}, nil
+}
+
+func (r *MemoryCouponRepository) DeleteCoupon(id string) (*mongo.DeleteResult, error) {
+	if id == "" {
+		return nil, utils.ErrCouponIDRequired
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.coupons[id]; !ok {
+		return &mongo.DeleteResult{}, nil
+	}
+	delete(r.coupons, id)
+
+	return &mongo.DeleteResult{DeletedCount: 1}, nil
+}
+
+func (r *MemoryCouponRepository) IncrementUsage(id string) (*mongo.UpdateResult, error) {
+	return r.addUsage(id, 1)
+}
+
+func (r *MemoryCouponRepository) DecrementUsage(id string) (*mongo.UpdateResult, error) {
+	return r.addUsage(id, -1)
+}
+
+func (r *MemoryCouponRepository) addUsage(id string, delta int) (*mongo.UpdateResult, error) {
+	if id == "" {
+		return nil, utils.ErrCouponIDRequired
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	coupon, ok := r.coupons[id]
+	if !ok {
+		return &mongo.UpdateResult{}, nil
+	}
+	if delta > 0 && coupon.UsageLimit > 0 && coupon.UsageCount >= coupon.UsageLimit {
+		return &mongo.UpdateResult{}, nil
+	}
+	if delta < 0 && coupon.UsageCount == 0 {
+		return &mongo.UpdateResult{}, nil
+	}
+
+	coupon.UsageCount += delta
+	r.coupons[id] = coupon
+
+	return &mongo.UpdateResult{MatchedCount: 1, ModifiedCount: 1}, nil
+}
+
+func (r *MemoryCouponRepository) codeTaken(code string, id string) bool {
+	for _, coupon := range r.coupons {
+		if coupon.Code == code && coupon.CouponID != id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,236 @@
+package repositories
+
+import (
+	"context"
+	"log"
+	"reflect"
+	"time"
+
+	"github.com/YugenDev/global-mobility-test/internal/config"
+	"github.com/YugenDev/global-mobility-test/internal/models"
+	"github.com/YugenDev/global-mobility-test/internal/utils"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type ICouponRepository interface {
+	CreateCoupon(coupon *models.Coupon) error
+	GetCouponByID(id string) (models.Coupon, error)
+	GetCouponByCode(code string) (models.Coupon, error)
+	GetAllCoupons() ([]models.Coupon, error)
+	UpdateCoupon(coupon *models.Coupon) (*mongo.UpdateResult, error)
+	DeleteCoupon(id string) (*mongo.DeleteResult, error)
+	IncrementUsage(id string) (*mongo.UpdateResult, error)
+	DecrementUsage(id string) (*mongo.UpdateResult, error)
+}
+
+type CouponRepository struct {
+	Collection MongoCollection
+}
+
+var _ ICouponRepository = (*CouponRepository)(nil)
+
+func NewCouponRepository() *CouponRepository {
+	return &CouponRepository{
+		Collection: config.GetCollection("coupons"),
+	}
+}
+
+func (r *CouponRepository) CreateCoupon(coupon *models.Coupon) error {
+	if r.Collection == nil {
+		return utils.ErrDatabaseNotInitialized
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if _, err := r.Collection.InsertOne(ctx, coupon); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return utils.ErrCouponCodeExists
+		}
+		log.Println("Error creating coupon: ", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *CouponRepository) GetCouponByID(id string) (models.Coupon, error) {
+	if id == "" {
+		return models.Coupon{}, utils.ErrCouponIDRequired
+	}
+	return r.findCoupon(bson.M{"coupon_id": id})
+}
+
+func (r *CouponRepository) GetCouponByCode(code string) (models.Coupon, error) {
+	if code == "" {
+		return models.Coupon{}, utils.ErrCouponCodeRequired
+	}
+	return r.findCoupon(bson.M{"code": code})
+}
+
+func (r *CouponRepository) GetAllCoupons() ([]models.Coupon, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "code", Value: 1}})
+
+	var coupons []models.Coupon
+	cursor, err := r.Collection.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		log.Println("Error getting coupons: ", err)
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var coupon models.Coupon
+		if err := cursor.Decode(&coupon); err != nil {
+			log.Println("Error decoding coupon: ", err)
+			continue
+		}
+		coupons = append(coupons, coupon)
+	}
+
+	if err := cursor.Err(); err != nil {
+		log.Println("Cursor error: ", err)
+		return nil, err
+	}
+
+	return coupons, nil
+}
+
+// UpdateCoupon replaces the coupon's rules. The usage count is left alone so
+// that redemptions made meanwhile are not lost.
+func (r *CouponRepository) UpdateCoupon(coupon *models.Coupon) (*mongo.UpdateResult, error) {
+	if coupon.CouponID == "" {
+		return nil, utils.ErrCouponIDRequired
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	set := bson.M{
+		"code":        coupon.Code,
+		"type":        coupon.Type,
+		"usage_limit": coupon.UsageLimit,
+		"updated_at":  coupon.UpdatedAt,
+	}
+	unset := bson.M{}
+	optional := map[string]interface{}{
+		"percent":      coupon.Percent,
+		"amount":       coupon.Amount,
+		"buy_quantity": coupon.BuyQuantity,
+		"get_quantity": coupon.GetQuantity,
+		"min_subtotal": coupon.MinSubtotal,
+		"product_ids":  coupon.ProductIDs,
+		"category_ids": coupon.CategoryIDs,
+		"starts_at":    coupon.StartsAt,
+		"ends_at":      coupon.EndsAt,
+	}
+	for field, value := range optional {
+		if reflect.ValueOf(value).IsZero() {
+			unset[field] = ""
+		} else {
+			set[field] = value
+		}
+	}
+
+	update := bson.M{"$set": set}
+	if len(unset) > 0 {
+		update["$unset"] = unset
+	}
+
+	result, err := r.Collection.UpdateOne(ctx, bson.M{"coupon_id": coupon.CouponID}, update)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return nil, utils.ErrCouponCodeExists
+		}
+		log.Println("Error updating coupon: ", err)
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (r *CouponRepository) DeleteCoupon(id string) (*mongo.DeleteResult, error) {
+	if id == "" {
+		return nil, utils.ErrCouponIDRequired
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	result, err := r.Collection.DeleteOne(ctx, bson.M{"coupon_id": id})
+	if err != nil {
+		log.Println("Error deleting coupon: ", err)
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// IncrementUsage counts one redemption. It only matches while the coupon is
+// below its usage limit, so concurrent checkouts cannot overshoot it.
+func (r *CouponRepository) IncrementUsage(id string) (*mongo.UpdateResult, error) {
+	if id == "" {
+		return nil, utils.ErrCouponIDRequired
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{
+		"coupon_id": id,
+		"$or": bson.A{
+			bson.M{"usage_limit": 0},
+			bson.M{"$expr": bson.M{"$lt": bson.A{"$usage_count", "$usage_limit"}}},
+		},
+	}
+	update := bson.M{"$inc": bson.M{"usage_count": 1}}
+
+	result, err := r.Collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		log.Println("Error redeeming coupon: ", err)
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (r *CouponRepository) DecrementUsage(id string) (*mongo.UpdateResult, error) {
+	if id == "" {
+		return nil, utils.ErrCouponIDRequired
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{"coupon_id": id, "usage_count": bson.M{"$gt": 0}}
+	update := bson.M{"$inc": bson.M{"usage_count": -1}}
+
+	result, err := r.Collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		log.Println("Error releasing coupon: ", err)
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (r *CouponRepository) findCoupon(filter bson.M) (models.Coupon, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var coupon models.Coupon
+	if err := r.Collection.FindOne(ctx, filter).Decode(&coupon); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return models.Coupon{}, utils.ErrCouponNotFound
+		}
+		log.Println("Error getting coupon: ", err)
+		return models.Coupon{}, err
+	}
+
+	return coupon, nil
+}
@@ -0,0 +1,134 @@
+package repositories
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/YugenDev/global-mobility-test/internal/models"
+)
+
+type IExchangeRateRepository interface {
+	GetRates() (models.ExchangeRates, error)
+	SaveRates(rates models.ExchangeRates) error
+}
+
+// MemoryExchangeRateRepository keeps the rate table in memory, so rates set
+// through the API are lost on restart.
+type MemoryExchangeRateRepository struct {
+	mu    sync.RWMutex
+	rates models.ExchangeRates
+}
+
+var _ IExchangeRateRepository = (*MemoryExchangeRateRepository)(nil)
+
+func NewMemoryExchangeRateRepository() *MemoryExchangeRateRepository {
+	return &MemoryExchangeRateRepository{
+		rates: models.ExchangeRates{Base: models.DefaultCurrency},
+	}
+}
+
+func (r *MemoryExchangeRateRepository) GetRates() (models.ExchangeRates, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return cloneExchangeRates(r.rates), nil
+}
+
+func (r *MemoryExchangeRateRepository) SaveRates(rates models.ExchangeRates) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.rates = cloneExchangeRates(rates)
+	return nil
+}
+
+// FileExchangeRateRepository keeps the rate table in a JSON file and serves
+// reads from a copy loaded when it is created.
+type FileExchangeRateRepository struct {
+	Path string
+
+	mu    sync.RWMutex
+	rates models.ExchangeRates
+}
+
+var _ IExchangeRateRepository = (*FileExchangeRateRepository)(nil)
+
+// NewFileExchangeRateRepository loads the rate table from path and checks it
+// like rates set through the API, so that a bad file fails at startup rather
+// than on requests. A missing file starts an empty table that is created on
+// the first save.
+func NewFileExchangeRateRepository(path string) (*FileExchangeRateRepository, error) {
+	repo := &FileExchangeRateRepository{
+		Path:  path,
+		rates: models.ExchangeRates{Base: models.DefaultCurrency},
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return repo, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &repo.rates); err != nil {
+		return nil, err
+	}
+	if err := repo.rates.Normalize(); err != nil {
+		return nil, err
+	}
+
+	return repo, nil
+}
+
+func (r *FileExchangeRateRepository) GetRates() (models.ExchangeRates, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return cloneExchangeRates(r.rates), nil
+}
+
+// SaveRates writes the table to a temporary file and renames it over the old
+// one, so a crash never leaves a half written file.
+func (r *FileExchangeRateRepository) SaveRates(rates models.ExchangeRates) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	data, err := json.MarshalIndent(rates, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(r.Path), 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(r.Path), ".exchange-rates-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), r.Path); err != nil {
+		return err
+	}
+
+	r.rates = cloneExchangeRates(rates)
+	return nil
+}
+
+func cloneExchangeRates(rates models.ExchangeRates) models.ExchangeRates {
+	rates.Rates = maps.Clone(rates.Rates)
+	rates.Rounding = maps.Clone(rates.Rounding)
+	return rates
+}
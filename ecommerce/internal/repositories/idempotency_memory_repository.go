@@ -0,0 +1,86 @@
+package repositories
+
+import (
+	"sync"
+	"time"
+
+	"github.com/YugenDev/global-mobility-test/internal/models"
+	"github.com/YugenDev/global-mobility-test/internal/utils"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type MemoryIdempotencyRepository struct {
+	mu      sync.RWMutex
+	records map[string]models.IdempotencyRecord
+}
+
+var _ IIdempotencyRepository = (*MemoryIdempotencyRepository)(nil)
+
+func NewMemoryIdempotencyRepository() *MemoryIdempotencyRepository {
+	return &MemoryIdempotencyRepository{
+		records: make(map[string]models.IdempotencyRecord),
+	}
+}
+
+// ClaimIdempotencyKey also drops expired records, which keeps the map from
+// growing without bound.
+func (r *MemoryIdempotencyRepository) ClaimIdempotencyKey(record *models.IdempotencyRecord) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for key, existing := range r.records {
+		if !existing.ExpiresAt.After(record.CreatedAt) {
+			delete(r.records, key)
+		}
+	}
+	if _, ok := r.records[record.Key]; ok {
+		return utils.ErrIdempotencyKeyExists
+	}
+
+	r.records[record.Key] = *record
+	return nil
+}
+
+func (r *MemoryIdempotencyRepository) GetIdempotencyRecord(key string) (models.IdempotencyRecord, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	record, ok := r.records[key]
+	if !ok || !record.ExpiresAt.After(time.Now()) {
+		return models.IdempotencyRecord{}, utils.ErrIdempotencyRecordNotFound
+	}
+
+	return record, nil
+}
+
+func (r *MemoryIdempotencyRepository) CompleteIdempotencyRecord(record *models.IdempotencyRecord) (*mongo.UpdateResult, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, ok := r.records[record.Key]
+	if !ok || existing.Fingerprint != record.Fingerprint || existing.Completed() {
+		return &mongo.UpdateResult{}, nil
+	}
+
+	existing.Status = record.Status
+	existing.ContentType = record.ContentType
+	existing.ETag = record.ETag
+	existing.Location = record.Location
+	existing.Body = record.Body
+	existing.ExpiresAt = record.ExpiresAt
+	r.records[record.Key] = existing
+
+	return &mongo.UpdateResult{MatchedCount: 1, ModifiedCount: 1}, nil
+}
+
+func (r *MemoryIdempotencyRepository) DeleteIdempotencyRecord(key string) (*mongo.DeleteResult, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.records[key]; !ok {
+		return &mongo.DeleteResult{}, nil
+	}
+	delete(r.records, key)
+
+	return &mongo.DeleteResult{DeletedCount: 1}, nil
+}
@@ -0,0 +1,115 @@
+package repositories
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/YugenDev/global-mobility-test/internal/config"
+	"github.com/YugenDev/global-mobility-test/internal/models"
+	"github.com/YugenDev/global-mobility-test/internal/utils"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type IIdempotencyRepository interface {
+	ClaimIdempotencyKey(record *models.IdempotencyRecord) error
+	GetIdempotencyRecord(key string) (models.IdempotencyRecord, error)
+	CompleteIdempotencyRecord(record *models.IdempotencyRecord) (*mongo.UpdateResult, error)
+	DeleteIdempotencyRecord(key string) (*mongo.DeleteResult, error)
+}
+
+type IdempotencyRepository struct {
+	Collection MongoCollection
+}
+
+var _ IIdempotencyRepository = (*IdempotencyRepository)(nil)
+
+func NewIdempotencyRepository() *IdempotencyRepository {
+	return &IdempotencyRepository{
+		Collection: config.GetCollection("idempotency_keys"),
+	}
+}
+
+// ClaimIdempotencyKey stores record unless a record with its key has not
+// expired yet. An expired record is replaced, since the TTL index may not
+// have removed it yet; a live one makes the upsert insert a duplicate key.
+func (r *IdempotencyRepository) ClaimIdempotencyKey(record *models.IdempotencyRecord) error {
+	if r.Collection == nil {
+		return utils.ErrDatabaseNotInitialized
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{"key": record.Key, "expires_at": bson.M{"$lte": record.CreatedAt}}
+	update := bson.M{"$set": record}
+	opts := options.Update().SetUpsert(true)
+
+	if _, err := r.Collection.UpdateOne(ctx, filter, update, opts); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return utils.ErrIdempotencyKeyExists
+		}
+		log.Println("Error claiming idempotency key: ", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *IdempotencyRepository) GetIdempotencyRecord(key string) (models.IdempotencyRecord, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{"key": key, "expires_at": bson.M{"$gt": time.Now()}}
+
+	var record models.IdempotencyRecord
+	if err := r.Collection.FindOne(ctx, filter).Decode(&record); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return models.IdempotencyRecord{}, utils.ErrIdempotencyRecordNotFound
+		}
+		log.Println("Error getting idempotency record: ", err)
+		return models.IdempotencyRecord{}, err
+	}
+
+	return record, nil
+}
+
+// CompleteIdempotencyRecord stores the response of the request that claimed
+// the key. It only matches that claim, while it is still in progress.
+func (r *IdempotencyRepository) CompleteIdempotencyRecord(record *models.IdempotencyRecord) (*mongo.UpdateResult, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{"key": record.Key, "fingerprint": record.Fingerprint, "status": 0}
+	update := bson.M{"$set": bson.M{
+		"status":       record.Status,
+		"content_type": record.ContentType,
+		"etag":         record.ETag,
+		"location":     record.Location,
+		"body":         record.Body,
+		"expires_at":   record.ExpiresAt,
+	}}
+
+	result, err := r.Collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		log.Println("Error completing idempotency record: ", err)
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (r *IdempotencyRepository) DeleteIdempotencyRecord(key string) (*mongo.DeleteResult, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	result, err := r.Collection.DeleteOne(ctx, bson.M{"key": key})
+	if err != nil {
+		log.Println("Error deleting idempotency record: ", err)
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -0,0 +1,76 @@
+package repositories
+
+import (
+	"sync"
+	"time"
+
+	"github.com/YugenDev/global-mobility-test/internal/models"
+	"github.com/YugenDev/global-mobility-test/internal/utils"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type MemoryOrderRepository struct {
+	mu     sync.RWMutex
+	orders map[string]models.Order
+}
+
+var _ IOrderRepository = (*MemoryOrderRepository)(nil)
+
+func NewMemoryOrderRepository() *MemoryOrderRepository {
+	return &MemoryOrderRepository{
+		orders: make(map[string]models.Order),
+	}
+}
+
+func (r *MemoryOrderRepository) CreateOrder(order *models.Order) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.orders[order.OrderID] = copyOrder(*order)
+	return nil
+}
+
+func (r *MemoryOrderRepository) GetOrderByID(id string) (models.Order, error) {
+	if id == "" {
+		return models.Order{}, utils.ErrOrderIDRequired
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	order, ok := r.orders[id]
+	if !ok {
+		return models.Order{}, utils.ErrOrderNotFound
+	}
+
+	return copyOrder(order), nil
+}
+
+func (r *MemoryOrderRepository) TransitionOrder(id string, from string, to string, at time.Time) (*mongo.UpdateResult, error) {
+	if id == "" {
+		return nil, utils.ErrOrderIDRequired
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	order, ok := r.orders[id]
+	if !ok || order.Status != from {
+		return &mongo.UpdateResult{}, nil
+	}
+
+	order = copyOrder(order)
+	order.Status = to
+	order.UpdatedAt = at
+	order.History = append(order.History, models.OrderStatusChange{Status: to, At: at})
+	r.orders[id] = order
+
+	return &mongo.UpdateResult{MatchedCount: 1, ModifiedCount: 1}, nil
+}
+
+// copyOrder keeps callers from sharing the stored order's slices.
+func copyOrder(order models.Order) models.Order {
+	order.Items = append([]models.OrderItem{}, order.Items...)
+	order.History = append([]models.OrderStatusChange{}, order.History...)
+	return order
+}
@@ -0,0 +1,92 @@
+package repositories
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/YugenDev/global-mobility-test/internal/config"
+	"github.com/YugenDev/global-mobility-test/internal/models"
+	"github.com/YugenDev/global-mobility-test/internal/utils"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type IOrderRepository interface {
+	CreateOrder(order *models.Order) error
+	GetOrderByID(id string) (models.Order, error)
+	TransitionOrder(id string, from string, to string, at time.Time) (*mongo.UpdateResult, error)
+}
+
+type OrderRepository struct {
+	Collection MongoCollection
+}
+
+var _ IOrderRepository = (*OrderRepository)(nil)
+
+func NewOrderRepository() *OrderRepository {
+	return &OrderRepository{
+		Collection: config.GetCollection("orders"),
+	}
+}
+
+func (r *OrderRepository) CreateOrder(order *models.Order) error {
+	if r.Collection == nil {
+		return utils.ErrDatabaseNotInitialized
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if _, err := r.Collection.InsertOne(ctx, order); err != nil {
+		log.Println("Error creating order: ", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *OrderRepository) GetOrderByID(id string) (models.Order, error) {
+	if id == "" {
+		return models.Order{}, utils.ErrOrderIDRequired
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var order models.Order
+	if err := r.Collection.FindOne(ctx, bson.M{"order_id": id}).Decode(&order); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return models.Order{}, utils.ErrOrderNotFound
+		}
+		log.Println("Error getting order by ID: ", err)
+		return models.Order{}, err
+	}
+
+	return order, nil
+}
+
+// TransitionOrder moves the order to status to and records the change, but
+// only while it is still in status from.
+func (r *OrderRepository) TransitionOrder(id string, from string, to string, at time.Time) (*mongo.UpdateResult, error) {
+	if id == "" {
+		return nil, utils.ErrOrderIDRequired
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{"order_id": id, "status": from}
+	update := bson.M{
+		"$set":  bson.M{"status": to, "updated_at": at},
+		"$push": bson.M{"history": models.OrderStatusChange{Status: to, At: at}},
+	}
+
+	result, err := r.Collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		log.Println("Error updating order status: ", err)
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -0,0 +1,71 @@
+package repositories
+
+import (
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/YugenDev/global-mobility-test/internal/models"
+	"github.com/YugenDev/global-mobility-test/internal/utils"
+)
+
+type MemoryPriceHistoryRepository struct {
+	mu      sync.RWMutex
+	entries []models.PriceHistoryEntry
+}
+
+var _ IPriceHistoryRepository = (*MemoryPriceHistoryRepository)(nil)
+
+func NewMemoryPriceHistoryRepository() *MemoryPriceHistoryRepository {
+	return &MemoryPriceHistoryRepository{}
+}
+
+func (r *MemoryPriceHistoryRepository) CreateEntry(entry *models.PriceHistoryEntry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.entries = append(r.entries, *entry)
+	return nil
+}
+
+func (r *MemoryPriceHistoryRepository) GetEntriesByProductID(query models.PriceHistoryQuery) ([]models.PriceHistoryEntry, error) {
+	if query.ProductID == "" {
+		return nil, utils.ErrProductIDRequired
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var matches []models.PriceHistoryEntry
+	for _, entry := range r.entries {
+		if entry.ProductID == query.ProductID {
+			matches = append(matches, entry)
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		return comparePriceHistoryEntries(matches[i], matches[j]) > 0
+	})
+
+	var entries []models.PriceHistoryEntry
+	for _, entry := range matches {
+		if query.After != nil {
+			after := models.PriceHistoryEntry{EffectiveAt: query.After.EffectiveAt, EntryID: query.After.EntryID}
+			if comparePriceHistoryEntries(entry, after) >= 0 {
+				continue
+			}
+		}
+		entries = append(entries, entry)
+		if query.Limit > 0 && len(entries) == query.Limit {
+			break
+		}
+	}
+
+	return entries, nil
+}
+
+func comparePriceHistoryEntries(a, b models.PriceHistoryEntry) int {
+	if result := a.EffectiveAt.Compare(b.EffectiveAt); result != 0 {
+		return result
+	}
+	return strings.Compare(a.EntryID, b.EntryID)
+}
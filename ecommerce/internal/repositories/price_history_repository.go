@@ -0,0 +1,92 @@
+package repositories
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/YugenDev/global-mobility-test/internal/config"
+	"github.com/YugenDev/global-mobility-test/internal/models"
+	"github.com/YugenDev/global-mobility-test/internal/utils"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type IPriceHistoryRepository interface {
+	CreateEntry(entry *models.PriceHistoryEntry) error
+	GetEntriesByProductID(query models.PriceHistoryQuery) ([]models.PriceHistoryEntry, error)
+}
+
+type PriceHistoryRepository struct {
+	Collection MongoCollection
+}
+
+var _ IPriceHistoryRepository = (*PriceHistoryRepository)(nil)
+
+func NewPriceHistoryRepository() *PriceHistoryRepository {
+	return &PriceHistoryRepository{
+		Collection: config.GetCollection("price_history"),
+	}
+}
+
+func (r *PriceHistoryRepository) CreateEntry(entry *models.PriceHistoryEntry) error {
+	if r.Collection == nil {
+		return utils.ErrDatabaseNotInitialized
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if _, err := r.Collection.InsertOne(ctx, entry); err != nil {
+		log.Println("Error creating price history entry: ", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *PriceHistoryRepository) GetEntriesByProductID(query models.PriceHistoryQuery) ([]models.PriceHistoryEntry, error) {
+	if query.ProductID == "" {
+		return nil, utils.ErrProductIDRequired
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{"product_id": query.ProductID}
+	if query.After != nil {
+		filter["$or"] = bson.A{
+			bson.M{"effective_at": bson.M{"$lt": query.After.EffectiveAt}},
+			bson.M{"effective_at": query.After.EffectiveAt, "entry_id": bson.M{"$lt": query.After.EntryID}},
+		}
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "effective_at", Value: -1}, {Key: "entry_id", Value: -1}})
+	if query.Limit > 0 {
+		opts.SetLimit(int64(query.Limit))
+	}
+
+	var entries []models.PriceHistoryEntry
+	cursor, err := r.Collection.Find(ctx, filter, opts)
+	if err != nil {
+		log.Println("Error getting price history entries: ", err)
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var entry models.PriceHistoryEntry
+		if err := cursor.Decode(&entry); err != nil {
+			log.Println("Error decoding price history entry: ", err)
+			continue
+		}
+		entries = append(entries, entry)
+	}
+
+	if err := cursor.Err(); err != nil {
+		log.Println("Cursor error: ", err)
+		return nil, err
+	}
+
+	return entries, nil
+}
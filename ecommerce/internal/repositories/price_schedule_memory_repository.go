@@ -0,0 +1,135 @@
+package repositories
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/YugenDev/global-mobility-test/internal/models"
+	"github.com/YugenDev/global-mobility-test/internal/utils"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type MemoryPriceScheduleRepository struct {
+	mu        sync.RWMutex
+	schedules map[string]models.PriceSchedule
+}
+
+var _ IPriceScheduleRepository = (*MemoryPriceScheduleRepository)(nil)
+
+func NewMemoryPriceScheduleRepository() *MemoryPriceScheduleRepository {
+	return &MemoryPriceScheduleRepository{
+		schedules: make(map[string]models.PriceSchedule),
+	}
+}
+
+func (r *MemoryPriceScheduleRepository) CreateSchedule(schedule *models.PriceSchedule) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.schedules[schedule.ScheduleID] = *schedule
+	return nil
+}
+
+func (r *MemoryPriceScheduleRepository) GetScheduleByID(id string) (models.PriceSchedule, error) {
+	if id == "" {
+		return models.PriceSchedule{}, utils.ErrPriceScheduleIDRequired
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	schedule, ok := r.schedules[id]
+	if !ok {
+		return models.PriceSchedule{}, utils.ErrPriceScheduleNotFound
+	}
+
+	return schedule, nil
+}
+
+func (r *MemoryPriceScheduleRepository) GetSchedulesByProductID(productID string) ([]models.PriceSchedule, error) {
+	if productID == "" {
+		return nil, utils.ErrProductIDRequired
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var schedules []models.PriceSchedule
+	for _, schedule := range r.schedules {
+		if schedule.ProductID == productID {
+			schedules = append(schedules, schedule)
+		}
+	}
+	sort.Slice(schedules, func(i, j int) bool {
+		return schedules[i].StartsAt.Before(schedules[j].StartsAt)
+	})
+
+	return schedules, nil
+}
+
+func (r *MemoryPriceScheduleRepository) TransitionSchedule(id string, from string, to string, revertPrice *models.Money) (*mongo.UpdateResult, error) {
+	if id == "" {
+		return nil, utils.ErrPriceScheduleIDRequired
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	schedule, ok := r.schedules[id]
+	if !ok || schedule.Status != from {
+		return &mongo.UpdateResult{}, nil
+	}
+
+	schedule.Status = to
+	if revertPrice != nil {
+		price := *revertPrice
+		schedule.RevertPrice = &price
+	}
+	schedule.UpdatedAt = time.Now()
+	r.schedules[id] = schedule
+
+	return &mongo.UpdateResult{MatchedCount: 1, ModifiedCount: 1}, nil
+}
+
+func (r *MemoryPriceScheduleRepository) GetSchedulesToStart(now time.Time, limit int) ([]models.PriceSchedule, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var due []models.PriceSchedule
+	for _, schedule := range r.schedules {
+		if schedule.Status == models.PriceScheduleStatusPending && !schedule.StartsAt.After(now) {
+			due = append(due, schedule)
+		}
+	}
+	sort.Slice(due, func(i, j int) bool {
+		return due[i].StartsAt.Before(due[j].StartsAt)
+	})
+
+	if limit > 0 && len(due) > limit {
+		due = due[:limit]
+	}
+
+	return due, nil
+}
+
+func (r *MemoryPriceScheduleRepository) GetSchedulesToEnd(now time.Time, limit int) ([]models.PriceSchedule, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var due []models.PriceSchedule
+	for _, schedule := range r.schedules {
+		if schedule.Status == models.PriceScheduleStatusActive && schedule.EndsAt != nil && !schedule.EndsAt.After(now) {
+			due = append(due, schedule)
+		}
+	}
+	sort.Slice(due, func(i, j int) bool {
+		return due[i].EndsAt.Before(*due[j].EndsAt)
+	})
+
+	if limit > 0 && len(due) > limit {
+		due = due[:limit]
+	}
+
+	return due, nil
+}
@@ -0,0 +1,149 @@
+package repositories
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/YugenDev/global-mobility-test/internal/config"
+	"github.com/YugenDev/global-mobility-test/internal/models"
+	"github.com/YugenDev/global-mobility-test/internal/utils"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type IPriceScheduleRepository interface {
+	CreateSchedule(schedule *models.PriceSchedule) error
+	GetScheduleByID(id string) (models.PriceSchedule, error)
+	GetSchedulesByProductID(productID string) ([]models.PriceSchedule, error)
+	TransitionSchedule(id string, from string, to string, revertPrice *models.Money) (*mongo.UpdateResult, error)
+	GetSchedulesToStart(now time.Time, limit int) ([]models.PriceSchedule, error)
+	GetSchedulesToEnd(now time.Time, limit int) ([]models.PriceSchedule, error)
+}
+
+type PriceScheduleRepository struct {
+	Collection MongoCollection
+}
+
+var _ IPriceScheduleRepository = (*PriceScheduleRepository)(nil)
+
+func NewPriceScheduleRepository() *PriceScheduleRepository {
+	return &PriceScheduleRepository{
+		Collection: config.GetCollection("price_schedules"),
+	}
+}
+
+func (r *PriceScheduleRepository) CreateSchedule(schedule *models.PriceSchedule) error {
+	if r.Collection == nil {
+		return utils.ErrDatabaseNotInitialized
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if _, err := r.Collection.InsertOne(ctx, schedule); err != nil {
+		log.Println("Error creating price schedule: ", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *PriceScheduleRepository) GetScheduleByID(id string) (models.PriceSchedule, error) {
+	if id == "" {
+		return models.PriceSchedule{}, utils.ErrPriceScheduleIDRequired
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var schedule models.PriceSchedule
+	err := r.Collection.FindOne(ctx, bson.M{"schedule_id": id}).Decode(&schedule)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return models.PriceSchedule{}, utils.ErrPriceScheduleNotFound
+		}
+		log.Println("Error getting price schedule by ID: ", err)
+		return models.PriceSchedule{}, err
+	}
+
+	return schedule, nil
+}
+
+func (r *PriceScheduleRepository) GetSchedulesByProductID(productID string) ([]models.PriceSchedule, error) {
+	if productID == "" {
+		return nil, utils.ErrProductIDRequired
+	}
+
+	return r.findSchedules(bson.M{"product_id": productID}, bson.D{{Key: "starts_at", Value: 1}}, 0)
+}
+
+// TransitionSchedule moves a schedule from one status to another, storing
+// revertPrice when it is not nil. It only matches while the schedule is still
+// in from, so two schedulers, or a scheduler and a cancel, cannot both win.
+func (r *PriceScheduleRepository) TransitionSchedule(id string, from string, to string, revertPrice *models.Money) (*mongo.UpdateResult, error) {
+	if id == "" {
+		return nil, utils.ErrPriceScheduleIDRequired
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	set := bson.M{"status": to, "updated_at": time.Now()}
+	if revertPrice != nil {
+		set["revert_price"] = *revertPrice
+	}
+
+	result, err := r.Collection.UpdateOne(ctx, bson.M{"schedule_id": id, "status": from}, bson.M{"$set": set})
+	if err != nil {
+		log.Println("Error updating price schedule: ", err)
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (r *PriceScheduleRepository) GetSchedulesToStart(now time.Time, limit int) ([]models.PriceSchedule, error) {
+	filter := bson.M{"status": models.PriceScheduleStatusPending, "starts_at": bson.M{"$lte": now}}
+	return r.findSchedules(filter, bson.D{{Key: "starts_at", Value: 1}}, limit)
+}
+
+func (r *PriceScheduleRepository) GetSchedulesToEnd(now time.Time, limit int) ([]models.PriceSchedule, error) {
+	filter := bson.M{"status": models.PriceScheduleStatusActive, "ends_at": bson.M{"$lte": now}}
+	return r.findSchedules(filter, bson.D{{Key: "ends_at", Value: 1}}, limit)
+}
+
+func (r *PriceScheduleRepository) findSchedules(filter bson.M, sort bson.D, limit int) ([]models.PriceSchedule, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	opts := options.Find().SetSort(sort)
+	if limit > 0 {
+		opts.SetLimit(int64(limit))
+	}
+
+	var schedules []models.PriceSchedule
+	cursor, err := r.Collection.Find(ctx, filter, opts)
+	if err != nil {
+		log.Println("Error getting price schedules: ", err)
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var schedule models.PriceSchedule
+		if err := cursor.Decode(&schedule); err != nil {
+			log.Println("Error decoding price schedule: ", err)
+			continue
+		}
+		schedules = append(schedules, schedule)
+	}
+
+	if err := cursor.Err(); err != nil {
+		log.Println("Cursor error: ", err)
+		return nil, err
+	}
+
+	return schedules, nil
+}
@@ -0,0 +1,653 @@
+package repositories
+
+import (
+	"cmp"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/YugenDev/global-mobility-test/internal/models"
+	"github.com/YugenDev/global-mobility-test/internal/utils"
+	"github.com/labstack/echo/v4"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// MemoryProductRepository keeps products in process memory. It mirrors the
+// observable behaviour of ProductRepository so it can stand in for MongoDB
+// during local development and tests.
+type MemoryProductRepository struct {
+	mu       sync.RWMutex
+	products map[string]models.Product
+}
+
+var _ IProductRepository = (*MemoryProductRepository)(nil)
+
+func NewMemoryProductRepository() *MemoryProductRepository {
+	return &MemoryProductRepository{
+		products: make(map[string]models.Product),
+	}
+}
+
+func (r *MemoryProductRepository) CreateProduct(c echo.Context, product *models.Product) (*mongo.InsertOneResult, error) {
+	if product == nil {
+		return nil, utils.ErrNullProductData
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.products[product.ProductID]; exists {
+		return nil, utils.ErrProductIDAlreadyExists
+	}
+
+	product.CreatedAt = time.Now()
+	product.UpdatedAt = time.Now()
+	product.Version = 1
+	product.Reserved = 0
+	product.ProductRating = models.ProductRating{}
+
+	r.products[product.ProductID] = *product
+
+	return &mongo.InsertOneResult{InsertedID: primitive.NewObjectID()}, nil
+}
+
+func (r *MemoryProductRepository) GetAllProducts(query models.ProductQuery) ([]models.Product, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	field, desc := query.SortSpec()
+
+	sorted := make([]models.Product, 0, len(r.products))
+	for _, product := range r.products {
+		if (product.DeletedAt != nil) == query.Trashed && matchesProductFilter(product, query.Filter) {
+			sorted = append(sorted, product)
+		}
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return compareProducts(sorted[i], sorted[j], field, desc) < 0
+	})
+
+	var after models.Product
+	if query.After != nil {
+		after = models.Product{
+			ProductID: query.After.ProductID,
+			Name:      query.After.Name,
+			Price:     models.Money{Amount: query.After.Price},
+			CreatedAt: query.After.CreatedAt,
+		}
+	}
+
+	var products []models.Product
+	for _, product := range sorted {
+		if query.After != nil && compareProducts(after, product, field, desc) >= 0 {
+			continue
+		}
+		products = append(products, product)
+		if query.Limit > 0 && len(products) == query.Limit {
+			break
+		}
+	}
+
+	return products, nil
+}
+
+func (r *MemoryProductRepository) GetProductByID(id string) (models.Product, error) {
+	if id == "" {
+		return models.Product{}, utils.ErrProductIDRequired
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	product, ok := r.products[id]
+	if !ok || product.DeletedAt != nil {
+		return models.Product{}, mongo.ErrNoDocuments
+	}
+
+	return product, nil
+}
+
+func (r *MemoryProductRepository) UpdateProduct(c echo.Context, id string, product *models.Product) (*mongo.UpdateResult, error) {
+	if id == "" {
+		return nil, utils.ErrProductIDRequired
+	}
+	if product == nil {
+		return nil, utils.ErrNullProductData
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	product.UpdatedAt = time.Now()
+
+	existing, ok := r.products[id]
+	if !ok || existing.DeletedAt != nil || existing.Version != product.Version {
+		return &mongo.UpdateResult{}, nil
+	}
+	merged := mergeProduct(existing, *product)
+	if merged.Reserved > merged.Stock {
+		return &mongo.UpdateResult{}, nil
+	}
+
+	product.Version++
+	merged.Version = product.Version
+	r.products[id] = merged
+
+	return &mongo.UpdateResult{MatchedCount: 1, ModifiedCount: 1}, nil
+}
+
+func (r *MemoryProductRepository) DeleteProduct(c echo.Context, id string, version int) (*mongo.DeleteResult, error) {
+	if id == "" {
+		return nil, utils.ErrProductIDRequired
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, ok := r.products[id]
+	if !ok || existing.DeletedAt != nil || existing.Version != version {
+		return &mongo.DeleteResult{}, nil
+	}
+
+	now := time.Now()
+	existing.DeletedAt = &now
+	existing.UpdatedAt = now
+	existing.Version++
+	r.products[id] = existing
+
+	return &mongo.DeleteResult{DeletedCount: 1}, nil
+}
+
+func (r *MemoryProductRepository) RestoreProduct(c echo.Context, id string) (*mongo.UpdateResult, error) {
+	if id == "" {
+		return nil, utils.ErrProductIDRequired
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, ok := r.products[id]
+	if !ok || existing.DeletedAt == nil {
+		return &mongo.UpdateResult{}, nil
+	}
+
+	existing.DeletedAt = nil
+	existing.UpdatedAt = time.Now()
+	existing.Version++
+	r.products[id] = existing
+
+	return &mongo.UpdateResult{MatchedCount: 1, ModifiedCount: 1}, nil
+}
+
+func (r *MemoryProductRepository) PurgeDeletedProducts(before time.Time) ([]models.Product, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var purged []models.Product
+	for id, product := range r.products {
+		if product.DeletedAt != nil && !product.DeletedAt.After(before) {
+			delete(r.products, id)
+			purged = append(purged, product)
+		}
+	}
+
+	return purged, nil
+}
+
+func (r *MemoryProductRepository) SearchProducts(query models.ProductSearchQuery) ([]models.ProductSearchResult, error) {
+	if query.Text == "" {
+		return nil, utils.ErrSearchQueryRequired
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	terms := searchTerms(query.Text)
+
+	var matches []models.ProductSearchResult
+	for _, product := range r.products {
+		if product.DeletedAt != nil {
+			continue
+		}
+		score := textScore(terms, product.Name, 3) + textScore(terms, product.Description, 1)
+		if score > 0 {
+			matches = append(matches, models.ProductSearchResult{Product: product, Score: score})
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Score != matches[j].Score {
+			return matches[i].Score > matches[j].Score
+		}
+		return matches[i].ProductID < matches[j].ProductID
+	})
+
+	if query.Offset >= len(matches) {
+		return nil, nil
+	}
+	matches = matches[query.Offset:]
+	if query.Limit > 0 && len(matches) > query.Limit {
+		matches = matches[:query.Limit]
+	}
+
+	return matches, nil
+}
+
+// EnsureTextIndex is a no-op: the in-memory store scans products on search.
+func (r *MemoryProductRepository) EnsureTextIndex() error {
+	return nil
+}
+
+func (r *MemoryProductRepository) AdjustStock(c echo.Context, id string, delta int) (models.Product, error) {
+	if id == "" {
+		return models.Product{}, utils.ErrProductIDRequired
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	product, ok := r.products[id]
+	if !ok || product.DeletedAt != nil {
+		return models.Product{}, mongo.ErrNoDocuments
+	}
+	if len(product.Variants) > 0 {
+		return models.Product{}, utils.ErrProductHasVariants
+	}
+	if product.Available()+delta < 0 {
+		return models.Product{}, utils.ErrInsufficientStock
+	}
+
+	product.Stock += delta
+	product.Version++
+	product.UpdatedAt = time.Now()
+	r.products[id] = product
+
+	return product, nil
+}
+
+func (r *MemoryProductRepository) AdjustVariantStock(c echo.Context, id string, sku string, delta int) (models.Product, error) {
+	if id == "" {
+		return models.Product{}, utils.ErrProductIDRequired
+	}
+	if sku == "" {
+		return models.Product{}, utils.ErrVariantSKURequired
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	product, ok := r.products[id]
+	if !ok || product.DeletedAt != nil {
+		return models.Product{}, mongo.ErrNoDocuments
+	}
+
+	index := slices.IndexFunc(product.Variants, func(variant models.Variant) bool {
+		return variant.SKU == sku
+	})
+	if index < 0 {
+		return models.Product{}, utils.ErrVariantNotFound
+	}
+	if product.Variants[index].Stock+delta < 0 || product.Available()+delta < 0 {
+		return models.Product{}, utils.ErrInsufficientStock
+	}
+
+	product.Variants = models.CloneVariants(product.Variants)
+	product.Variants[index].Stock += delta
+	product.Stock += delta
+	product.Version++
+	product.UpdatedAt = time.Now()
+	r.products[id] = product
+
+	return product, nil
+}
+
+func (r *MemoryProductRepository) UpdateVariants(c echo.Context, id string, version int, variants []models.Variant) (*mongo.UpdateResult, error) {
+	if id == "" {
+		return nil, utils.ErrProductIDRequired
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	product, ok := r.products[id]
+	if !ok || product.DeletedAt != nil || product.Version != version {
+		return &mongo.UpdateResult{}, nil
+	}
+
+	product.Variants = nil
+	if len(variants) > 0 {
+		product.Variants = models.CloneVariants(variants)
+	}
+	product.Stock = models.VariantStock(variants)
+	product.Version++
+	product.UpdatedAt = time.Now()
+	r.products[id] = product
+
+	return &mongo.UpdateResult{MatchedCount: 1, ModifiedCount: 1}, nil
+}
+
+func (r *MemoryProductRepository) ReserveStock(c echo.Context, id string, quantity int) (models.Product, error) {
+	if id == "" {
+		return models.Product{}, utils.ErrProductIDRequired
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	product, ok := r.products[id]
+	if !ok || product.DeletedAt != nil {
+		return models.Product{}, mongo.ErrNoDocuments
+	}
+	if len(product.Variants) > 0 {
+		return models.Product{}, utils.ErrProductHasVariants
+	}
+	if product.Available() < quantity {
+		return models.Product{}, utils.ErrInsufficientStock
+	}
+
+	product.Reserved += quantity
+	r.products[id] = product
+
+	return product, nil
+}
+
+func (r *MemoryProductRepository) ReleaseStock(c echo.Context, id string, quantity int) (*mongo.UpdateResult, error) {
+	if id == "" {
+		return nil, utils.ErrProductIDRequired
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	product, ok := r.products[id]
+	if !ok || product.Reserved < quantity {
+		return &mongo.UpdateResult{}, nil
+	}
+
+	product.Reserved -= quantity
+	r.products[id] = product
+
+	return &mongo.UpdateResult{MatchedCount: 1, ModifiedCount: 1}, nil
+}
+
+func (r *MemoryProductRepository) CommitReservedStock(c echo.Context, id string, quantity int) (models.Product, error) {
+	if id == "" {
+		return models.Product{}, utils.ErrProductIDRequired
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	product, ok := r.products[id]
+	if !ok || product.DeletedAt != nil || product.Reserved < quantity || product.Stock < quantity || len(product.Variants) > 0 {
+		return models.Product{}, mongo.ErrNoDocuments
+	}
+
+	product.Stock -= quantity
+	product.Reserved -= quantity
+	product.Version++
+	product.UpdatedAt = time.Now()
+	r.products[id] = product
+
+	return product, nil
+}
+
+func (r *MemoryProductRepository) UpdateImages(c echo.Context, id string, version int, images []models.ProductImage) (*mongo.UpdateResult, error) {
+	if id == "" {
+		return nil, utils.ErrProductIDRequired
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	product, ok := r.products[id]
+	if !ok || product.DeletedAt != nil || product.Version != version {
+		return &mongo.UpdateResult{}, nil
+	}
+
+	product.Images = nil
+	if len(images) > 0 {
+		product.Images = slices.Clone(images)
+	}
+	product.Version++
+	product.UpdatedAt = time.Now()
+	r.products[id] = product
+
+	return &mongo.UpdateResult{MatchedCount: 1, ModifiedCount: 1}, nil
+}
+
+func (r *MemoryProductRepository) ReassignCategory(id string, from string, to string) (models.Product, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	product, ok := r.products[id]
+	if !ok || !slices.Contains(product.CategoryIDs, from) {
+		return models.Product{}, mongo.ErrNoDocuments
+	}
+	before := product
+
+	categoryIDs := slices.DeleteFunc(slices.Clone(product.CategoryIDs), func(categoryID string) bool {
+		return categoryID == from
+	})
+	if !slices.Contains(categoryIDs, to) {
+		categoryIDs = append(categoryIDs, to)
+	}
+
+	product.CategoryIDs = categoryIDs
+	product.Version++
+	product.UpdatedAt = time.Now()
+	r.products[id] = product
+
+	return before, nil
+}
+
+func (r *MemoryProductRepository) BulkWriteProducts(c echo.Context, writes []models.ProductWrite) ([]error, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	errs := make([]error, len(writes))
+	now := time.Now()
+	for i := range writes {
+		write := &writes[i]
+		id := write.Product.ProductID
+
+		if write.Op == models.BulkOpCreate {
+			if _, exists := r.products[id]; exists {
+				errs[i] = utils.ErrProductIDAlreadyExists
+				for j := i + 1; j < len(writes); j++ {
+					errs[j] = utils.ErrBulkOperationSkipped
+				}
+				break
+			}
+			write.Product.CreatedAt = now
+			write.Product.UpdatedAt = now
+			write.Product.Version = 1
+			write.Product.Reserved = 0
+			write.Product.ProductRating = models.ProductRating{}
+			r.products[id] = write.Product
+			continue
+		}
+
+		existing, ok := r.products[id]
+		if !ok || existing.DeletedAt != nil || existing.Version != write.Product.Version ||
+			(write.Op != models.BulkOpDelete && existing.Reserved > mergeProduct(existing, write.Product).Stock) {
+			errs[i] = utils.ErrProductVersionMismatch
+			continue
+		}
+
+		write.Product.UpdatedAt = now
+		write.Product.Version++
+		if write.Op == models.BulkOpDelete {
+			existing.DeletedAt = &now
+			existing.UpdatedAt = now
+			existing.Version = write.Product.Version
+			write.Product.DeletedAt = &now
+			r.products[id] = existing
+		} else {
+			r.products[id] = mergeProduct(existing, write.Product)
+		}
+	}
+
+	return errs, nil
+}
+
+func (r *MemoryProductRepository) AdjustRating(id string, count int, total int) (*mongo.UpdateResult, error) {
+	if id == "" {
+		return nil, utils.ErrProductIDRequired
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	product, ok := r.products[id]
+	if !ok {
+		return &mongo.UpdateResult{}, nil
+	}
+
+	product.RatingCount += count
+	product.RatingTotal += total
+	product.RatingAverage = 0
+	if product.RatingCount > 0 {
+		product.RatingAverage = math.Round(float64(product.RatingTotal)/float64(product.RatingCount)*100) / 100
+	}
+	r.products[id] = product
+
+	return &mongo.UpdateResult{MatchedCount: 1, ModifiedCount: 1}, nil
+}
+
+func searchTerms(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
+
+// textScore approximates MongoDB's text score: every occurrence of a search
+// term in text counts once, scaled by the field weight.
+func textScore(terms []string, text string, weight float64) float64 {
+	var score float64
+	words := searchTerms(text)
+	for _, term := range terms {
+		for _, word := range words {
+			if word == term {
+				score += weight
+			}
+		}
+	}
+	return score
+}
+
+// priceIn returns the product's own price when it is in currency, else its
+// explicit price in currency, if it has one.
+func priceIn(product models.Product, currency string) (models.Money, bool) {
+	if product.Price.Currency == currency {
+		return product.Price, true
+	}
+	return product.PriceOverride(currency)
+}
+
+func matchesProductFilter(product models.Product, filter models.ProductFilter) bool {
+	if filter.MinPrice != nil {
+		price, ok := priceIn(product, filter.MinPrice.Currency)
+		if !ok || price.Amount < filter.MinPrice.Amount {
+			return false
+		}
+	}
+	if filter.MaxPrice != nil {
+		price, ok := priceIn(product, filter.MaxPrice.Currency)
+		if !ok || price.Amount > filter.MaxPrice.Amount {
+			return false
+		}
+	}
+	if filter.InStock != nil && (product.Stock > 0) != *filter.InStock {
+		return false
+	}
+	if filter.NamePrefix != "" && !strings.HasPrefix(strings.ToLower(product.Name), strings.ToLower(filter.NamePrefix)) {
+		return false
+	}
+	if filter.CreatedAfter != nil && !product.CreatedAt.After(*filter.CreatedAfter) {
+		return false
+	}
+	if len(filter.CategoryIDs) > 0 && !hasAnyCategory(product, filter.CategoryIDs) {
+		return false
+	}
+	return true
+}
+
+func hasAnyCategory(product models.Product, categoryIDs []string) bool {
+	for _, id := range categoryIDs {
+		if slices.Contains(product.CategoryIDs, id) {
+			return true
+		}
+	}
+	return false
+}
+
+// compareProducts orders a and b by field, breaking ties by ascending
+// product ID the same way the Mongo repository's sort does.
+func compareProducts(a, b models.Product, field string, desc bool) int {
+	var result int
+	switch field {
+	case "price":
+		result = cmp.Compare(a.Price.Amount, b.Price.Amount)
+	case "name":
+		result = strings.Compare(a.Name, b.Name)
+	default:
+		result = a.CreatedAt.Compare(b.CreatedAt)
+	}
+	if desc {
+		result = -result
+	}
+	if result != 0 {
+		return result
+	}
+	return strings.Compare(a.ProductID, b.ProductID)
+}
+
+// mergeProduct applies the non-zero fields of src onto dst, matching what a
+// $set of a Product with omitempty tags does in MongoDB.
+func mergeProduct(dst, src models.Product) models.Product {
+	if src.ProductID != "" {
+		dst.ProductID = src.ProductID
+	}
+	if src.Name != "" {
+		dst.Name = src.Name
+	}
+	if src.Description != "" {
+		dst.Description = src.Description
+	}
+	if !src.Price.IsZero() {
+		dst.Price = src.Price
+	}
+	if src.Stock != 0 {
+		dst.Stock = src.Stock
+	}
+	if src.CategoryIDs != nil {
+		dst.CategoryIDs = src.CategoryIDs
+	}
+	if src.Prices != nil {
+		dst.Prices = src.Prices
+	}
+	if len(src.Variants) > 0 {
+		dst.Variants = src.Variants
+	}
+	if len(src.Images) > 0 {
+		dst.Images = src.Images
+	}
+	if src.Version != 0 {
+		dst.Version = src.Version
+	}
+	if src.DeletedAt != nil {
+		dst.DeletedAt = src.DeletedAt
+	}
+	if !src.CreatedAt.IsZero() {
+		dst.CreatedAt = src.CreatedAt
+	}
+	if !src.UpdatedAt.IsZero() {
+		dst.UpdatedAt = src.UpdatedAt
+	}
+	return dst
+}
@@ -2,7 +2,9 @@ package repositories
 
 import (
 	"context"
+	"errors"
 	"log"
+	"regexp"
 	"time"
 
 	"github.com/YugenDev/global-mobility-test/internal/config"
@@ -16,10 +18,24 @@ import (
 
 type IProductRepository interface {
 	CreateProduct(c echo.Context, product *models.Product) (*mongo.InsertOneResult, error)
-	GetAllProducts() ([]models.Product, error)
+	GetAllProducts(query models.ProductQuery) ([]models.Product, error)
 	GetProductByID(id string) (models.Product, error)
 	UpdateProduct(c echo.Context, id string, product *models.Product) (*mongo.UpdateResult, error)
-	DeleteProduct(c echo.Context, id string) (*mongo.DeleteResult, error)
+	DeleteProduct(c echo.Context, id string, version int) (*mongo.DeleteResult, error)
+	RestoreProduct(c echo.Context, id string) (*mongo.UpdateResult, error)
+	PurgeDeletedProducts(before time.Time) ([]models.Product, error)
+	SearchProducts(query models.ProductSearchQuery) ([]models.ProductSearchResult, error)
+	EnsureTextIndex() error
+	BulkWriteProducts(c echo.Context, writes []models.ProductWrite) ([]error, error)
+	AdjustStock(c echo.Context, id string, delta int) (models.Product, error)
+	ReserveStock(c echo.Context, id string, quantity int) (models.Product, error)
+	ReleaseStock(c echo.Context, id string, quantity int) (*mongo.UpdateResult, error)
+	CommitReservedStock(c echo.Context, id string, quantity int) (models.Product, error)
+	ReassignCategory(id string, from string, to string) (models.Product, error)
+	AdjustVariantStock(c echo.Context, id string, sku string, delta int) (models.Product, error)
+	UpdateVariants(c echo.Context, id string, version int, variants []models.Variant) (*mongo.UpdateResult, error)
+	UpdateImages(c echo.Context, id string, version int, images []models.ProductImage) (*mongo.UpdateResult, error)
+	AdjustRating(id string, count int, total int) (*mongo.UpdateResult, error)
 }
 
 type MongoCollection interface {
@@ -28,8 +44,18 @@ type MongoCollection interface {
 	FindOne(ctx context.Context, filter interface{}, opts ...*options.FindOneOptions) *mongo.SingleResult
 	UpdateOne(ctx context.Context, filter interface{}, update interface{}, opts ...*options.UpdateOptions) (*mongo.UpdateResult, error)
 	DeleteOne(ctx context.Context, filter interface{}, opts ...*options.DeleteOptions) (*mongo.DeleteResult, error)
+	DeleteMany(ctx context.Context, filter interface{}, opts ...*options.DeleteOptions) (*mongo.DeleteResult, error)
+	UpdateMany(ctx context.Context, filter interface{}, update interface{}, opts ...*options.UpdateOptions) (*mongo.UpdateResult, error)
+	FindOneAndUpdate(ctx context.Context, filter interface{}, update interface{}, opts ...*options.FindOneAndUpdateOptions) *mongo.SingleResult
+	FindOneAndDelete(ctx context.Context, filter interface{}, opts ...*options.FindOneAndDeleteOptions) *mongo.SingleResult
+	BulkWrite(ctx context.Context, models []mongo.WriteModel, opts ...*options.BulkWriteOptions) (*mongo.BulkWriteResult, error)
+	Indexes() mongo.IndexView
 }
 
+const productTextIndexName = "product_text_search"
+
+var productTextIndexWeights = bson.D{{Key: "name", Value: 3}, {Key: "description", Value: 1}}
+
 type ProductRepository struct {
 	Collection MongoCollection
 }
@@ -55,9 +81,15 @@ func (r *ProductRepository) CreateProduct(c echo.Context, product *models.Produc
 
 	product.CreatedAt = time.Now()
 	product.UpdatedAt = time.Now()
+	product.Version = 1
+	product.Reserved = 0
+	product.ProductRating = models.ProductRating{}
 
 	result, err := r.Collection.InsertOne(ctx, product)
 	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return nil, utils.ErrProductIDAlreadyExists
+		}
 		log.Println("Error creating product: ", err)
 		return nil, err
 	}
@@ -65,12 +97,25 @@ func (r *ProductRepository) CreateProduct(c echo.Context, product *models.Produc
 	return result, nil
 }
 
-func (r *ProductRepository) GetAllProducts() ([]models.Product, error) {
+func (r *ProductRepository) GetAllProducts(query models.ProductQuery) ([]models.Product, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
+	field, desc := query.SortSpec()
+	direction := 1
+	if desc {
+		direction = -1
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: sortKey(field), Value: direction}, {Key: "product_id", Value: 1}})
+	if query.Limit > 0 {
+		opts.SetLimit(int64(query.Limit))
+	}
+
+	filter := buildProductFilter(query)
+
 	var products []models.Product
-	cursor, err := r.Collection.Find(ctx, bson.M{})
+	cursor, err := r.Collection.Find(ctx, filter, opts)
 	if err != nil {
 		log.Println("Error getting products: ", err)
 		return nil, err
@@ -103,7 +148,7 @@ func (r *ProductRepository) GetProductByID(id string) (models.Product, error) {
 	defer cancel()
 
 	var product models.Product
-	err := r.Collection.FindOne(ctx, bson.M{"product_id": id}).Decode(&product)
+	err := r.Collection.FindOne(ctx, bson.M{"product_id": id, "deleted_at": nil}).Decode(&product)
 	if err != nil {
 		log.Println("Error getting product by ID: ", err)
 		return models.Product{}, err
@@ -125,16 +170,20 @@ func (r *ProductRepository) UpdateProduct(c echo.Context, id string, product *mo
 
 	product.UpdatedAt = time.Now()
 
-	result, err := r.Collection.UpdateOne(ctx, bson.M{"product_id": id}, bson.M{"$set": product})
+	result, err := r.Collection.UpdateOne(ctx, productUpdateFilter(*product), productUpdate(*product))
 	if err != nil {
 		log.Println("Error updating product: ", err)
 		return nil, err
 	}
 
+	if result.MatchedCount > 0 {
+		product.Version++
+	}
+
 	return result, nil
 }
 
-func (r *ProductRepository) DeleteProduct(c echo.Context, id string) (*mongo.DeleteResult, error) {
+func (r *ProductRepository) DeleteProduct(c echo.Context, id string, version int) (*mongo.DeleteResult, error) {
 	if id == "" {
 		return nil, utils.ErrProductIDRequired
 	}
@@ -142,11 +191,704 @@ func (r *ProductRepository) DeleteProduct(c echo.Context, id string) (*mongo.Del
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	result, err := r.Collection.DeleteOne(ctx, bson.M{"product_id": id})
+	filter := versionFilter(id, version)
+	filter["deleted_at"] = nil
+
+	now := time.Now()
+	update := bson.M{
+		"$set": bson.M{"deleted_at": now, "updated_at": now},
+		"$inc": bson.M{"version": 1},
+	}
+
+	result, err := r.Collection.UpdateOne(ctx, filter, update)
 	if err != nil {
 		log.Println("Error deleting product: ", err)
 		return nil, err
 	}
 
+	return &mongo.DeleteResult{DeletedCount: result.MatchedCount}, nil
+}
+
+func (r *ProductRepository) RestoreProduct(c echo.Context, id string) (*mongo.UpdateResult, error) {
+	if id == "" {
+		return nil, utils.ErrProductIDRequired
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{"product_id": id, "deleted_at": bson.M{"$ne": nil}}
+	update := bson.M{
+		"$unset": bson.M{"deleted_at": ""},
+		"$set":   bson.M{"updated_at": time.Now()},
+		"$inc":   bson.M{"version": 1},
+	}
+
+	result, err := r.Collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		log.Println("Error restoring product: ", err)
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// PurgeDeletedProducts removes the products deleted at or before before one
+// at a time and returns them, so the caller knows exactly which products,
+// and which of their images, are gone. A product restored in the meantime is
+// no longer matched and stays.
+func (r *ProductRepository) PurgeDeletedProducts(before time.Time) ([]models.Product, error) {
+	var purged []models.Product
+	for {
+		product, err := r.purgeDeletedProduct(before)
+		if err == mongo.ErrNoDocuments {
+			return purged, nil
+		}
+		if err != nil {
+			log.Println("Error purging deleted products: ", err)
+			return purged, err
+		}
+		purged = append(purged, product)
+	}
+}
+
+func (r *ProductRepository) purgeDeletedProduct(before time.Time) (models.Product, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var product models.Product
+	err := r.Collection.FindOneAndDelete(ctx, bson.M{"deleted_at": bson.M{"$lte": before}}).Decode(&product)
+	return product, err
+}
+
+func (r *ProductRepository) SearchProducts(query models.ProductSearchQuery) ([]models.ProductSearchResult, error) {
+	if query.Text == "" {
+		return nil, utils.ErrSearchQueryRequired
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	score := bson.M{"$meta": "textScore"}
+	opts := options.Find().
+		SetProjection(bson.M{"score": score}).
+		SetSort(bson.D{{Key: "score", Value: score}, {Key: "product_id", Value: 1}}).
+		SetSkip(int64(query.Offset))
+	if query.Limit > 0 {
+		opts.SetLimit(int64(query.Limit))
+	}
+
+	var results []models.ProductSearchResult
+	filter := bson.M{"$text": bson.M{"$search": query.Text}, "deleted_at": nil}
+	cursor, err := r.Collection.Find(ctx, filter, opts)
+	if err != nil {
+		log.Println("Error searching products: ", err)
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var result models.ProductSearchResult
+		if err := cursor.Decode(&result); err != nil {
+			log.Println("Error decoding search result: ", err)
+			continue
+		}
+		results = append(results, result)
+	}
+
+	if err := cursor.Err(); err != nil {
+		log.Println("Cursor error: ", err)
+		return nil, err
+	}
+
+	return results, nil
+}
+
+func (r *ProductRepository) EnsureTextIndex() error {
+	if r.Collection == nil {
+		return utils.ErrDatabaseNotInitialized
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	index := mongo.IndexModel{
+		Keys: bson.D{{Key: "name", Value: "text"}, {Key: "description", Value: "text"}},
+		Options: options.Index().
+			SetName(productTextIndexName).
+			SetWeights(productTextIndexWeights),
+	}
+
+	if _, err := r.Collection.Indexes().CreateOne(ctx, index); err != nil {
+		log.Println("Error creating text index: ", err)
+		return err
+	}
+
+	return nil
+}
+
+// AdjustStock adds delta to the product's stock in a single conditional
+// update. Decrements only match while enough unreserved stock is left, so
+// concurrent callers can never take stock below what is reserved.
+func (r *ProductRepository) AdjustStock(c echo.Context, id string, delta int) (models.Product, error) {
+	if id == "" {
+		return models.Product{}, utils.ErrProductIDRequired
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{"product_id": id, "deleted_at": nil, "variants.0": bson.M{"$exists": false}}
+	if delta < 0 {
+		filter["$expr"] = availableAtLeast(-delta)
+	}
+	update := bson.M{
+		"$inc": bson.M{"stock": delta, "version": 1},
+		"$set": bson.M{"updated_at": time.Now()},
+	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var product models.Product
+	err := r.Collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&product)
+	if err == mongo.ErrNoDocuments {
+		existing, findErr := r.GetProductByID(id)
+		if findErr != nil {
+			return models.Product{}, err
+		}
+		if len(existing.Variants) > 0 {
+			return models.Product{}, utils.ErrProductHasVariants
+		}
+		return models.Product{}, utils.ErrInsufficientStock
+	}
+	if err != nil {
+		log.Println("Error adjusting stock: ", err)
+		return models.Product{}, err
+	}
+
+	return product, nil
+}
+
+// AdjustVariantStock changes one variant's stock and the product's total by
+// delta in a single update. Like AdjustStock, a decrement only matches while
+// both the variant and the product have enough units.
+func (r *ProductRepository) AdjustVariantStock(c echo.Context, id string, sku string, delta int) (models.Product, error) {
+	if id == "" {
+		return models.Product{}, utils.ErrProductIDRequired
+	}
+	if sku == "" {
+		return models.Product{}, utils.ErrVariantSKURequired
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	match := bson.M{"sku": sku}
+	filter := bson.M{"product_id": id, "deleted_at": nil}
+	if delta < 0 {
+		match["stock"] = bson.M{"$gte": -delta}
+		filter["$expr"] = availableAtLeast(-delta)
+	}
+	filter["variants"] = bson.M{"$elemMatch": match}
+	update := bson.M{
+		"$inc": bson.M{"variants.$.stock": delta, "stock": delta, "version": 1},
+		"$set": bson.M{"updated_at": time.Now()},
+	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var product models.Product
+	err := r.Collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&product)
+	if err == mongo.ErrNoDocuments {
+		existing, findErr := r.GetProductByID(id)
+		if findErr != nil {
+			return models.Product{}, err
+		}
+		if _, ok := existing.Variant(sku); !ok {
+			return models.Product{}, utils.ErrVariantNotFound
+		}
+		return models.Product{}, utils.ErrInsufficientStock
+	}
+	if err != nil {
+		log.Println("Error adjusting variant stock: ", err)
+		return models.Product{}, err
+	}
+
+	return product, nil
+}
+
+// UpdateVariants replaces the product's variants and resets its stock to
+// their combined stock, provided the product is still at version.
+func (r *ProductRepository) UpdateVariants(c echo.Context, id string, version int, variants []models.Variant) (*mongo.UpdateResult, error) {
+	if id == "" {
+		return nil, utils.ErrProductIDRequired
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	filter := versionFilter(id, version)
+	filter["deleted_at"] = nil
+
+	set := bson.M{"stock": models.VariantStock(variants), "updated_at": time.Now()}
+	update := bson.M{"$set": set, "$inc": bson.M{"version": 1}}
+	if len(variants) == 0 {
+		update["$unset"] = bson.M{"variants": ""}
+	} else {
+		set["variants"] = variants
+	}
+
+	result, err := r.Collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		log.Println("Error updating variants: ", err)
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// ReserveStock holds quantity units for a reservation without taking them out
+// of stock. It only matches while that many units are still available.
+// Reservations do not name a variant, so products with variants, whose stock
+// is kept per variant, cannot be reserved.
+func (r *ProductRepository) ReserveStock(c echo.Context, id string, quantity int) (models.Product, error) {
+	if id == "" {
+		return models.Product{}, utils.ErrProductIDRequired
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{"product_id": id, "deleted_at": nil, "variants.0": bson.M{"$exists": false}, "$expr": availableAtLeast(quantity)}
+	update := bson.M{"$inc": bson.M{"reserved": quantity}}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var product models.Product
+	err := r.Collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&product)
+	if err == mongo.ErrNoDocuments {
+		existing, findErr := r.GetProductByID(id)
+		if findErr != nil {
+			return models.Product{}, err
+		}
+		if len(existing.Variants) > 0 {
+			return models.Product{}, utils.ErrProductHasVariants
+		}
+		return models.Product{}, utils.ErrInsufficientStock
+	}
+	if err != nil {
+		log.Println("Error reserving stock: ", err)
+		return models.Product{}, err
+	}
+
+	return product, nil
+}
+
+func (r *ProductRepository) ReleaseStock(c echo.Context, id string, quantity int) (*mongo.UpdateResult, error) {
+	if id == "" {
+		return nil, utils.ErrProductIDRequired
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{"product_id": id, "reserved": bson.M{"$gte": quantity}}
+	update := bson.M{"$inc": bson.M{"reserved": -quantity}}
+
+	result, err := r.Collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		log.Println("Error releasing stock: ", err)
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// CommitReservedStock turns held units into a sale by taking them out of both
+// stock and reserved in one update. Like ReserveStock, it does not match a
+// product that has gained variants since.
+func (r *ProductRepository) CommitReservedStock(c echo.Context, id string, quantity int) (models.Product, error) {
+	if id == "" {
+		return models.Product{}, utils.ErrProductIDRequired
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{
+		"product_id": id,
+		"deleted_at": nil,
+		"reserved":   bson.M{"$gte": quantity},
+		"stock":      bson.M{"$gte": quantity},
+		"variants.0": bson.M{"$exists": false},
+	}
+	update := bson.M{
+		"$inc": bson.M{"stock": -quantity, "reserved": -quantity, "version": 1},
+		"$set": bson.M{"updated_at": time.Now()},
+	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var product models.Product
+	err := r.Collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&product)
+	if err != nil {
+		log.Println("Error committing reserved stock: ", err)
+		return models.Product{}, err
+	}
+
+	return product, nil
+}
+
+// BulkWriteProducts runs writes as one ordered BulkWrite. The returned slice
+// holds one error per write: nil when it was applied, the failure for the
+// write that stopped the batch, and ErrBulkOperationSkipped for every write
+// after it. Updates and deletes whose version no longer matches do not stop
+// the batch and are reported as ErrProductVersionMismatch.
+func (r *ProductRepository) BulkWriteProducts(c echo.Context, writes []models.ProductWrite) ([]error, error) {
+	if r.Collection == nil {
+		return nil, utils.ErrDatabaseNotInitialized
+	}
+
+	errs := make([]error, len(writes))
+	if len(writes) == 0 {
+		return errs, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	writeModels := make([]mongo.WriteModel, len(writes))
+	for i := range writes {
+		writeModels[i] = productWriteModel(&writes[i], now)
+	}
+
+	applied := len(writes)
+	result, err := r.Collection.BulkWrite(ctx, writeModels, options.BulkWrite().SetOrdered(true))
+	if err != nil {
+		var bulkErr mongo.BulkWriteException
+		if !errors.As(err, &bulkErr) || len(bulkErr.WriteErrors) == 0 {
+			log.Println("Error running bulk write: ", err)
+			return nil, err
+		}
+
+		writeErr := bulkErr.WriteErrors[0]
+		applied = writeErr.Index
+		for i := applied + 1; i < len(writes); i++ {
+			errs[i] = utils.ErrBulkOperationSkipped
+		}
+		if mongo.IsDuplicateKeyError(writeErr.WriteError) {
+			errs[applied] = utils.ErrProductIDAlreadyExists
+		} else {
+			log.Println("Error running bulk write: ", writeErr)
+			errs[applied] = utils.ErrInternalServer
+		}
+	}
+
+	var conditional int64
+	for _, write := range writes[:applied] {
+		if write.Op != models.BulkOpCreate {
+			conditional++
+		}
+	}
+	if result == nil || result.MatchedCount < conditional {
+		if err := r.markVersionConflicts(ctx, writes[:applied], errs); err != nil {
+			return nil, err
+		}
+	}
+
+	for i := range writes[:applied] {
+		if errs[i] == nil && writes[i].Op != models.BulkOpCreate {
+			writes[i].Product.Version++
+		}
+	}
+
+	return errs, nil
+}
+
+// markVersionConflicts works out which conditional writes did not match.
+// BulkWrite only reports a total matched count, so every update and delete is
+// re-read and compared with the version it should have been bumped to.
+func (r *ProductRepository) markVersionConflicts(ctx context.Context, writes []models.ProductWrite, errs []error) error {
+	for i, write := range writes {
+		if write.Op == models.BulkOpCreate {
+			continue
+		}
+
+		var current models.Product
+		err := r.Collection.FindOne(ctx, bson.M{"product_id": write.Product.ProductID}).Decode(&current)
+		if err != nil && err != mongo.ErrNoDocuments {
+			log.Println("Error checking bulk write result: ", err)
+			return err
+		}
+
+		deleted := current.DeletedAt != nil
+		if err == mongo.ErrNoDocuments || current.Version != write.Product.Version+1 || deleted != (write.Op == models.BulkOpDelete) {
+			errs[i] = utils.ErrProductVersionMismatch
+		}
+	}
+	return nil
+}
+
+func productWriteModel(write *models.ProductWrite, now time.Time) mongo.WriteModel {
+	switch write.Op {
+	case models.BulkOpCreate:
+		write.Product.CreatedAt = now
+		write.Product.UpdatedAt = now
+		write.Product.Version = 1
+		write.Product.Reserved = 0
+		write.Product.ProductRating = models.ProductRating{}
+		return mongo.NewInsertOneModel().SetDocument(write.Product)
+	case models.BulkOpDelete:
+		write.Product.DeletedAt = &now
+		write.Product.UpdatedAt = now
+		filter := versionFilter(write.Product.ProductID, write.Product.Version)
+		filter["deleted_at"] = nil
+		update := bson.M{
+			"$set": bson.M{"deleted_at": now, "updated_at": now},
+			"$inc": bson.M{"version": 1},
+		}
+		return mongo.NewUpdateOneModel().SetFilter(filter).SetUpdate(update)
+	default:
+		write.Product.UpdatedAt = now
+		return mongo.NewUpdateOneModel().SetFilter(productUpdateFilter(write.Product)).SetUpdate(productUpdate(write.Product))
+	}
+}
+
+// UpdateImages replaces the product's images, provided the product is still
+// at version.
+func (r *ProductRepository) UpdateImages(c echo.Context, id string, version int, images []models.ProductImage) (*mongo.UpdateResult, error) {
+	if id == "" {
+		return nil, utils.ErrProductIDRequired
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	filter := versionFilter(id, version)
+	filter["deleted_at"] = nil
+
+	set := bson.M{"updated_at": time.Now()}
+	update := bson.M{"$set": set, "$inc": bson.M{"version": 1}}
+	if len(images) == 0 {
+		update["$unset"] = bson.M{"images": ""}
+	} else {
+		set["images"] = images
+	}
+
+	result, err := r.Collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		log.Println("Error updating images: ", err)
+		return nil, err
+	}
+
 	return result, nil
 }
+
+// AdjustRating adds count reviews with a rating sum of total to the product's
+// rating, trashed products included, and recomputes the average from the new
+// counters in the same pipeline update. The product's version is left alone
+// so that reviews never conflict with edits.
+func (r *ProductRepository) AdjustRating(id string, count int, total int) (*mongo.UpdateResult, error) {
+	if id == "" {
+		return nil, utils.ErrProductIDRequired
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	update := bson.A{
+		bson.M{"$set": bson.M{
+			"rating_count": bson.M{"$add": bson.A{bson.M{"$ifNull": bson.A{"$rating_count", 0}}, count}},
+			"rating_total": bson.M{"$add": bson.A{bson.M{"$ifNull": bson.A{"$rating_total", 0}}, total}},
+		}},
+		bson.M{"$set": bson.M{
+			"rating_average": bson.M{"$cond": bson.A{
+				bson.M{"$gt": bson.A{"$rating_count", 0}},
+				bson.M{"$round": bson.A{bson.M{"$divide": bson.A{"$rating_total", "$rating_count"}}, 2}},
+				0,
+			}},
+		}},
+	}
+
+	result, err := r.Collection.UpdateOne(ctx, bson.M{"product_id": id}, update)
+	if err != nil {
+		log.Println("Error adjusting rating: ", err)
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// ReassignCategory swaps category from for to on the product, trashed or not,
+// in a single pipeline update and returns the product as it was before. It
+// fails with mongo.ErrNoDocuments when the product is no longer in from.
+func (r *ProductRepository) ReassignCategory(id string, from string, to string) (models.Product, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	update := bson.A{bson.M{"$set": bson.M{
+		"category_ids": bson.M{"$setUnion": bson.A{
+			bson.M{"$setDifference": bson.A{"$category_ids", bson.A{from}}},
+			bson.A{to},
+		}},
+		"version":    bson.M{"$add": bson.A{bson.M{"$ifNull": bson.A{"$version", 0}}, 1}},
+		"updated_at": time.Now(),
+	}}}
+
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.Before)
+
+	var product models.Product
+	err := r.Collection.FindOneAndUpdate(ctx, bson.M{"product_id": id, "category_ids": from}, update, opts).Decode(&product)
+	if err != nil && err != mongo.ErrNoDocuments {
+		log.Println("Error reassigning category: ", err)
+	}
+
+	return product, err
+}
+
+// productUpdate builds the update for a full product write. The version and
+// reserved counters and the rating are only ever changed with $inc, and an
+// empty but non-nil CategoryIDs or Prices clears the product's categories or
+// price overrides.
+func productUpdate(product models.Product) bson.M {
+	fields := product
+	fields.Version = 0
+	fields.Reserved = 0
+	fields.ProductRating = models.ProductRating{}
+
+	update := bson.M{"$set": fields, "$inc": bson.M{"version": 1}}
+	unset := bson.M{}
+	if product.CategoryIDs != nil && len(product.CategoryIDs) == 0 {
+		unset["category_ids"] = ""
+	}
+	if product.Prices != nil && len(product.Prices) == 0 {
+		unset["prices"] = ""
+	}
+	if len(unset) > 0 {
+		update["$unset"] = unset
+	}
+	return update
+}
+
+// productUpdateFilter matches the live product at its version, provided a
+// new stock still covers the units reserved in the meantime. A zero stock is
+// left out of the update, so it is not checked.
+func productUpdateFilter(product models.Product) bson.M {
+	filter := versionFilter(product.ProductID, product.Version)
+	filter["deleted_at"] = nil
+	if product.Stock != 0 {
+		filter["$expr"] = bson.M{"$lte": bson.A{bson.M{"$ifNull": bson.A{"$reserved", 0}}, product.Stock}}
+	}
+	return filter
+}
+
+// versionFilter matches the product only while it is still at version.
+// Products written before versioning have no version field and are matched
+// by version 0.
+func versionFilter(id string, version int) bson.M {
+	if version == 0 {
+		return bson.M{"product_id": id, "version": nil}
+	}
+	return bson.M{"product_id": id, "version": version}
+}
+
+// availableAtLeast matches products whose unreserved stock covers quantity.
+// Products that were never reserved have no reserved field.
+func availableAtLeast(quantity int) bson.M {
+	return bson.M{"$gte": bson.A{
+		bson.M{"$subtract": bson.A{"$stock", bson.M{"$ifNull": bson.A{"$reserved", 0}}}},
+		quantity,
+	}}
+}
+
+// priceRangeCondition matches products whose price in the bounds' currency
+// lies within them: their own price when it is in that currency, else their
+// explicit price in it. Overrides never repeat the product's own currency,
+// so only one of the two can apply.
+func priceRangeCondition(minPrice *models.Money, maxPrice *models.Money) bson.M {
+	if minPrice == nil && maxPrice == nil {
+		return nil
+	}
+
+	bounds := bson.M{}
+	currency := ""
+	if minPrice != nil {
+		bounds["$gte"] = minPrice.Amount
+		currency = minPrice.Currency
+	}
+	if maxPrice != nil {
+		bounds["$lte"] = maxPrice.Amount
+		currency = maxPrice.Currency
+	}
+
+	return bson.M{"$or": bson.A{
+		bson.M{"price.currency": currency, "price.amount": bounds},
+		bson.M{"prices": bson.M{"$elemMatch": bson.M{"currency": currency, "amount": bounds}}},
+	}}
+}
+
+func buildProductFilter(query models.ProductQuery) bson.M {
+	var conditions bson.A
+
+	if query.Trashed {
+		conditions = append(conditions, bson.M{"deleted_at": bson.M{"$ne": nil}})
+	} else {
+		conditions = append(conditions, bson.M{"deleted_at": nil})
+	}
+
+	if condition := priceRangeCondition(query.Filter.MinPrice, query.Filter.MaxPrice); condition != nil {
+		conditions = append(conditions, condition)
+	}
+	if query.Filter.InStock != nil {
+		if *query.Filter.InStock {
+			conditions = append(conditions, bson.M{"stock": bson.M{"$gt": 0}})
+		} else {
+			conditions = append(conditions, bson.M{"stock": bson.M{"$not": bson.M{"$gt": 0}}})
+		}
+	}
+	if query.Filter.NamePrefix != "" {
+		conditions = append(conditions, bson.M{"name": bson.M{
+			"$regex":   "^" + regexp.QuoteMeta(query.Filter.NamePrefix),
+			"$options": "i",
+		}})
+	}
+	if query.Filter.CreatedAfter != nil {
+		conditions = append(conditions, bson.M{"created_at": bson.M{"$gt": *query.Filter.CreatedAfter}})
+	}
+	if len(query.Filter.CategoryIDs) > 0 {
+		conditions = append(conditions, bson.M{"category_ids": bson.M{"$in": query.Filter.CategoryIDs}})
+	}
+
+	if query.After != nil {
+		field, desc := query.SortSpec()
+		op := "$gt"
+		if desc {
+			op = "$lt"
+		}
+		value := cursorSortValue(query.After, field)
+		key := sortKey(field)
+		conditions = append(conditions, bson.M{"$or": bson.A{
+			bson.M{key: bson.M{op: value}},
+			bson.M{key: value, "product_id": bson.M{"$gt": query.After.ProductID}},
+		}})
+	}
+
+	return bson.M{"$and": conditions}
+}
+
+// sortKey is the document field a sort field orders by. Prices are ordered
+// by their amount in minor units, whatever their currency.
+func sortKey(field string) string {
+	if field == "price" {
+		return "price.amount"
+	}
+	return field
+}
+
+func cursorSortValue(cursor *models.ProductCursor, field string) interface{} {
+	switch field {
+	case "price":
+		return cursor.Price
+	case "name":
+		return cursor.Name
+	default:
+		return cursor.CreatedAt
+	}
+}
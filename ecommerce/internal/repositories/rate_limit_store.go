@@ -0,0 +1,90 @@
+package repositories
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/YugenDev/global-mobility-test/internal/models"
+)
+
+// IRateLimitStore keeps clients' token buckets. Take must update a bucket
+// atomically, so that a store shared between instances can replace the
+// memory one.
+type IRateLimitStore interface {
+	Take(key string, limit models.RateLimit, now time.Time) (models.RateLimitResult, error)
+}
+
+// rateLimitSweepInterval is how often the memory store drops full buckets.
+const rateLimitSweepInterval = time.Minute
+
+type bucket struct {
+	tokens  float64
+	updated time.Time
+	full    time.Time
+}
+
+// MemoryRateLimitStore keeps the buckets of one instance in memory. A bucket
+// that has refilled is the same as no bucket, so those are dropped now and
+// then to bound memory.
+type MemoryRateLimitStore struct {
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+}
+
+var _ IRateLimitStore = (*MemoryRateLimitStore)(nil)
+
+func NewMemoryRateLimitStore() *MemoryRateLimitStore {
+	return &MemoryRateLimitStore{
+		buckets: make(map[string]*bucket),
+	}
+}
+
+// Take refills key's bucket for the time since it was last used and takes a
+// token from it if there is a whole one.
+func (s *MemoryRateLimitStore) Take(key string, limit models.RateLimit, now time.Time) (models.RateLimitResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sweep(now)
+
+	capacity := float64(limit.Requests)
+	perToken := limit.Period / time.Duration(limit.Requests)
+
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &bucket{tokens: capacity, updated: now}
+		s.buckets[key] = b
+	}
+	if elapsed := now.Sub(b.updated); elapsed > 0 {
+		b.tokens = math.Min(capacity, b.tokens+float64(elapsed)/float64(perToken))
+		b.updated = now
+	}
+
+	result := models.RateLimitResult{Limit: limit.Requests}
+	if b.tokens >= 1 {
+		b.tokens--
+		result.Allowed = true
+	} else {
+		result.RetryAfter = time.Duration((1 - b.tokens) * float64(perToken))
+	}
+	result.Remaining = int(b.tokens)
+	result.Reset = time.Duration((capacity - b.tokens) * float64(perToken))
+	b.full = now.Add(result.Reset)
+
+	return result, nil
+}
+
+func (s *MemoryRateLimitStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < rateLimitSweepInterval {
+		return
+	}
+	s.lastSweep = now
+
+	for key, b := range s.buckets {
+		if !now.Before(b.full) {
+			delete(s.buckets, key)
+		}
+	}
+}
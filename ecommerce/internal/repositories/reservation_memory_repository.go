@@ -0,0 +1,89 @@
+package repositories
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/YugenDev/global-mobility-test/internal/models"
+	"github.com/YugenDev/global-mobility-test/internal/utils"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type MemoryReservationRepository struct {
+	mu           sync.RWMutex
+	reservations map[string]models.Reservation
+}
+
+var _ IReservationRepository = (*MemoryReservationRepository)(nil)
+
+func NewMemoryReservationRepository() *MemoryReservationRepository {
+	return &MemoryReservationRepository{
+		reservations: make(map[string]models.Reservation),
+	}
+}
+
+func (r *MemoryReservationRepository) CreateReservation(reservation *models.Reservation) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.reservations[reservation.ReservationID] = *reservation
+	return nil
+}
+
+func (r *MemoryReservationRepository) GetReservationByID(id string) (models.Reservation, error) {
+	if id == "" {
+		return models.Reservation{}, utils.ErrReservationIDRequired
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	reservation, ok := r.reservations[id]
+	if !ok {
+		return models.Reservation{}, utils.ErrReservationNotFound
+	}
+
+	return reservation, nil
+}
+
+func (r *MemoryReservationRepository) TransitionReservation(id string, from string, to string) (*mongo.UpdateResult, error) {
+	if id == "" {
+		return nil, utils.ErrReservationIDRequired
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	reservation, ok := r.reservations[id]
+	if !ok || reservation.Status != from {
+		return &mongo.UpdateResult{}, nil
+	}
+
+	reservation.Status = to
+	reservation.UpdatedAt = time.Now()
+	r.reservations[id] = reservation
+
+	return &mongo.UpdateResult{MatchedCount: 1, ModifiedCount: 1}, nil
+}
+
+func (r *MemoryReservationRepository) GetExpiredReservations(now time.Time, limit int) ([]models.Reservation, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var expired []models.Reservation
+	for _, reservation := range r.reservations {
+		if reservation.Status == models.ReservationStatusActive && !reservation.ExpiresAt.After(now) {
+			expired = append(expired, reservation)
+		}
+	}
+	sort.Slice(expired, func(i, j int) bool {
+		return expired[i].ExpiresAt.Before(expired[j].ExpiresAt)
+	})
+
+	if limit > 0 && len(expired) > limit {
+		expired = expired[:limit]
+	}
+
+	return expired, nil
+}
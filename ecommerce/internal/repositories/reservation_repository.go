@@ -0,0 +1,128 @@
+package repositories
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/YugenDev/global-mobility-test/internal/config"
+	"github.com/YugenDev/global-mobility-test/internal/models"
+	"github.com/YugenDev/global-mobility-test/internal/utils"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type IReservationRepository interface {
+	CreateReservation(reservation *models.Reservation) error
+	GetReservationByID(id string) (models.Reservation, error)
+	TransitionReservation(id string, from string, to string) (*mongo.UpdateResult, error)
+	GetExpiredReservations(now time.Time, limit int) ([]models.Reservation, error)
+}
+
+type ReservationRepository struct {
+	Collection MongoCollection
+}
+
+var _ IReservationRepository = (*ReservationRepository)(nil)
+
+func NewReservationRepository() *ReservationRepository {
+	return &ReservationRepository{
+		Collection: config.GetCollection("reservations"),
+	}
+}
+
+func (r *ReservationRepository) CreateReservation(reservation *models.Reservation) error {
+	if r.Collection == nil {
+		return utils.ErrDatabaseNotInitialized
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if _, err := r.Collection.InsertOne(ctx, reservation); err != nil {
+		log.Println("Error creating reservation: ", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *ReservationRepository) GetReservationByID(id string) (models.Reservation, error) {
+	if id == "" {
+		return models.Reservation{}, utils.ErrReservationIDRequired
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var reservation models.Reservation
+	err := r.Collection.FindOne(ctx, bson.M{"reservation_id": id}).Decode(&reservation)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return models.Reservation{}, utils.ErrReservationNotFound
+		}
+		log.Println("Error getting reservation by ID: ", err)
+		return models.Reservation{}, err
+	}
+
+	return reservation, nil
+}
+
+// TransitionReservation moves a reservation from one status to another. It
+// only matches while the reservation is still in from, so the sweeper and a
+// confirm or cancel racing on the same reservation cannot both win.
+func (r *ReservationRepository) TransitionReservation(id string, from string, to string) (*mongo.UpdateResult, error) {
+	if id == "" {
+		return nil, utils.ErrReservationIDRequired
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{"reservation_id": id, "status": from}
+	update := bson.M{"$set": bson.M{"status": to, "updated_at": time.Now()}}
+
+	result, err := r.Collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		log.Println("Error updating reservation: ", err)
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (r *ReservationRepository) GetExpiredReservations(now time.Time, limit int) ([]models.Reservation, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{"status": models.ReservationStatusActive, "expires_at": bson.M{"$lte": now}}
+	opts := options.Find().SetSort(bson.D{{Key: "expires_at", Value: 1}})
+	if limit > 0 {
+		opts.SetLimit(int64(limit))
+	}
+
+	var reservations []models.Reservation
+	cursor, err := r.Collection.Find(ctx, filter, opts)
+	if err != nil {
+		log.Println("Error getting expired reservations: ", err)
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var reservation models.Reservation
+		if err := cursor.Decode(&reservation); err != nil {
+			log.Println("Error decoding reservation: ", err)
+			continue
+		}
+		reservations = append(reservations, reservation)
+	}
+
+	if err := cursor.Err(); err != nil {
+		log.Println("Cursor error: ", err)
+		return nil, err
+	}
+
+	return reservations, nil
+}
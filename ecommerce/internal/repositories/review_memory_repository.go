@@ -0,0 +1,107 @@
+package repositories
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/YugenDev/global-mobility-test/internal/models"
+	"github.com/YugenDev/global-mobility-test/internal/utils"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type MemoryReviewRepository struct {
+	mu      sync.RWMutex
+	reviews map[string]models.Review
+}
+
+var _ IReviewRepository = (*MemoryReviewRepository)(nil)
+
+func NewMemoryReviewRepository() *MemoryReviewRepository {
+	return &MemoryReviewRepository{
+		reviews: make(map[string]models.Review),
+	}
+}
+
+func (r *MemoryReviewRepository) CreateReview(review *models.Review) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.reviews[review.ReviewID] = *review
+	return nil
+}
+
+func (r *MemoryReviewRepository) GetReviewByID(id string) (models.Review, error) {
+	if id == "" {
+		return models.Review{}, utils.ErrReviewIDRequired
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	review, ok := r.reviews[id]
+	if !ok {
+		return models.Review{}, utils.ErrReviewNotFound
+	}
+
+	return review, nil
+}
+
+func (r *MemoryReviewRepository) GetReviews(query models.ReviewQuery) ([]models.Review, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var matches []models.Review
+	for _, review := range r.reviews {
+		if (query.ProductID == "" || review.ProductID == query.ProductID) && (query.Status == "" || review.Status == query.Status) {
+			matches = append(matches, review)
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		return compareReviews(matches[i], matches[j]) > 0
+	})
+
+	var reviews []models.Review
+	for _, review := range matches {
+		if query.After != nil {
+			after := models.Review{CreatedAt: query.After.CreatedAt, ReviewID: query.After.ReviewID}
+			if compareReviews(review, after) >= 0 {
+				continue
+			}
+		}
+		reviews = append(reviews, review)
+		if query.Limit > 0 && len(reviews) == query.Limit {
+			break
+		}
+	}
+
+	return reviews, nil
+}
+
+func (r *MemoryReviewRepository) TransitionReview(id string, from string, to string) (*mongo.UpdateResult, error) {
+	if id == "" {
+		return nil, utils.ErrReviewIDRequired
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	review, ok := r.reviews[id]
+	if !ok || review.Status != from {
+		return &mongo.UpdateResult{}, nil
+	}
+
+	review.Status = to
+	review.UpdatedAt = time.Now()
+	r.reviews[id] = review
+
+	return &mongo.UpdateResult{MatchedCount: 1, ModifiedCount: 1}, nil
+}
+
+func compareReviews(a, b models.Review) int {
+	if result := a.CreatedAt.Compare(b.CreatedAt); result != 0 {
+		return result
+	}
+	return strings.Compare(a.ReviewID, b.ReviewID)
+}
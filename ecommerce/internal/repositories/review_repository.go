@@ -0,0 +1,139 @@
+package repositories
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/YugenDev/global-mobility-test/internal/config"
+	"github.com/YugenDev/global-mobility-test/internal/models"
+	"github.com/YugenDev/global-mobility-test/internal/utils"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type IReviewRepository interface {
+	CreateReview(review *models.Review) error
+	GetReviewByID(id string) (models.Review, error)
+	GetReviews(query models.ReviewQuery) ([]models.Review, error)
+	TransitionReview(id string, from string, to string) (*mongo.UpdateResult, error)
+}
+
+type ReviewRepository struct {
+	Collection MongoCollection
+}
+
+var _ IReviewRepository = (*ReviewRepository)(nil)
+
+func NewReviewRepository() *ReviewRepository {
+	return &ReviewRepository{
+		Collection: config.GetCollection("reviews"),
+	}
+}
+
+func (r *ReviewRepository) CreateReview(review *models.Review) error {
+	if r.Collection == nil {
+		return utils.ErrDatabaseNotInitialized
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if _, err := r.Collection.InsertOne(ctx, review); err != nil {
+		log.Println("Error creating review: ", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *ReviewRepository) GetReviewByID(id string) (models.Review, error) {
+	if id == "" {
+		return models.Review{}, utils.ErrReviewIDRequired
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var review models.Review
+	if err := r.Collection.FindOne(ctx, bson.M{"review_id": id}).Decode(&review); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return models.Review{}, utils.ErrReviewNotFound
+		}
+		log.Println("Error getting review: ", err)
+		return models.Review{}, err
+	}
+
+	return review, nil
+}
+
+func (r *ReviewRepository) GetReviews(query models.ReviewQuery) ([]models.Review, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{}
+	if query.ProductID != "" {
+		filter["product_id"] = query.ProductID
+	}
+	if query.Status != "" {
+		filter["status"] = query.Status
+	}
+	if query.After != nil {
+		filter["$or"] = bson.A{
+			bson.M{"created_at": bson.M{"$lt": query.After.CreatedAt}},
+			bson.M{"created_at": query.After.CreatedAt, "review_id": bson.M{"$lt": query.After.ReviewID}},
+		}
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "review_id", Value: -1}})
+	if query.Limit > 0 {
+		opts.SetLimit(int64(query.Limit))
+	}
+
+	var reviews []models.Review
+	cursor, err := r.Collection.Find(ctx, filter, opts)
+	if err != nil {
+		log.Println("Error getting reviews: ", err)
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var review models.Review
+		if err := cursor.Decode(&review); err != nil {
+			log.Println("Error decoding review: ", err)
+			continue
+		}
+		reviews = append(reviews, review)
+	}
+
+	if err := cursor.Err(); err != nil {
+		log.Println("Cursor error: ", err)
+		return nil, err
+	}
+
+	return reviews, nil
+}
+
+// TransitionReview moves the review from one status to another. It only
+// matches while the review is still in from, so two moderators cannot both
+// count the same change.
+func (r *ReviewRepository) TransitionReview(id string, from string, to string) (*mongo.UpdateResult, error) {
+	if id == "" {
+		return nil, utils.ErrReviewIDRequired
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	update := bson.M{"$set": bson.M{"status": to, "updated_at": time.Now()}}
+
+	result, err := r.Collection.UpdateOne(ctx, bson.M{"review_id": id, "status": from}, update)
+	if err != nil {
+		log.Println("Error updating review: ", err)
+		return nil, err
+	}
+
+	return result, nil
+}
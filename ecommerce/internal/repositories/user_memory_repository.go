@@ -0,0 +1,106 @@
+package repositories
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/YugenDev/global-mobility-test/internal/models"
+	"github.com/YugenDev/global-mobility-test/internal/utils"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type MemoryUserRepository struct {
+	mu    sync.RWMutex
+	users map[string]models.User
+}
+
+var _ IUserRepository = (*MemoryUserRepository)(nil)
+
+func NewMemoryUserRepository() *MemoryUserRepository {
+	return &MemoryUserRepository{
+		users: make(map[string]models.User),
+	}
+}
+
+func (r *MemoryUserRepository) CreateUser(user *models.User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.users {
+		if existing.Email == user.Email {
+			return utils.ErrEmailTaken
+		}
+	}
+
+	r.users[user.UserID] = *user
+	return nil
+}
+
+func (r *MemoryUserRepository) GetUserByID(id string) (models.User, error) {
+	if id == "" {
+		return models.User{}, utils.ErrUserIDRequired
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	user, ok := r.users[id]
+	if !ok {
+		return models.User{}, utils.ErrUserNotFound
+	}
+
+	return user, nil
+}
+
+func (r *MemoryUserRepository) GetUserByEmail(email string) (models.User, error) {
+	if email == "" {
+		return models.User{}, utils.ErrInvalidEmail
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, user := range r.users {
+		if user.Email == email {
+			return user, nil
+		}
+	}
+
+	return models.User{}, utils.ErrUserNotFound
+}
+
+func (r *MemoryUserRepository) GetAllUsers() ([]models.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var users []models.User
+	for _, user := range r.users {
+		users = append(users, user)
+	}
+	sort.Slice(users, func(i, j int) bool {
+		return users[i].Email < users[j].Email
+	})
+
+	return users, nil
+}
+
+func (r *MemoryUserRepository) UpdateRoles(id string, roles []string) (*mongo.UpdateResult, error) {
+	if id == "" {
+		return nil, utils.ErrUserIDRequired
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	user, ok := r.users[id]
+	if !ok {
+		return &mongo.UpdateResult{}, nil
+	}
+
+	user.Roles = roles
+	user.UpdatedAt = time.Now()
+	r.users[id] = user
+
+	return &mongo.UpdateResult{MatchedCount: 1, ModifiedCount: 1}, nil
+}
@@ -0,0 +1,133 @@
+package repositories
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/YugenDev/global-mobility-test/internal/config"
+	"github.com/YugenDev/global-mobility-test/internal/models"
+	"github.com/YugenDev/global-mobility-test/internal/utils"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type IUserRepository interface {
+	CreateUser(user *models.User) error
+	GetUserByID(id string) (models.User, error)
+	GetUserByEmail(email string) (models.User, error)
+	GetAllUsers() ([]models.User, error)
+	UpdateRoles(id string, roles []string) (*mongo.UpdateResult, error)
+}
+
+type UserRepository struct {
+	Collection MongoCollection
+}
+
+var _ IUserRepository = (*UserRepository)(nil)
+
+func NewUserRepository() *UserRepository {
+	return &UserRepository{
+		Collection: config.GetCollection("users"),
+	}
+}
+
+func (r *UserRepository) CreateUser(user *models.User) error {
+	if r.Collection == nil {
+		return utils.ErrDatabaseNotInitialized
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if _, err := r.Collection.InsertOne(ctx, user); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return utils.ErrEmailTaken
+		}
+		log.Println("Error creating user: ", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *UserRepository) GetUserByID(id string) (models.User, error) {
+	if id == "" {
+		return models.User{}, utils.ErrUserIDRequired
+	}
+	return r.findUser(bson.M{"user_id": id})
+}
+
+func (r *UserRepository) GetUserByEmail(email string) (models.User, error) {
+	if email == "" {
+		return models.User{}, utils.ErrInvalidEmail
+	}
+	return r.findUser(bson.M{"email": email})
+}
+
+func (r *UserRepository) GetAllUsers() ([]models.User, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "email", Value: 1}})
+
+	var users []models.User
+	cursor, err := r.Collection.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		log.Println("Error getting users: ", err)
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var user models.User
+		if err := cursor.Decode(&user); err != nil {
+			log.Println("Error decoding user: ", err)
+			continue
+		}
+		users = append(users, user)
+	}
+
+	if err := cursor.Err(); err != nil {
+		log.Println("Cursor error: ", err)
+		return nil, err
+	}
+
+	return users, nil
+}
+
+func (r *UserRepository) UpdateRoles(id string, roles []string) (*mongo.UpdateResult, error) {
+	if id == "" {
+		return nil, utils.ErrUserIDRequired
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	update := bson.M{"$set": bson.M{"roles": roles, "updated_at": time.Now()}}
+
+	result, err := r.Collection.UpdateOne(ctx, bson.M{"user_id": id}, update)
+	if err != nil {
+		log.Println("Error updating user roles: ", err)
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (r *UserRepository) findUser(filter bson.M) (models.User, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var user models.User
+	if err := r.Collection.FindOne(ctx, filter).Decode(&user); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return models.User{}, utils.ErrUserNotFound
+		}
+		log.Println("Error getting user: ", err)
+		return models.User{}, err
+	}
+
+	return user, nil
+}
@@ -0,0 +1,15 @@
+package routes
+
+import (
+	"github.com/YugenDev/global-mobility-test/internal/handlers"
+	"github.com/YugenDev/global-mobility-test/internal/models"
+	"github.com/labstack/echo/v4"
+)
+
+func APIKeyRoutes(e *echo.Echo, handler *handlers.APIKeyHandler) {
+	manage := handlers.RequirePermission(models.PermissionAPIKeysManage)
+
+	e.POST("/admin/api-keys", handler.CreateAPIKey, manage)
+	e.GET("/admin/api-keys", handler.GetAPIKeys, manage)
+	e.DELETE("/admin/api-keys/:id", handler.RevokeAPIKey, manage)
+}
@@ -0,0 +1,17 @@
+package routes
+
+import (
+	"github.com/YugenDev/global-mobility-test/internal/handlers"
+	"github.com/YugenDev/global-mobility-test/internal/models"
+	"github.com/labstack/echo/v4"
+)
+
+func AuthRoutes(e *echo.Echo, handler *handlers.AuthHandler) {
+
+	e.POST("/auth/register", handler.Register)
+	e.POST("/auth/login", handler.Login)
+	e.POST("/auth/refresh", handler.Refresh)
+	e.GET("/auth/me", handler.Me, handlers.RequireUser)
+	e.GET("/admin/users", handler.GetUsers, handlers.RequirePermission(models.PermissionUsersManage))
+	e.PUT("/admin/users/:id/roles", handler.SetRoles, handlers.RequirePermission(models.PermissionUsersManage))
+}
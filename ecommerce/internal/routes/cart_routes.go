@@ -0,0 +1,15 @@
+package routes
+
+import (
+	"github.com/YugenDev/global-mobility-test/internal/handlers"
+	"github.com/labstack/echo/v4"
+)
+
+func CartRoutes(e *echo.Echo, handler *handlers.CartHandler) {
+
+	e.POST("/carts", handler.CreateCart)
+	e.GET("/carts/:id", handler.GetCart)
+	e.POST("/carts/:id/items", handler.AddItem)
+	e.PUT("/carts/:id/items/:product_id", handler.UpdateItem)
+	e.DELETE("/carts/:id/items/:product_id", handler.RemoveItem)
+}
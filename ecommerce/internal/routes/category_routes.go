@@ -0,0 +1,19 @@
+package routes
+
+import (
+	"github.com/YugenDev/global-mobility-test/internal/handlers"
+	"github.com/YugenDev/global-mobility-test/internal/models"
+	"github.com/labstack/echo/v4"
+)
+
+func CategoryRoutes(e *echo.Echo, handler *handlers.CategoryHandler) {
+	write := handlers.RequirePermission(models.PermissionProductsWrite)
+
+	e.POST("/categories", handler.CreateCategory, write)
+	e.GET("/categories", handler.GetCategories)
+	e.GET("/categories/tree", handler.GetCategoryTree)
+	e.GET("/categories/:id", handler.GetCategoryByID)
+	e.PUT("/categories/:id", handler.UpdateCategory, write)
+	e.DELETE("/categories/:id", handler.DeleteCategory, write)
+	e.GET("/categories/:id/products", handler.GetCategoryProducts)
+}
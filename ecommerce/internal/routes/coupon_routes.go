@@ -0,0 +1,18 @@
+package routes
+
+import (
+	"github.com/YugenDev/global-mobility-test/internal/handlers"
+	"github.com/YugenDev/global-mobility-test/internal/models"
+	"github.com/labstack/echo/v4"
+)
+
+func CouponRoutes(e *echo.Echo, handler *handlers.CouponHandler) {
+	manage := handlers.RequirePermission(models.PermissionCouponsManage)
+
+	e.POST("/admin/coupons", handler.CreateCoupon, manage)
+	e.GET("/admin/coupons", handler.GetCoupons, manage)
+	e.GET("/admin/coupons/:id", handler.GetCouponByID, manage)
+	e.PUT("/admin/coupons/:id", handler.UpdateCoupon, manage)
+	e.DELETE("/admin/coupons/:id", handler.DeleteCoupon, manage)
+	e.POST("/coupons/evaluate", handler.EvaluateCoupon)
+}
@@ -0,0 +1,13 @@
+package routes
+
+import (
+	"github.com/YugenDev/global-mobility-test/internal/handlers"
+	"github.com/YugenDev/global-mobility-test/internal/models"
+	"github.com/labstack/echo/v4"
+)
+
+func ExchangeRateRoutes(e *echo.Echo, handler *handlers.ExchangeRateHandler) {
+
+	e.GET("/admin/exchange-rates", handler.GetRates)
+	e.PUT("/admin/exchange-rates", handler.UpdateRates, handlers.RequirePermission(models.PermissionProductsWrite))
+}
@@ -0,0 +1,14 @@
+package routes
+
+import (
+	"github.com/YugenDev/global-mobility-test/internal/handlers"
+	"github.com/YugenDev/global-mobility-test/internal/models"
+	"github.com/labstack/echo/v4"
+)
+
+func OrderRoutes(e *echo.Echo, handler *handlers.OrderHandler) {
+
+	e.POST("/checkout", handler.Checkout, handlers.RequireUser)
+	e.GET("/orders/:id", handler.GetOrder)
+	e.PUT("/orders/:id/status", handler.UpdateStatus, handlers.RequirePermission(models.PermissionOrdersManage))
+}
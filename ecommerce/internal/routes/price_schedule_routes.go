@@ -0,0 +1,15 @@
+package routes
+
+import (
+	"github.com/YugenDev/global-mobility-test/internal/handlers"
+	"github.com/YugenDev/global-mobility-test/internal/models"
+	"github.com/labstack/echo/v4"
+)
+
+func PriceScheduleRoutes(e *echo.Echo, handler *handlers.PriceScheduleHandler) {
+	write := handlers.RequirePermission(models.PermissionProductsWrite)
+
+	e.POST("/products/:id/price-schedule", handler.CreatePriceSchedule, write)
+	e.GET("/products/:id/price-schedule", handler.GetPriceSchedules)
+	e.DELETE("/products/:id/price-schedule/:schedule_id", handler.CancelPriceSchedule, write)
+}
@@ -0,0 +1,16 @@
+package routes
+
+import (
+	"github.com/YugenDev/global-mobility-test/internal/handlers"
+	"github.com/YugenDev/global-mobility-test/internal/models"
+	"github.com/labstack/echo/v4"
+)
+
+func ProductImageRoutes(e *echo.Echo, handler *handlers.ProductImageHandler) {
+	write := handlers.RequirePermission(models.PermissionProductsWrite)
+
+	e.POST("/products/:id/images", handler.UploadImage, write)
+	e.PUT("/products/:id/images/order", handler.ReorderImages, write)
+	e.GET("/products/:id/images/:image_id", handler.GetImage)
+	e.DELETE("/products/:id/images/:image_id", handler.DeleteImage, write)
+}
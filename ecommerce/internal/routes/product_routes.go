@@ -2,14 +2,28 @@ package routes
 
 import (
 	"github.com/YugenDev/global-mobility-test/internal/handlers"
+	"github.com/YugenDev/global-mobility-test/internal/models"
 	"github.com/labstack/echo/v4"
 )
 
 func ProductRoutes(e *echo.Echo, handler *handlers.ProductHandler) {
+	write := handlers.RequirePermission(models.PermissionProductsWrite)
+	adjustStock := handlers.RequirePermission(models.PermissionStockAdjust)
 
-	e.POST("/products", handler.CreateProduct)
+	e.POST("/products", handler.CreateProduct, write)
 	e.GET("/products", handler.GetAllProducts)
+	e.POST("/products/bulk", handler.BulkProducts, write)
+	e.GET("/products/search", handler.SearchProducts)
+	e.GET("/products/trash", handler.GetTrash, write)
 	e.GET("/products/:id", handler.GetProductByID)
-	e.PUT("/products/:id", handler.UpdateProduct)
-	e.DELETE("/products/:id", handler.DeleteProduct)
+	e.PUT("/products/:id", handler.UpdateProduct, write)
+	e.DELETE("/products/:id", handler.DeleteProduct, write)
+	e.POST("/products/:id/restore", handler.RestoreProduct, write)
+	e.GET("/products/:id/history", handler.GetHistory)
+	e.GET("/products/:id/price-history", handler.GetPriceHistory)
+	e.POST("/products/:id/stock/adjust", handler.AdjustStock, adjustStock)
+	e.POST("/products/:id/variants", handler.AddVariant, write)
+	e.PUT("/products/:id/variants/:sku", handler.UpdateVariant, write)
+	e.DELETE("/products/:id/variants/:sku", handler.RemoveVariant, write)
+	e.POST("/products/:id/variants/:sku/stock/adjust", handler.AdjustVariantStock, adjustStock)
 }
@@ -0,0 +1,14 @@
+package routes
+
+import (
+	"github.com/YugenDev/global-mobility-test/internal/handlers"
+	"github.com/labstack/echo/v4"
+)
+
+func ReservationRoutes(e *echo.Echo, handler *handlers.ReservationHandler) {
+
+	e.POST("/products/:id/reservations", handler.CreateReservation, handlers.RequireUser)
+	e.GET("/reservations/:id", handler.GetReservation, handlers.RequireUser)
+	e.POST("/reservations/:id/confirm", handler.ConfirmReservation, handlers.RequireUser)
+	e.POST("/reservations/:id/cancel", handler.CancelReservation, handlers.RequireUser)
+}
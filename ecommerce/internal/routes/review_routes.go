@@ -0,0 +1,16 @@
+package routes
+
+import (
+	"github.com/YugenDev/global-mobility-test/internal/handlers"
+	"github.com/YugenDev/global-mobility-test/internal/models"
+	"github.com/labstack/echo/v4"
+)
+
+func ReviewRoutes(e *echo.Echo, handler *handlers.ReviewHandler) {
+	moderate := handlers.RequirePermission(models.PermissionReviewsModerate)
+
+	e.POST("/products/:id/reviews", handler.CreateReview)
+	e.GET("/products/:id/reviews", handler.GetProductReviews)
+	e.GET("/admin/reviews", handler.GetReviews, moderate)
+	e.PUT("/admin/reviews/:id/status", handler.ModerateReview, moderate)
+}
@@ -0,0 +1,161 @@
+package services
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"log"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/YugenDev/global-mobility-test/internal/models"
+	"github.com/YugenDev/global-mobility-test/internal/repositories"
+	"github.com/YugenDev/global-mobility-test/internal/utils"
+)
+
+type IAPIKeyService interface {
+	Create(request models.APIKeyRequest, createdBy string) (models.CreatedAPIKey, error)
+	GetAll() ([]models.APIKey, error)
+	Revoke(id string) error
+	Authenticate(key string) (models.Identity, error)
+}
+
+const (
+	apiKeyPrefix       = "ek_"
+	apiKeyBytes        = 32
+	apiKeyPrefixLength = len(apiKeyPrefix) + 8
+
+	// apiKeyTouchInterval is how stale a key's last use may get before it is
+	// written again, so that a busy key does not cost a write per request.
+	apiKeyTouchInterval = time.Minute
+)
+
+type APIKeyService struct {
+	Repository repositories.IAPIKeyRepository
+}
+
+var _ IAPIKeyService = (*APIKeyService)(nil)
+
+func NewAPIKeyService(repo repositories.IAPIKeyRepository) *APIKeyService {
+	return &APIKeyService{
+		Repository: repo,
+	}
+}
+
+// Create issues a new key. The key itself is only returned here; afterwards
+// it can only be told apart by its prefix.
+func (s *APIKeyService) Create(request models.APIKeyRequest, createdBy string) (models.CreatedAPIKey, error) {
+	name := strings.TrimSpace(request.Name)
+	if name == "" {
+		return models.CreatedAPIKey{}, utils.ErrAPIKeyNameRequired
+	}
+
+	scopes := []string{}
+	for _, scope := range request.Scopes {
+		if !models.APIKeyScopes[scope] {
+			return models.CreatedAPIKey{}, utils.ErrInvalidAPIKeyScope
+		}
+		if !slices.Contains(scopes, scope) {
+			scopes = append(scopes, scope)
+		}
+	}
+	if len(scopes) == 0 {
+		return models.CreatedAPIKey{}, utils.ErrInvalidAPIKeyScope
+	}
+
+	now := time.Now()
+	if request.ExpiresAt != nil && !request.ExpiresAt.After(now) {
+		return models.CreatedAPIKey{}, utils.ErrInvalidAPIKeyExpiry
+	}
+
+	secret := make([]byte, apiKeyBytes)
+	if _, err := rand.Read(secret); err != nil {
+		return models.CreatedAPIKey{}, err
+	}
+	key := apiKeyPrefix + base64.RawURLEncoding.EncodeToString(secret)
+
+	apiKey := models.APIKey{
+		KeyID:     utils.GenerateUniqueID(),
+		Name:      name,
+		Prefix:    key[:apiKeyPrefixLength],
+		KeyHash:   hashAPIKey(key),
+		Scopes:    scopes,
+		ExpiresAt: request.ExpiresAt,
+		CreatedBy: createdBy,
+		CreatedAt: now,
+	}
+	if err := s.Repository.CreateAPIKey(&apiKey); err != nil {
+		return models.CreatedAPIKey{}, err
+	}
+
+	return models.CreatedAPIKey{APIKey: apiKey, Key: key}, nil
+}
+
+func (s *APIKeyService) GetAll() ([]models.APIKey, error) {
+	keys, err := s.Repository.GetAllAPIKeys()
+	if err != nil {
+		return nil, err
+	}
+	if keys == nil {
+		keys = []models.APIKey{}
+	}
+	return keys, nil
+}
+
+func (s *APIKeyService) Revoke(id string) error {
+	if id == "" {
+		return utils.ErrAPIKeyIDRequired
+	}
+
+	result, err := s.Repository.RevokeAPIKey(id, time.Now())
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return utils.ErrAPIKeyNotFound
+	}
+
+	return nil
+}
+
+// Authenticate checks a key and returns an identity whose permissions are
+// the key's scopes. Unknown, revoked and expired keys are all reported as
+// invalid. The key's last use is recorded to the minute.
+func (s *APIKeyService) Authenticate(key string) (models.Identity, error) {
+	if !strings.HasPrefix(key, apiKeyPrefix) {
+		return models.Identity{}, utils.ErrInvalidAPIKey
+	}
+
+	apiKey, err := s.Repository.GetAPIKeyByHash(hashAPIKey(key))
+	if err == utils.ErrAPIKeyNotFound {
+		return models.Identity{}, utils.ErrInvalidAPIKey
+	}
+	if err != nil {
+		return models.Identity{}, err
+	}
+
+	now := time.Now()
+	if apiKey.RevokedAt != nil || (apiKey.ExpiresAt != nil && !now.Before(*apiKey.ExpiresAt)) {
+		return models.Identity{}, utils.ErrInvalidAPIKey
+	}
+
+	if apiKey.LastUsedAt == nil || now.Sub(*apiKey.LastUsedAt) >= apiKeyTouchInterval {
+		if _, err := s.Repository.TouchAPIKey(apiKey.KeyID, now); err != nil {
+			log.Println("Error recording API key use: ", err)
+		}
+	}
+
+	return models.Identity{
+		APIKeyID:    apiKey.KeyID,
+		Permissions: apiKey.Scopes,
+	}, nil
+}
+
+// hashAPIKey returns the SHA-256 of key. Keys are random enough that a fast
+// hash is safe, and it lets a key be looked up by its hash.
+func hashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,292 @@
+package services
+
+import (
+	"net/mail"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/YugenDev/global-mobility-test/internal/models"
+	"github.com/YugenDev/global-mobility-test/internal/repositories"
+	"github.com/YugenDev/global-mobility-test/internal/utils"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/labstack/echo/v4"
+	"golang.org/x/crypto/bcrypt"
+)
+
+type IAuthService interface {
+	Register(request models.RegisterRequest) (models.User, error)
+	Login(request models.LoginRequest) (models.TokenPair, error)
+	Refresh(refreshToken string) (models.TokenPair, error)
+	Authenticate(accessToken string) (models.Identity, error)
+	GetUser(id string) (models.User, error)
+	GetUsers() ([]models.User, error)
+	SetRoles(id string, roles []string) (models.User, error)
+	EnsureAdmin(email string, password string) (models.User, error)
+}
+
+const (
+	tokenIssuer      = "ecommerce"
+	tokenTypeAccess  = "access"
+	tokenTypeRefresh = "refresh"
+
+	minPasswordLength = 8
+	maxPasswordLength = 72
+)
+
+// tokenClaims are the claims of both token types. Type keeps a refresh token
+// from being accepted as an access token and the other way round.
+type tokenClaims struct {
+	Type  string   `json:"type"`
+	Email string   `json:"email"`
+	Roles []string `json:"roles"`
+	jwt.RegisteredClaims
+}
+
+// dummyPasswordHash is compared against when the email is unknown, so that a
+// failed login takes as long whether or not the account exists.
+var dummyPasswordHash = sync.OnceValue(func() []byte {
+	hash, _ := bcrypt.GenerateFromPassword([]byte("not a real password"), bcrypt.DefaultCost)
+	return hash
+})
+
+type AuthService struct {
+	Repository      repositories.IUserRepository
+	Secret          []byte
+	AccessTokenTTL  time.Duration
+	RefreshTokenTTL time.Duration
+}
+
+var _ IAuthService = (*AuthService)(nil)
+
+func NewAuthService(repo repositories.IUserRepository, secret string, accessTokenTTL time.Duration, refreshTokenTTL time.Duration) *AuthService {
+	return &AuthService{
+		Repository:      repo,
+		Secret:          []byte(secret),
+		AccessTokenTTL:  accessTokenTTL,
+		RefreshTokenTTL: refreshTokenTTL,
+	}
+}
+
+// Register creates a viewer account. Admins are created with EnsureAdmin or
+// granted the role by another admin.
+func (s *AuthService) Register(request models.RegisterRequest) (models.User, error) {
+	email, err := normalizeEmail(request.Email)
+	if err != nil {
+		return models.User{}, err
+	}
+	return s.createUser(email, request.Name, request.Password, models.RoleViewer)
+}
+
+// EnsureAdmin creates the admin account with the given password unless it
+// already exists. An existing account that is not an admin is never
+// promoted, since anyone could have registered it.
+func (s *AuthService) EnsureAdmin(email string, password string) (models.User, error) {
+	email, err := normalizeEmail(email)
+	if err != nil {
+		return models.User{}, err
+	}
+
+	user, err := s.Repository.GetUserByEmail(email)
+	if err == nil {
+		if !slices.Contains(user.Roles, models.RoleAdmin) {
+			return models.User{}, utils.ErrAdminEmailTaken
+		}
+		return user, nil
+	}
+	if err != utils.ErrUserNotFound {
+		return models.User{}, err
+	}
+
+	return s.createUser(email, "", password, models.RoleAdmin)
+}
+
+func (s *AuthService) createUser(email string, name string, password string, role string) (models.User, error) {
+	if len(password) < minPasswordLength || len(password) > maxPasswordLength {
+		return models.User{}, utils.ErrInvalidPassword
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return models.User{}, err
+	}
+
+	now := time.Now()
+	user := models.User{
+		UserID:       utils.GenerateUniqueID(),
+		Email:        email,
+		Name:         strings.TrimSpace(name),
+		Roles:        []string{role},
+		PasswordHash: string(hash),
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	if err := s.Repository.CreateUser(&user); err != nil {
+		return models.User{}, err
+	}
+
+	return user, nil
+}
+
+func (s *AuthService) Login(request models.LoginRequest) (models.TokenPair, error) {
+	email, err := normalizeEmail(request.Email)
+	if err != nil {
+		return models.TokenPair{}, utils.ErrInvalidCredentials
+	}
+
+	user, err := s.Repository.GetUserByEmail(email)
+	if err == utils.ErrUserNotFound {
+		bcrypt.CompareHashAndPassword(dummyPasswordHash(), []byte(request.Password))
+		return models.TokenPair{}, utils.ErrInvalidCredentials
+	}
+	if err != nil {
+		return models.TokenPair{}, err
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(request.Password)); err != nil {
+		return models.TokenPair{}, utils.ErrInvalidCredentials
+	}
+
+	return s.issueTokens(user)
+}
+
+// Refresh exchanges a refresh token for a new token pair, provided the user
+// still exists. The new access token carries the user's current roles.
+func (s *AuthService) Refresh(refreshToken string) (models.TokenPair, error) {
+	claims, err := s.parseToken(refreshToken, tokenTypeRefresh)
+	if err != nil {
+		return models.TokenPair{}, err
+	}
+
+	user, err := s.Repository.GetUserByID(claims.Subject)
+	if err == utils.ErrUserNotFound {
+		return models.TokenPair{}, utils.ErrInvalidToken
+	}
+	if err != nil {
+		return models.TokenPair{}, err
+	}
+
+	return s.issueTokens(user)
+}
+
+// Authenticate verifies an access token. It does not touch the database, so
+// an access token keeps its roles until it expires.
+func (s *AuthService) Authenticate(accessToken string) (models.Identity, error) {
+	claims, err := s.parseToken(accessToken, tokenTypeAccess)
+	if err != nil {
+		return models.Identity{}, err
+	}
+	return models.Identity{
+		UserID:      claims.Subject,
+		Email:       claims.Email,
+		Roles:       claims.Roles,
+		Permissions: models.PermissionsFor(claims.Roles),
+	}, nil
+}
+
+func (s *AuthService) GetUser(id string) (models.User, error) {
+	return s.Repository.GetUserByID(id)
+}
+
+func (s *AuthService) GetUsers() ([]models.User, error) {
+	users, err := s.Repository.GetAllUsers()
+	if err != nil {
+		return nil, err
+	}
+	if users == nil {
+		users = []models.User{}
+	}
+	return users, nil
+}
+
+// SetRoles replaces the user's roles. They take effect on the user's next
+// login or refresh.
+func (s *AuthService) SetRoles(id string, roles []string) (models.User, error) {
+	if id == "" {
+		return models.User{}, utils.ErrUserIDRequired
+	}
+
+	unique := []string{}
+	for _, role := range roles {
+		if _, ok := models.RolePermissions[role]; !ok {
+			return models.User{}, utils.ErrInvalidRole
+		}
+		if !slices.Contains(unique, role) {
+			unique = append(unique, role)
+		}
+	}
+
+	result, err := s.Repository.UpdateRoles(id, unique)
+	if err != nil {
+		return models.User{}, err
+	}
+	if result.MatchedCount == 0 {
+		return models.User{}, utils.ErrUserNotFound
+	}
+
+	return s.Repository.GetUserByID(id)
+}
+
+func (s *AuthService) issueTokens(user models.User) (models.TokenPair, error) {
+	accessToken, err := s.signToken(user, tokenTypeAccess, s.AccessTokenTTL)
+	if err != nil {
+		return models.TokenPair{}, err
+	}
+	refreshToken, err := s.signToken(user, tokenTypeRefresh, s.RefreshTokenTTL)
+	if err != nil {
+		return models.TokenPair{}, err
+	}
+
+	return models.TokenPair{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    int(s.AccessTokenTTL.Seconds()),
+	}, nil
+}
+
+func (s *AuthService) signToken(user models.User, tokenType string, ttl time.Duration) (string, error) {
+	now := time.Now()
+	claims := tokenClaims{
+		Type:  tokenType,
+		Email: user.Email,
+		Roles: user.Roles,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    tokenIssuer,
+			Subject:   user.UserID,
+			ID:        utils.GenerateUniqueID(),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+		},
+	}
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(s.Secret)
+}
+
+func (s *AuthService) parseToken(token string, tokenType string) (tokenClaims, error) {
+	var claims tokenClaims
+	_, err := jwt.ParseWithClaims(token, &claims, func(*jwt.Token) (interface{}, error) {
+		return s.Secret, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithIssuer(tokenIssuer), jwt.WithExpirationRequired())
+	if err != nil || claims.Type != tokenType || claims.Subject == "" {
+		return tokenClaims{}, utils.ErrInvalidToken
+	}
+	return claims, nil
+}
+
+func normalizeEmail(email string) (string, error) {
+	address, err := mail.ParseAddress(strings.TrimSpace(email))
+	if err != nil || address.Name != "" {
+		return "", utils.ErrInvalidEmail
+	}
+	return strings.ToLower(address.Address), nil
+}
+
+// IdentityFromContext returns the caller identified by the authentication
+// middleware, if any.
+func IdentityFromContext(c echo.Context) (models.Identity, bool) {
+	if c == nil {
+		return models.Identity{}, false
+	}
+	identity, ok := c.Get(utils.ContextKeyIdentity).(models.Identity)
+	return identity, ok
+}
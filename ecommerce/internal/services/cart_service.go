@@ -0,0 +1,200 @@
+package services
+
+import (
+	"time"
+
+	"github.com/YugenDev/global-mobility-test/internal/models"
+	"github.com/YugenDev/global-mobility-test/internal/repositories"
+	"github.com/YugenDev/global-mobility-test/internal/utils"
+	"github.com/labstack/echo/v4"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type ICartService interface {
+	CreateCart(c echo.Context) (models.Cart, error)
+	GetCart(id string) (models.Cart, error)
+	AddItem(c echo.Context, id string, item models.CartItemRequest) (models.Cart, error)
+	UpdateItem(c echo.Context, id string, productID string, sku string, quantity int) (models.Cart, error)
+	RemoveItem(c echo.Context, id string, productID string, sku string) (models.Cart, error)
+	ClearCart(c echo.Context, id string) (models.Cart, error)
+}
+
+type CartService struct {
+	Repository     repositories.ICartRepository
+	ProductService IProductService
+	TTL            time.Duration
+}
+
+var _ ICartService = (*CartService)(nil)
+
+func NewCartService(repo repositories.ICartRepository, productService IProductService, ttl time.Duration) *CartService {
+	return &CartService{
+		Repository:     repo,
+		ProductService: productService,
+		TTL:            ttl,
+	}
+}
+
+func (s *CartService) CreateCart(c echo.Context) (models.Cart, error) {
+	now := time.Now()
+	cart := models.Cart{
+		CartID:    utils.GenerateUniqueID(),
+		Items:     []models.CartItem{},
+		CreatedAt: now,
+		UpdatedAt: now,
+		ExpiresAt: now.Add(s.TTL),
+	}
+
+	if err := s.Repository.CreateCart(&cart); err != nil {
+		return models.Cart{}, err
+	}
+
+	return cart, nil
+}
+
+func (s *CartService) GetCart(id string) (models.Cart, error) {
+	if id == "" {
+		return models.Cart{}, utils.ErrCartIDRequired
+	}
+	return s.Repository.GetCartByID(id)
+}
+
+// AddItem adds quantity units of a product, merging with an existing line for
+// the same product and variant. New lines snapshot the product's current name
+// and the variant's price, which must be in the cart's currency.
+func (s *CartService) AddItem(c echo.Context, id string, item models.CartItemRequest) (models.Cart, error) {
+	if item.ProductID == "" {
+		return models.Cart{}, utils.ErrProductIDRequired
+	}
+	if item.Quantity <= 0 {
+		return models.Cart{}, utils.ErrInvalidCartQuantity
+	}
+
+	cart, err := s.GetCart(id)
+	if err != nil {
+		return models.Cart{}, err
+	}
+
+	product, err := s.availableProduct(item.ProductID)
+	if err != nil {
+		return models.Cart{}, err
+	}
+	price, available, err := productOffer(product, item.SKU)
+	if err != nil {
+		return models.Cart{}, err
+	}
+
+	index := findCartItem(cart, item.ProductID, item.SKU)
+	if index < 0 {
+		if len(cart.Items) > 0 && price.Currency != cart.Items[0].UnitPrice.Currency {
+			return models.Cart{}, utils.ErrCurrencyMismatch
+		}
+		cart.Items = append(cart.Items, models.CartItem{
+			ProductID: product.ProductID,
+			SKU:       item.SKU,
+			Name:      product.Name,
+			UnitPrice: price,
+		})
+		index = len(cart.Items) - 1
+	}
+
+	quantity := cart.Items[index].Quantity + item.Quantity
+	if quantity > available {
+		return models.Cart{}, utils.ErrInsufficientStock
+	}
+	cart.Items[index].Quantity = quantity
+
+	return s.save(cart)
+}
+
+func (s *CartService) UpdateItem(c echo.Context, id string, productID string, sku string, quantity int) (models.Cart, error) {
+	if quantity <= 0 {
+		return models.Cart{}, utils.ErrInvalidCartQuantity
+	}
+
+	cart, err := s.GetCart(id)
+	if err != nil {
+		return models.Cart{}, err
+	}
+
+	index := findCartItem(cart, productID, sku)
+	if index < 0 {
+		return models.Cart{}, utils.ErrCartItemNotFound
+	}
+
+	product, err := s.availableProduct(productID)
+	if err != nil {
+		return models.Cart{}, err
+	}
+	_, available, err := productOffer(product, sku)
+	if err != nil {
+		return models.Cart{}, err
+	}
+	if quantity > available {
+		return models.Cart{}, utils.ErrInsufficientStock
+	}
+	cart.Items[index].Quantity = quantity
+
+	return s.save(cart)
+}
+
+func (s *CartService) RemoveItem(c echo.Context, id string, productID string, sku string) (models.Cart, error) {
+	cart, err := s.GetCart(id)
+	if err != nil {
+		return models.Cart{}, err
+	}
+
+	index := findCartItem(cart, productID, sku)
+	if index < 0 {
+		return models.Cart{}, utils.ErrCartItemNotFound
+	}
+	cart.Items = append(cart.Items[:index], cart.Items[index+1:]...)
+
+	return s.save(cart)
+}
+
+func (s *CartService) ClearCart(c echo.Context, id string) (models.Cart, error) {
+	cart, err := s.GetCart(id)
+	if err != nil {
+		return models.Cart{}, err
+	}
+	cart.Items = []models.CartItem{}
+
+	return s.save(cart)
+}
+
+func (s *CartService) availableProduct(productID string) (models.Product, error) {
+	product, err := s.ProductService.GetByID(productID)
+	if err == mongo.ErrNoDocuments {
+		return models.Product{}, utils.ErrNoProductsFound
+	}
+	return product, err
+}
+
+// save recalculates the totals and pushes the cart's expiry forward, since
+// every change counts as activity.
+func (s *CartService) save(cart models.Cart) (models.Cart, error) {
+	now := time.Now()
+	cart.Recalculate()
+	cart.UpdatedAt = now
+	cart.ExpiresAt = now.Add(s.TTL)
+
+	result, err := s.Repository.UpdateCart(&cart)
+	if err != nil {
+		return models.Cart{}, err
+	}
+	if result.MatchedCount == 0 {
+		return models.Cart{}, utils.ErrCartVersionMismatch
+	}
+
+	return cart, nil
+}
+
+func findCartItem(cart models.Cart, productID string, sku string) int {
+	for i, item := range cart.Items {
+		if item.ProductID == productID && item.SKU == sku {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,361 @@
+package services
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/YugenDev/global-mobility-test/internal/models"
+	"github.com/YugenDev/global-mobility-test/internal/repositories"
+	"github.com/YugenDev/global-mobility-test/internal/utils"
+	"github.com/labstack/echo/v4"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type ICategoryService interface {
+	CreateCategory(category *models.Category) error
+	GetByID(id string) (models.Category, error)
+	GetAll() ([]models.Category, error)
+	GetTree() ([]models.CategoryNode, error)
+	UpdateCategory(id string, category *models.Category) error
+	DeleteCategory(c echo.Context, id string, reassignTo string) error
+	GetProducts(id string, includeDescendants bool, query models.ProductQuery) (models.ProductPage, error)
+}
+
+type CategoryService struct {
+	Repository        repositories.ICategoryRepository
+	ProductRepository repositories.IProductRepository
+	AuditRepository   repositories.IAuditRepository
+	ProductService    IProductService
+}
+
+var _ ICategoryService = (*CategoryService)(nil)
+
+var slugPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+var nonSlugChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+func NewCategoryService(repo repositories.ICategoryRepository, productRepo repositories.IProductRepository, auditRepo repositories.IAuditRepository, productService IProductService) *CategoryService {
+	return &CategoryService{
+		Repository:        repo,
+		ProductRepository: productRepo,
+		AuditRepository:   auditRepo,
+		ProductService:    productService,
+	}
+}
+
+func (s *CategoryService) CreateCategory(category *models.Category) error {
+	if err := prepareCategory(category); err != nil {
+		return err
+	}
+	if category.ParentID != "" {
+		if _, err := s.Repository.GetCategoryByID(category.ParentID); err != nil {
+			if err == utils.ErrCategoryNotFound {
+				return utils.ErrParentCategoryNotFound
+			}
+			return err
+		}
+	}
+
+	now := time.Now()
+	category.CategoryID = utils.GenerateUniqueID()
+	category.CreatedAt = now
+	category.UpdatedAt = now
+
+	return s.Repository.CreateCategory(category)
+}
+
+func (s *CategoryService) GetByID(id string) (models.Category, error) {
+	if id == "" {
+		return models.Category{}, utils.ErrCategoryIDRequired
+	}
+	return s.Repository.GetCategoryByID(id)
+}
+
+func (s *CategoryService) GetAll() ([]models.Category, error) {
+	categories, err := s.Repository.GetAllCategories()
+	if err != nil {
+		return nil, err
+	}
+	if categories == nil {
+		categories = []models.Category{}
+	}
+	return categories, nil
+}
+
+// GetTree nests every category under its parent. Categories whose parent no
+// longer exists are returned as roots.
+func (s *CategoryService) GetTree() ([]models.CategoryNode, error) {
+	categories, err := s.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	known := make(map[string]bool)
+	for _, category := range categories {
+		known[category.CategoryID] = true
+	}
+
+	children := make(map[string][]models.Category)
+	for _, category := range categories {
+		parent := category.ParentID
+		if !known[parent] {
+			parent = ""
+		}
+		children[parent] = append(children[parent], category)
+	}
+
+	return buildCategoryNodes(children, ""), nil
+}
+
+// UpdateCategory replaces the name, slug, parent and position of the category.
+func (s *CategoryService) UpdateCategory(id string, category *models.Category) error {
+	if id == "" {
+		return utils.ErrCategoryIDRequired
+	}
+
+	existing, err := s.Repository.GetCategoryByID(id)
+	if err != nil {
+		return err
+	}
+	if err := prepareCategory(category); err != nil {
+		return err
+	}
+
+	if category.ParentID != "" {
+		categories, err := s.Repository.GetAllCategories()
+		if err != nil {
+			return err
+		}
+		parents := make(map[string]string)
+		for _, other := range categories {
+			parents[other.CategoryID] = other.ParentID
+		}
+		if _, ok := parents[category.ParentID]; !ok {
+			return utils.ErrParentCategoryNotFound
+		}
+		for parent, steps := category.ParentID, 0; parent != "" && steps <= len(parents); parent, steps = parents[parent], steps+1 {
+			if parent == id {
+				return utils.ErrCategoryCycle
+			}
+		}
+	}
+
+	category.CategoryID = id
+	category.CreatedAt = existing.CreatedAt
+	category.UpdatedAt = time.Now()
+
+	result, err := s.Repository.UpdateCategory(category)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return utils.ErrCategoryNotFound
+	}
+
+	return nil
+}
+
+// DeleteCategory removes a leaf category. Products still assigned to it,
+// trashed ones included, block the delete unless reassignTo names another
+// category to move them to. Every product moved gets an entry in its change
+// history. Products assigned to the category while it is being emptied
+// still block the delete.
+func (s *CategoryService) DeleteCategory(c echo.Context, id string, reassignTo string) error {
+	if id == "" {
+		return utils.ErrCategoryIDRequired
+	}
+	if _, err := s.Repository.GetCategoryByID(id); err != nil {
+		return err
+	}
+
+	categories, err := s.Repository.GetAllCategories()
+	if err != nil {
+		return err
+	}
+	for _, category := range categories {
+		if category.ParentID == id {
+			return utils.ErrCategoryHasChildren
+		}
+	}
+
+	if reassignTo != "" {
+		if reassignTo == id {
+			return utils.ErrInvalidReassignCategory
+		}
+		if _, err := s.Repository.GetCategoryByID(reassignTo); err != nil {
+			if err == utils.ErrCategoryNotFound {
+				return utils.ErrInvalidReassignCategory
+			}
+			return err
+		}
+		if err := s.reassignProducts(c, id, reassignTo); err != nil {
+			return err
+		}
+	}
+
+	inUse, err := s.hasProducts(id)
+	if err != nil {
+		return err
+	}
+	if inUse {
+		return utils.ErrCategoryHasProducts
+	}
+
+	result, err := s.Repository.DeleteCategory(id)
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return utils.ErrCategoryNotFound
+	}
+
+	return nil
+}
+
+// GetProducts lists the live products in the category, and in all of its
+// descendants when includeDescendants is set.
+func (s *CategoryService) GetProducts(id string, includeDescendants bool, query models.ProductQuery) (models.ProductPage, error) {
+	if id == "" {
+		return models.ProductPage{}, utils.ErrCategoryIDRequired
+	}
+	if _, err := s.Repository.GetCategoryByID(id); err != nil {
+		return models.ProductPage{}, err
+	}
+
+	ids := []string{id}
+	if includeDescendants {
+		categories, err := s.Repository.GetAllCategories()
+		if err != nil {
+			return models.ProductPage{}, err
+		}
+		ids = append(ids, descendantIDs(categories, id)...)
+	}
+	query.Filter.CategoryIDs = ids
+
+	page, err := s.ProductService.GetAll(query)
+	if err == utils.ErrNoProductsFound {
+		return models.ProductPage{Products: []models.Product{}}, nil
+	}
+
+	return page, err
+}
+
+// reassignProducts moves the products in category from, trashed ones
+// included, to category to one page at a time, and records each product it
+// actually moved in its change history. Products that left the category
+// after their page was read are skipped.
+func (s *CategoryService) reassignProducts(c echo.Context, from string, to string) error {
+	for _, trashed := range []bool{false, true} {
+		query := models.ProductQuery{
+			Limit:   MaxPageLimit,
+			Trashed: trashed,
+			Filter:  models.ProductFilter{CategoryIDs: []string{from}},
+		}
+		for {
+			products, err := s.ProductRepository.GetAllProducts(query)
+			if err != nil {
+				return err
+			}
+
+			for _, product := range products {
+				before, err := s.ProductRepository.ReassignCategory(product.ProductID, from, to)
+				if err == mongo.ErrNoDocuments {
+					continue
+				}
+				if err != nil {
+					return err
+				}
+				recordProductAudit(s.AuditRepository, c, models.AuditActionUpdate, before, reassignedProduct(before, from, to))
+			}
+
+			if len(products) < query.Limit {
+				break
+			}
+			last := products[len(products)-1]
+			query.After = &models.ProductCursor{CreatedAt: last.CreatedAt, ProductID: last.ProductID}
+		}
+	}
+	return nil
+}
+
+// reassignedProduct is product as ReassignCategory leaves it.
+func reassignedProduct(product models.Product, from string, to string) models.Product {
+	categoryIDs := slices.DeleteFunc(slices.Clone(product.CategoryIDs), func(categoryID string) bool {
+		return categoryID == from
+	})
+	if !slices.Contains(categoryIDs, to) {
+		categoryIDs = append(categoryIDs, to)
+	}
+
+	product.CategoryIDs = categoryIDs
+	product.Version++
+	return product
+}
+
+func (s *CategoryService) hasProducts(id string) (bool, error) {
+	for _, trashed := range []bool{false, true} {
+		products, err := s.ProductRepository.GetAllProducts(models.ProductQuery{
+			Limit:   1,
+			Trashed: trashed,
+			Filter:  models.ProductFilter{CategoryIDs: []string{id}},
+		})
+		if err != nil {
+			return false, err
+		}
+		if len(products) > 0 {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// prepareCategory validates the name and derives the slug from it when none
+// was given.
+func prepareCategory(category *models.Category) error {
+	category.Name = strings.TrimSpace(category.Name)
+	if category.Name == "" {
+		return utils.ErrCategoryNameRequired
+	}
+	if category.Slug == "" {
+		category.Slug = strings.Trim(nonSlugChars.ReplaceAllString(strings.ToLower(category.Name), "-"), "-")
+	}
+	if !slugPattern.MatchString(category.Slug) {
+		return utils.ErrInvalidCategorySlug
+	}
+	return nil
+}
+
+func buildCategoryNodes(children map[string][]models.Category, parent string) []models.CategoryNode {
+	nodes := []models.CategoryNode{}
+	for _, category := range children[parent] {
+		nodes = append(nodes, models.CategoryNode{
+			Category: category,
+			Children: buildCategoryNodes(children, category.CategoryID),
+		})
+	}
+	return nodes
+}
+
+func descendantIDs(categories []models.Category, id string) []string {
+	children := make(map[string][]string)
+	for _, category := range categories {
+		children[category.ParentID] = append(children[category.ParentID], category.CategoryID)
+	}
+
+	var ids []string
+	queue := children[id]
+	seen := map[string]bool{id: true}
+	for len(queue) > 0 {
+		next := queue[0]
+		queue = queue[1:]
+		if seen[next] {
+			continue
+		}
+		seen[next] = true
+		ids = append(ids, next)
+		queue = append(queue, children[next]...)
+	}
+	return ids
+}
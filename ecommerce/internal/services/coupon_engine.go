@@ -0,0 +1,144 @@
+package services
+
+import (
+	"math/big"
+	"sort"
+
+	"github.com/YugenDev/global-mobility-test/internal/models"
+	"github.com/YugenDev/global-mobility-test/internal/utils"
+)
+
+// applyCoupon works out the coupon's discount on a copy of items, which all
+// share one currency. covered says which items the coupon's scope includes.
+// Every item gets a discount, zero for the ones the coupon does not reduce.
+func applyCoupon(coupon models.Coupon, items []models.OrderItem, covered []bool) (models.CouponEvaluation, error) {
+	if len(items) == 0 {
+		return models.CouponEvaluation{}, utils.ErrCouponNotApplicable
+	}
+
+	order := models.Order{Items: append([]models.OrderItem(nil), items...)}
+	order.Recalculate()
+	currency := order.Total.Currency
+	subtotal := order.Total
+
+	if coupon.MinSubtotal != nil {
+		if coupon.MinSubtotal.Currency != currency {
+			return models.CouponEvaluation{}, utils.ErrCurrencyMismatch
+		}
+		if subtotal.Amount < coupon.MinSubtotal.Amount {
+			return models.CouponEvaluation{}, utils.ErrCouponMinimumNotMet
+		}
+	}
+
+	var eligible []int
+	var eligibleTotal int64
+	for i := range order.Items {
+		if covered[i] {
+			eligible = append(eligible, i)
+			eligibleTotal += order.Items[i].LineTotal.Amount
+		}
+	}
+	if len(eligible) == 0 {
+		return models.CouponEvaluation{}, utils.ErrCouponNotApplicable
+	}
+
+	discounts := make([]int64, len(order.Items))
+	switch coupon.Type {
+	case models.CouponTypePercentage:
+		for _, i := range eligible {
+			discounts[i] = percentOf(order.Items[i].LineTotal.Amount, coupon.Percent)
+		}
+	case models.CouponTypeFixed:
+		if coupon.Amount.Currency != currency {
+			return models.CouponEvaluation{}, utils.ErrCurrencyMismatch
+		}
+		spreadDiscount(order.Items, eligible, eligibleTotal, min(coupon.Amount.Amount, eligibleTotal), discounts)
+	case models.CouponTypeBuyXGetY:
+		if !discountCheapestUnits(coupon, order.Items, eligible, discounts) {
+			return models.CouponEvaluation{}, utils.ErrCouponNotApplicable
+		}
+	default:
+		return models.CouponEvaluation{}, utils.ErrInvalidCouponType
+	}
+
+	for i := range order.Items {
+		discount := models.Money{Amount: discounts[i], Currency: currency}
+		order.Items[i].Discount = &discount
+	}
+	order.CouponID = coupon.CouponID
+	order.Recalculate()
+
+	return models.CouponEvaluation{
+		CouponID: coupon.CouponID,
+		Code:     coupon.Code,
+		Items:    order.Items,
+		Subtotal: subtotal,
+		Discount: *order.Discount,
+		Total:    order.Total,
+	}, nil
+}
+
+// percentOf returns percent of amount, rounded half up to the minor unit.
+func percentOf(amount int64, percent int) int64 {
+	return (amount*int64(percent) + 50) / 100
+}
+
+// spreadDiscount splits amount over the eligible items in proportion to their
+// line totals. Minor units lost to rounding down go to the first lines that
+// still have room, so the shares add up to amount.
+func spreadDiscount(items []models.OrderItem, eligible []int, eligibleTotal int64, amount int64, discounts []int64) {
+	if eligibleTotal == 0 {
+		return
+	}
+
+	remaining := amount
+	for _, i := range eligible {
+		share := new(big.Int).Mul(big.NewInt(amount), big.NewInt(items[i].LineTotal.Amount))
+		share.Quo(share, big.NewInt(eligibleTotal))
+		discounts[i] = share.Int64()
+		remaining -= discounts[i]
+	}
+	for _, i := range eligible {
+		if remaining == 0 {
+			break
+		}
+		if discounts[i] < items[i].LineTotal.Amount {
+			discounts[i]++
+			remaining--
+		}
+	}
+}
+
+// discountCheapestUnits takes the coupon's percent off the cheapest
+// GetQuantity units of every BuyQuantity+GetQuantity eligible units. It
+// reports false when there are not enough units for a single group.
+func discountCheapestUnits(coupon models.Coupon, items []models.OrderItem, eligible []int, discounts []int64) bool {
+	units := 0
+	for _, i := range eligible {
+		units += items[i].Quantity
+	}
+	free := units / (coupon.BuyQuantity + coupon.GetQuantity) * coupon.GetQuantity
+	if free == 0 {
+		return false
+	}
+
+	percent := coupon.Percent
+	if percent == 0 {
+		percent = 100
+	}
+
+	cheapest := append([]int(nil), eligible...)
+	sort.SliceStable(cheapest, func(a, b int) bool {
+		return items[cheapest[a]].UnitPrice.Amount < items[cheapest[b]].UnitPrice.Amount
+	})
+	for _, i := range cheapest {
+		if free == 0 {
+			break
+		}
+		quantity := min(free, items[i].Quantity)
+		discounts[i] = percentOf(items[i].UnitPrice.Times(quantity).Amount, percent)
+		free -= quantity
+	}
+
+	return true
+}
@@ -0,0 +1,315 @@
+package services
+
+import (
+	"log"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/YugenDev/global-mobility-test/internal/models"
+	"github.com/YugenDev/global-mobility-test/internal/repositories"
+	"github.com/YugenDev/global-mobility-test/internal/utils"
+)
+
+type ICouponService interface {
+	CreateCoupon(coupon *models.Coupon) error
+	GetAll() ([]models.Coupon, error)
+	GetByID(id string) (models.Coupon, error)
+	UpdateCoupon(id string, coupon *models.Coupon) error
+	DeleteCoupon(id string) error
+	Evaluate(request models.CouponEvaluationRequest) (models.CouponEvaluation, error)
+	Apply(code string, items []models.OrderItem) (models.CouponEvaluation, error)
+	Redeem(id string) error
+	Release(id string)
+}
+
+var couponCodePattern = regexp.MustCompile(`^[A-Z0-9_-]{3,32}$`)
+
+type CouponService struct {
+	Repository         repositories.ICouponRepository
+	CategoryRepository repositories.ICategoryRepository
+	ProductService     IProductService
+	CartService        ICartService
+}
+
+var _ ICouponService = (*CouponService)(nil)
+
+func NewCouponService(repo repositories.ICouponRepository, categoryRepo repositories.ICategoryRepository, productService IProductService, cartService ICartService) *CouponService {
+	return &CouponService{
+		Repository:         repo,
+		CategoryRepository: categoryRepo,
+		ProductService:     productService,
+		CartService:        cartService,
+	}
+}
+
+func (s *CouponService) CreateCoupon(coupon *models.Coupon) error {
+	if err := s.prepareCoupon(coupon); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	coupon.CouponID = utils.GenerateUniqueID()
+	coupon.UsageCount = 0
+	coupon.CreatedAt = now
+	coupon.UpdatedAt = now
+
+	return s.Repository.CreateCoupon(coupon)
+}
+
+func (s *CouponService) GetAll() ([]models.Coupon, error) {
+	coupons, err := s.Repository.GetAllCoupons()
+	if err != nil {
+		return nil, err
+	}
+	if coupons == nil {
+		coupons = []models.Coupon{}
+	}
+	return coupons, nil
+}
+
+func (s *CouponService) GetByID(id string) (models.Coupon, error) {
+	if id == "" {
+		return models.Coupon{}, utils.ErrCouponIDRequired
+	}
+	return s.Repository.GetCouponByID(id)
+}
+
+// UpdateCoupon replaces the coupon's code and rules. Its usage count carries
+// over.
+func (s *CouponService) UpdateCoupon(id string, coupon *models.Coupon) error {
+	existing, err := s.GetByID(id)
+	if err != nil {
+		return err
+	}
+	if err := s.prepareCoupon(coupon); err != nil {
+		return err
+	}
+
+	coupon.CouponID = id
+	coupon.UsageCount = existing.UsageCount
+	coupon.CreatedAt = existing.CreatedAt
+	coupon.UpdatedAt = time.Now()
+
+	result, err := s.Repository.UpdateCoupon(coupon)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return utils.ErrCouponNotFound
+	}
+
+	return nil
+}
+
+func (s *CouponService) DeleteCoupon(id string) error {
+	if id == "" {
+		return utils.ErrCouponIDRequired
+	}
+
+	result, err := s.Repository.DeleteCoupon(id)
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return utils.ErrCouponNotFound
+	}
+
+	return nil
+}
+
+// Evaluate applies a coupon to a cart, at the prices its items were added
+// with, or to a list of items at the products' current prices. Nothing is
+// redeemed.
+func (s *CouponService) Evaluate(request models.CouponEvaluationRequest) (models.CouponEvaluation, error) {
+	if (request.CartID == "") == (len(request.Items) == 0) {
+		return models.CouponEvaluation{}, utils.ErrInvalidCheckoutRequest
+	}
+
+	var items []models.OrderItem
+	if request.CartID != "" {
+		cart, err := s.CartService.GetCart(request.CartID)
+		if err != nil {
+			return models.CouponEvaluation{}, err
+		}
+		items = cartOrderItems(cart)
+	} else {
+		var err error
+		items, err = priceItems(s.ProductService, request.Items)
+		if err != nil {
+			return models.CouponEvaluation{}, err
+		}
+	}
+
+	return s.Apply(request.Code, items)
+}
+
+// Apply works out the discount of the coupon with code on items, which must
+// be priced in one currency. The items are not changed.
+func (s *CouponService) Apply(code string, items []models.OrderItem) (models.CouponEvaluation, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if code == "" {
+		return models.CouponEvaluation{}, utils.ErrCouponCodeRequired
+	}
+
+	coupon, err := s.Repository.GetCouponByCode(code)
+	if err != nil {
+		return models.CouponEvaluation{}, err
+	}
+	if err := couponAvailable(coupon, time.Now()); err != nil {
+		return models.CouponEvaluation{}, err
+	}
+
+	covered, err := s.coverage(coupon, items)
+	if err != nil {
+		return models.CouponEvaluation{}, err
+	}
+
+	return applyCoupon(coupon, items, covered)
+}
+
+// Redeem counts one use of the coupon, failing once its usage limit is
+// reached.
+func (s *CouponService) Redeem(id string) error {
+	result, err := s.Repository.IncrementUsage(id)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return utils.ErrCouponUsageLimitReached
+	}
+	return nil
+}
+
+// Release gives back a use of the coupon, for an order that was cancelled or
+// never placed. A failure is logged.
+func (s *CouponService) Release(id string) {
+	if _, err := s.Repository.DecrementUsage(id); err != nil {
+		log.Println("Error releasing coupon use: ", err)
+	}
+}
+
+// coverage reports which items the coupon's product and category scope
+// covers.
+func (s *CouponService) coverage(coupon models.Coupon, items []models.OrderItem) ([]bool, error) {
+	covered := make([]bool, len(items))
+	if len(coupon.ProductIDs) == 0 && len(coupon.CategoryIDs) == 0 {
+		for i := range covered {
+			covered[i] = true
+		}
+		return covered, nil
+	}
+
+	products := make(map[string]bool)
+	for _, id := range coupon.ProductIDs {
+		products[id] = true
+	}
+
+	categories := make(map[string]bool)
+	if len(coupon.CategoryIDs) > 0 {
+		all, err := s.CategoryRepository.GetAllCategories()
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range coupon.CategoryIDs {
+			categories[id] = true
+			for _, descendant := range descendantIDs(all, id) {
+				categories[descendant] = true
+			}
+		}
+	}
+
+	for i, item := range items {
+		if products[item.ProductID] {
+			covered[i] = true
+			continue
+		}
+		if len(categories) == 0 {
+			continue
+		}
+
+		product, err := s.ProductService.GetByID(item.ProductID)
+		if err != nil {
+			continue
+		}
+		for _, id := range product.CategoryIDs {
+			if categories[id] {
+				covered[i] = true
+				break
+			}
+		}
+	}
+
+	return covered, nil
+}
+
+// prepareCoupon normalises the code and checks the coupon's rules.
+func (s *CouponService) prepareCoupon(coupon *models.Coupon) error {
+	coupon.Code = strings.ToUpper(strings.TrimSpace(coupon.Code))
+	if coupon.Code == "" {
+		return utils.ErrCouponCodeRequired
+	}
+	if !couponCodePattern.MatchString(coupon.Code) {
+		return utils.ErrInvalidCouponCode
+	}
+	if !models.CouponTypes[coupon.Type] {
+		return utils.ErrInvalidCouponType
+	}
+
+	switch coupon.Type {
+	case models.CouponTypePercentage:
+		if coupon.Percent < 1 || coupon.Percent > 100 {
+			return utils.ErrInvalidCouponDiscount
+		}
+	case models.CouponTypeFixed:
+		if coupon.Amount == nil || coupon.Amount.Amount <= 0 {
+			return utils.ErrInvalidCouponDiscount
+		}
+		if !models.SupportedCurrency(coupon.Amount.Currency) {
+			return utils.ErrUnsupportedCurrency
+		}
+	case models.CouponTypeBuyXGetY:
+		if coupon.BuyQuantity < 1 || coupon.GetQuantity < 1 || coupon.Percent < 0 || coupon.Percent > 100 {
+			return utils.ErrInvalidCouponDiscount
+		}
+	}
+
+	if coupon.MinSubtotal != nil {
+		if coupon.MinSubtotal.Amount <= 0 {
+			return utils.ErrInvalidCouponRule
+		}
+		if !models.SupportedCurrency(coupon.MinSubtotal.Currency) {
+			return utils.ErrUnsupportedCurrency
+		}
+	}
+	if coupon.UsageLimit < 0 {
+		return utils.ErrInvalidCouponRule
+	}
+	if coupon.StartsAt != nil && coupon.EndsAt != nil && !coupon.EndsAt.After(*coupon.StartsAt) {
+		return utils.ErrInvalidCouponWindow
+	}
+
+	for _, id := range coupon.CategoryIDs {
+		if _, err := s.CategoryRepository.GetCategoryByID(id); err != nil {
+			if err == utils.ErrCategoryNotFound {
+				return utils.ErrUnknownCategory
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+func couponAvailable(coupon models.Coupon, now time.Time) error {
+	if coupon.StartsAt != nil && now.Before(*coupon.StartsAt) {
+		return utils.ErrCouponNotActive
+	}
+	if coupon.EndsAt != nil && !now.Before(*coupon.EndsAt) {
+		return utils.ErrCouponExpired
+	}
+	if coupon.UsageLimit > 0 && coupon.UsageCount >= coupon.UsageLimit {
+		return utils.ErrCouponUsageLimitReached
+	}
+	return nil
+}
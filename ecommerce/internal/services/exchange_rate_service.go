@@ -0,0 +1,40 @@
+package services
+
+import (
+	"time"
+
+	"github.com/YugenDev/global-mobility-test/internal/models"
+	"github.com/YugenDev/global-mobility-test/internal/repositories"
+)
+
+type IExchangeRateService interface {
+	GetRates() (models.ExchangeRates, error)
+	UpdateRates(rates *models.ExchangeRates) error
+}
+
+type ExchangeRateService struct {
+	Repository repositories.IExchangeRateRepository
+}
+
+var _ IExchangeRateService = (*ExchangeRateService)(nil)
+
+func NewExchangeRateService(repo repositories.IExchangeRateRepository) *ExchangeRateService {
+	return &ExchangeRateService{
+		Repository: repo,
+	}
+}
+
+func (s *ExchangeRateService) GetRates() (models.ExchangeRates, error) {
+	return s.Repository.GetRates()
+}
+
+// UpdateRates replaces the whole rate table. Currency codes are upper-cased
+// before they are checked.
+func (s *ExchangeRateService) UpdateRates(rates *models.ExchangeRates) error {
+	if err := rates.Normalize(); err != nil {
+		return err
+	}
+	rates.UpdatedAt = time.Now()
+
+	return s.Repository.SaveRates(*rates)
+}
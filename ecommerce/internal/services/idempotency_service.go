@@ -0,0 +1,106 @@
+package services
+
+import (
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/YugenDev/global-mobility-test/internal/models"
+	"github.com/YugenDev/global-mobility-test/internal/repositories"
+	"github.com/YugenDev/global-mobility-test/internal/utils"
+)
+
+type IIdempotencyService interface {
+	Begin(key string, fingerprint string) (models.IdempotencyRecord, bool, error)
+	Complete(key string, fingerprint string, status int, header http.Header, body []byte) error
+	Release(key string)
+}
+
+// idempotencyLockTimeout is how long a key stays claimed by a request that
+// never completes, e.g. because the instance handling it crashed.
+const idempotencyLockTimeout = time.Minute
+
+type IdempotencyService struct {
+	Repository repositories.IIdempotencyRepository
+	TTL        time.Duration
+}
+
+var _ IIdempotencyService = (*IdempotencyService)(nil)
+
+func NewIdempotencyService(repo repositories.IIdempotencyRepository, ttl time.Duration) *IdempotencyService {
+	return &IdempotencyService{
+		Repository: repo,
+		TTL:        ttl,
+	}
+}
+
+// Begin claims key for the request with fingerprint. It reports true with
+// the stored response when the request was already handled, so that the
+// response can be replayed instead.
+func (s *IdempotencyService) Begin(key string, fingerprint string) (models.IdempotencyRecord, bool, error) {
+	for attempt := 0; attempt < 2; attempt++ {
+		now := time.Now()
+		err := s.Repository.ClaimIdempotencyKey(&models.IdempotencyRecord{
+			Key:         key,
+			Fingerprint: fingerprint,
+			CreatedAt:   now,
+			ExpiresAt:   now.Add(idempotencyLockTimeout),
+		})
+		if err == nil {
+			return models.IdempotencyRecord{}, false, nil
+		}
+		if err != utils.ErrIdempotencyKeyExists {
+			return models.IdempotencyRecord{}, false, err
+		}
+
+		record, err := s.Repository.GetIdempotencyRecord(key)
+		if err == utils.ErrIdempotencyRecordNotFound {
+			// The record expired in between; claim the key again.
+			continue
+		}
+		if err != nil {
+			return models.IdempotencyRecord{}, false, err
+		}
+		if record.Fingerprint != fingerprint {
+			return models.IdempotencyRecord{}, false, utils.ErrIdempotencyKeyReused
+		}
+		if !record.Completed() {
+			return models.IdempotencyRecord{}, false, utils.ErrIdempotencyKeyInProgress
+		}
+		return record, true, nil
+	}
+
+	return models.IdempotencyRecord{}, false, utils.ErrIdempotencyKeyInProgress
+}
+
+// Complete stores the response to the request that claimed key for the TTL,
+// with the headers from header that are replayed. It returns
+// ErrIdempotencyClaimLost when the claim expired or was released in the
+// meantime, in which case nothing is stored.
+func (s *IdempotencyService) Complete(key string, fingerprint string, status int, header http.Header, body []byte) error {
+	result, err := s.Repository.CompleteIdempotencyRecord(&models.IdempotencyRecord{
+		Key:         key,
+		Fingerprint: fingerprint,
+		Status:      status,
+		ContentType: header.Get("Content-Type"),
+		ETag:        header.Get("ETag"),
+		Location:    header.Get("Location"),
+		Body:        body,
+		ExpiresAt:   time.Now().Add(s.TTL),
+	})
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return utils.ErrIdempotencyClaimLost
+	}
+	return nil
+}
+
+// Release gives up the claim on key, so that a retry is handled afresh. A
+// failure is logged.
+func (s *IdempotencyService) Release(key string) {
+	if _, err := s.Repository.DeleteIdempotencyRecord(key); err != nil {
+		log.Println("Error releasing idempotency key: ", err)
+	}
+}
@@ -0,0 +1,248 @@
+package services
+
+import (
+	"log"
+	"time"
+
+	"github.com/YugenDev/global-mobility-test/internal/models"
+	"github.com/YugenDev/global-mobility-test/internal/repositories"
+	"github.com/YugenDev/global-mobility-test/internal/utils"
+	"github.com/labstack/echo/v4"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type IOrderService interface {
+	Checkout(c echo.Context, request models.CheckoutRequest) (models.Order, error)
+	GetByID(id string) (models.Order, error)
+	UpdateStatus(c echo.Context, id string, status string) (models.Order, error)
+}
+
+type OrderService struct {
+	Repository     repositories.IOrderRepository
+	ProductService IProductService
+	CartService    ICartService
+	CouponService  ICouponService
+}
+
+var _ IOrderService = (*OrderService)(nil)
+
+func NewOrderService(repo repositories.IOrderRepository, productService IProductService, cartService ICartService, couponService ICouponService) *OrderService {
+	return &OrderService{
+		Repository:     repo,
+		ProductService: productService,
+		CartService:    cartService,
+		CouponService:  couponService,
+	}
+}
+
+// Checkout turns a cart or a list of items into a pending order, discounted
+// by the coupon when one is given. Stock for every line is taken with an
+// atomic decrement; if any line cannot be fulfilled the lines already taken
+// are put back, the coupon use is given back and nothing is ordered.
+func (s *OrderService) Checkout(c echo.Context, request models.CheckoutRequest) (models.Order, error) {
+	if (request.CartID == "") == (len(request.Items) == 0) {
+		return models.Order{}, utils.ErrInvalidCheckoutRequest
+	}
+
+	var items []models.OrderItem
+	if request.CartID != "" {
+		cart, err := s.CartService.GetCart(request.CartID)
+		if err != nil {
+			return models.Order{}, err
+		}
+		items = cartOrderItems(cart)
+	} else {
+		var err error
+		items, err = priceItems(s.ProductService, request.Items)
+		if err != nil {
+			return models.Order{}, err
+		}
+	}
+	if len(items) == 0 {
+		return models.Order{}, utils.ErrEmptyOrder
+	}
+
+	var coupon models.CouponEvaluation
+	if request.CouponCode != "" {
+		var err error
+		coupon, err = s.CouponService.Apply(request.CouponCode, items)
+		if err != nil {
+			return models.Order{}, err
+		}
+		if err := s.CouponService.Redeem(coupon.CouponID); err != nil {
+			return models.Order{}, err
+		}
+		items = coupon.Items
+	}
+
+	for i, item := range items {
+		if err := s.adjustStock(c, item, -item.Quantity); err != nil {
+			s.restock(c, items[:i])
+			s.releaseCoupon(coupon.CouponID)
+			if err == mongo.ErrNoDocuments {
+				return models.Order{}, utils.ErrNoProductsFound
+			}
+			return models.Order{}, err
+		}
+	}
+
+	now := time.Now()
+	order := models.Order{
+		OrderID:    utils.GenerateUniqueID(),
+		CartID:     request.CartID,
+		Items:      items,
+		CouponID:   coupon.CouponID,
+		CouponCode: coupon.Code,
+		Status:     models.OrderStatusPending,
+		History:    []models.OrderStatusChange{{Status: models.OrderStatusPending, At: now}},
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+	order.Recalculate()
+
+	if err := s.Repository.CreateOrder(&order); err != nil {
+		s.restock(c, items)
+		s.releaseCoupon(coupon.CouponID)
+		return models.Order{}, err
+	}
+
+	if request.CartID != "" {
+		if _, err := s.CartService.ClearCart(c, request.CartID); err != nil {
+			log.Println("Error clearing checked out cart: ", err)
+		}
+	}
+
+	return order, nil
+}
+
+func (s *OrderService) GetByID(id string) (models.Order, error) {
+	if id == "" {
+		return models.Order{}, utils.ErrOrderIDRequired
+	}
+	return s.Repository.GetOrderByID(id)
+}
+
+// UpdateStatus moves the order along its lifecycle. Cancelling an order puts
+// its items back in stock and gives back its coupon use.
+func (s *OrderService) UpdateStatus(c echo.Context, id string, status string) (models.Order, error) {
+	if !models.OrderStatuses[status] {
+		return models.Order{}, utils.ErrInvalidOrderStatus
+	}
+
+	order, err := s.GetByID(id)
+	if err != nil {
+		return models.Order{}, err
+	}
+	if !models.CanTransitionOrder(order.Status, status) {
+		return models.Order{}, utils.ErrInvalidOrderTransition
+	}
+
+	now := time.Now()
+	result, err := s.Repository.TransitionOrder(id, order.Status, status, now)
+	if err != nil {
+		return models.Order{}, err
+	}
+	if result.MatchedCount == 0 {
+		return models.Order{}, utils.ErrInvalidOrderTransition
+	}
+
+	if status == models.OrderStatusCancelled {
+		s.restock(c, order.Items)
+		s.releaseCoupon(order.CouponID)
+	}
+
+	order.Status = status
+	order.UpdatedAt = now
+	order.History = append(order.History, models.OrderStatusChange{Status: status, At: now})
+	return order, nil
+}
+
+// cartOrderItems freezes the cart's price snapshots into order items.
+func cartOrderItems(cart models.Cart) []models.OrderItem {
+	items := make([]models.OrderItem, 0, len(cart.Items))
+	for _, item := range cart.Items {
+		items = append(items, models.OrderItem{
+			ProductID: item.ProductID,
+			SKU:       item.SKU,
+			Name:      item.Name,
+			UnitPrice: item.UnitPrice,
+			Quantity:  item.Quantity,
+		})
+	}
+	return items
+}
+
+// priceItems prices a list of items at the products' current prices,
+// merging repeated products and variants into one line. All items must be
+// priced in the same currency.
+func priceItems(productService IProductService, requested []models.CartItemRequest) ([]models.OrderItem, error) {
+	var items []models.OrderItem
+	lines := make(map[models.CartItemRequest]int)
+	for _, request := range requested {
+		if request.ProductID == "" {
+			return nil, utils.ErrProductIDRequired
+		}
+		if request.Quantity <= 0 {
+			return nil, utils.ErrInvalidCartQuantity
+		}
+
+		key := models.CartItemRequest{ProductID: request.ProductID, SKU: request.SKU}
+		if line, ok := lines[key]; ok {
+			items[line].Quantity += request.Quantity
+			continue
+		}
+
+		product, err := productService.GetByID(request.ProductID)
+		if err == mongo.ErrNoDocuments {
+			return nil, utils.ErrNoProductsFound
+		}
+		if err != nil {
+			return nil, err
+		}
+		price, _, err := productOffer(product, request.SKU)
+		if err != nil {
+			return nil, err
+		}
+		if len(items) > 0 && price.Currency != items[0].UnitPrice.Currency {
+			return nil, utils.ErrCurrencyMismatch
+		}
+
+		lines[key] = len(items)
+		items = append(items, models.OrderItem{
+			ProductID: product.ProductID,
+			SKU:       request.SKU,
+			Name:      product.Name,
+			UnitPrice: price,
+			Quantity:  request.Quantity,
+		})
+	}
+	return items, nil
+}
+
+// adjustStock changes the stock of the item's variant, or of the product when
+// the item has no variant.
+func (s *OrderService) adjustStock(c echo.Context, item models.OrderItem, delta int) error {
+	var err error
+	if item.SKU != "" {
+		_, err = s.ProductService.AdjustVariantStock(c, item.ProductID, item.SKU, delta)
+	} else {
+		_, err = s.ProductService.AdjustStock(c, item.ProductID, delta)
+	}
+	return err
+}
+
+// restock puts the items' quantities back. A failure here leaves stock too low
+// rather than oversold, so it is logged instead of failing the request.
+func (s *OrderService) restock(c echo.Context, items []models.OrderItem) {
+	for _, item := range items {
+		if err := s.adjustStock(c, item, item.Quantity); err != nil {
+			log.Printf("Error restocking %d units of product %s: %v", item.Quantity, item.ProductID, err)
+		}
+	}
+}
+
+func (s *OrderService) releaseCoupon(id string) {
+	if id != "" {
+		s.CouponService.Release(id)
+	}
+}
@@ -0,0 +1,318 @@
+package services
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/YugenDev/global-mobility-test/internal/models"
+	"github.com/YugenDev/global-mobility-test/internal/repositories"
+	"github.com/YugenDev/global-mobility-test/internal/utils"
+	"github.com/labstack/echo/v4"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type IPriceScheduleService interface {
+	Create(c echo.Context, productID string, request models.PriceScheduleRequest) (models.PriceSchedule, error)
+	GetByProductID(productID string) ([]models.PriceSchedule, error)
+	Cancel(c echo.Context, productID string, scheduleID string) (models.PriceSchedule, error)
+	ProcessDue(now time.Time) (int, error)
+}
+
+const duePriceScheduleBatchSize = 100
+
+type PriceScheduleService struct {
+	Repository     repositories.IPriceScheduleRepository
+	ProductService IProductService
+}
+
+var _ IPriceScheduleService = (*PriceScheduleService)(nil)
+
+func NewPriceScheduleService(repo repositories.IPriceScheduleRepository, productService IProductService) *PriceScheduleService {
+	return &PriceScheduleService{
+		Repository:     repo,
+		ProductService: productService,
+	}
+}
+
+// Create schedules a price for productID. A start in the past is applied on
+// the scheduler's next run. Windows of pending and active schedules for the
+// same product may not overlap, since each one reverts to the price it found.
+func (s *PriceScheduleService) Create(c echo.Context, productID string, request models.PriceScheduleRequest) (models.PriceSchedule, error) {
+	if productID == "" {
+		return models.PriceSchedule{}, utils.ErrProductIDRequired
+	}
+
+	now := time.Now()
+	if request.StartsAt.IsZero() || (request.EndsAt != nil && (!request.EndsAt.After(request.StartsAt) || !request.EndsAt.After(now))) {
+		return models.PriceSchedule{}, utils.ErrInvalidPriceSchedule
+	}
+	if err := validatePrice(request.Price); err != nil {
+		return models.PriceSchedule{}, err
+	}
+
+	product, err := s.ProductService.GetByID(productID)
+	if err != nil {
+		return models.PriceSchedule{}, err
+	}
+	if request.Price.Currency != product.Price.Currency {
+		return models.PriceSchedule{}, utils.ErrCurrencyMismatch
+	}
+
+	schedule := models.PriceSchedule{
+		ScheduleID: utils.GenerateUniqueID(),
+		ProductID:  productID,
+		Price:      request.Price,
+		StartsAt:   request.StartsAt,
+		EndsAt:     request.EndsAt,
+		Status:     models.PriceScheduleStatusPending,
+		CreatedBy:  utils.ActorFromContext(c),
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+
+	existing, err := s.Repository.GetSchedulesByProductID(productID)
+	if err != nil {
+		return models.PriceSchedule{}, err
+	}
+	for _, other := range existing {
+		if other.Status != models.PriceScheduleStatusPending && other.Status != models.PriceScheduleStatusActive {
+			continue
+		}
+		if schedulesOverlap(schedule, other) {
+			return models.PriceSchedule{}, utils.ErrPriceScheduleOverlap
+		}
+	}
+
+	if err := s.Repository.CreateSchedule(&schedule); err != nil {
+		return models.PriceSchedule{}, err
+	}
+
+	return schedule, nil
+}
+
+func (s *PriceScheduleService) GetByProductID(productID string) ([]models.PriceSchedule, error) {
+	if productID == "" {
+		return nil, utils.ErrProductIDRequired
+	}
+
+	schedules, err := s.Repository.GetSchedulesByProductID(productID)
+	if err != nil {
+		return nil, err
+	}
+	if schedules == nil {
+		schedules = []models.PriceSchedule{}
+	}
+
+	return schedules, nil
+}
+
+// Cancel stops a schedule. A schedule that is already active reverts the
+// price straight away.
+func (s *PriceScheduleService) Cancel(c echo.Context, productID string, scheduleID string) (models.PriceSchedule, error) {
+	schedule, err := s.Repository.GetScheduleByID(scheduleID)
+	if err != nil {
+		return models.PriceSchedule{}, err
+	}
+	if schedule.ProductID != productID {
+		return models.PriceSchedule{}, utils.ErrPriceScheduleNotFound
+	}
+
+	from := schedule.Status
+	if from != models.PriceScheduleStatusPending && from != models.PriceScheduleStatusActive {
+		return models.PriceSchedule{}, utils.ErrPriceScheduleFinished
+	}
+
+	result, err := s.Repository.TransitionSchedule(scheduleID, from, models.PriceScheduleStatusCancelled, nil)
+	if err != nil {
+		return models.PriceSchedule{}, err
+	}
+	if result.MatchedCount == 0 {
+		return models.PriceSchedule{}, utils.ErrPriceScheduleFinished
+	}
+	schedule.Status = models.PriceScheduleStatusCancelled
+
+	if from == models.PriceScheduleStatusActive {
+		if err := s.revert(c, schedule); err != nil {
+			if _, rollbackErr := s.Repository.TransitionSchedule(scheduleID, models.PriceScheduleStatusCancelled, from, nil); rollbackErr != nil {
+				log.Println("Error handing back price schedule: ", rollbackErr)
+			}
+			return models.PriceSchedule{}, err
+		}
+	}
+
+	return schedule, nil
+}
+
+// ProcessDue ends every active schedule whose end has passed by now and then
+// starts every pending schedule whose start has. Ending first lets a window
+// that starts exactly when another ends find the price the first one
+// reverted to. It returns how many schedules were applied or reverted.
+func (s *PriceScheduleService) ProcessDue(now time.Time) (int, error) {
+	ended, err := s.processBatches(func() ([]models.PriceSchedule, error) {
+		return s.Repository.GetSchedulesToEnd(now, duePriceScheduleBatchSize)
+	}, s.end)
+	if err != nil {
+		return ended, err
+	}
+
+	started, err := s.processBatches(func() ([]models.PriceSchedule, error) {
+		return s.Repository.GetSchedulesToStart(now, duePriceScheduleBatchSize)
+	}, s.start)
+	return ended + started, err
+}
+
+// RunScheduler applies and reverts due price schedules every interval until
+// ctx is done.
+func (s *PriceScheduleService) RunScheduler(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			processed, err := s.ProcessDue(now)
+			if err != nil {
+				log.Println("Error processing price schedules: ", err)
+			}
+			if processed > 0 {
+				log.Printf("Processed %d price schedules", processed)
+			}
+		}
+	}
+}
+
+func (s *PriceScheduleService) processBatches(next func() ([]models.PriceSchedule, error), process func(models.PriceSchedule) (bool, error)) (int, error) {
+	var processed int
+	for {
+		schedules, err := next()
+		if err != nil {
+			return processed, err
+		}
+
+		for _, schedule := range schedules {
+			done, err := process(schedule)
+			if err != nil {
+				return processed, err
+			}
+			if done {
+				processed++
+			}
+		}
+
+		if len(schedules) < duePriceScheduleBatchSize {
+			return processed, nil
+		}
+	}
+}
+
+// start claims a pending schedule, remembering the price it replaces, and
+// applies its price. A schedule without an end goes straight to ended. A
+// schedule that can never apply, because the product was deleted or now has
+// another currency, is cancelled; any other failure hands the schedule back
+// so the next run retries it.
+func (s *PriceScheduleService) start(schedule models.PriceSchedule) (bool, error) {
+	product, err := s.ProductService.GetByID(schedule.ProductID)
+	if err == nil && product.Price.Currency != schedule.Price.Currency {
+		err = utils.ErrCurrencyMismatch
+	}
+	if scheduleCannotApply(err) {
+		_, err = s.Repository.TransitionSchedule(schedule.ScheduleID, models.PriceScheduleStatusPending, models.PriceScheduleStatusCancelled, nil)
+		return false, err
+	}
+	if err != nil {
+		return false, err
+	}
+
+	to := models.PriceScheduleStatusActive
+	if schedule.EndsAt == nil {
+		to = models.PriceScheduleStatusEnded
+	}
+
+	result, err := s.Repository.TransitionSchedule(schedule.ScheduleID, models.PriceScheduleStatusPending, to, &product.Price)
+	if err != nil {
+		return false, err
+	}
+	if result.MatchedCount == 0 {
+		return false, nil
+	}
+
+	if _, err := s.ProductService.SetPrice(nil, schedule.ProductID, schedule.Price, schedule.ScheduleID); err != nil {
+		next := models.PriceScheduleStatusPending
+		if scheduleCannotApply(err) {
+			next = models.PriceScheduleStatusCancelled
+		}
+		if _, rollbackErr := s.Repository.TransitionSchedule(schedule.ScheduleID, to, next, nil); rollbackErr != nil {
+			log.Println("Error handing back price schedule: ", rollbackErr)
+		}
+		if next == models.PriceScheduleStatusCancelled {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (s *PriceScheduleService) end(schedule models.PriceSchedule) (bool, error) {
+	result, err := s.Repository.TransitionSchedule(schedule.ScheduleID, models.PriceScheduleStatusActive, models.PriceScheduleStatusEnded, nil)
+	if err != nil {
+		return false, err
+	}
+	if result.MatchedCount == 0 {
+		return false, nil
+	}
+
+	if err := s.revert(nil, schedule); err != nil {
+		if _, rollbackErr := s.Repository.TransitionSchedule(schedule.ScheduleID, models.PriceScheduleStatusEnded, models.PriceScheduleStatusActive, nil); rollbackErr != nil {
+			log.Println("Error handing back price schedule: ", rollbackErr)
+		}
+		return false, err
+	}
+
+	return true, nil
+}
+
+// revert puts back the price an active schedule replaced. A price that was
+// changed by hand while the schedule ran is kept, as is a deleted product.
+func (s *PriceScheduleService) revert(c echo.Context, schedule models.PriceSchedule) error {
+	if schedule.RevertPrice == nil {
+		return nil
+	}
+
+	product, err := s.ProductService.GetByID(schedule.ProductID)
+	if err == mongo.ErrNoDocuments {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if product.Price != schedule.Price {
+		return nil
+	}
+
+	_, err = s.ProductService.SetPrice(c, schedule.ProductID, *schedule.RevertPrice, schedule.ScheduleID)
+	return err
+}
+
+func scheduleCannotApply(err error) bool {
+	return err == mongo.ErrNoDocuments || err == utils.ErrCurrencyMismatch
+}
+
+// schedulesOverlap reports whether the windows of a and b share any time. A
+// schedule without an end only occupies its start.
+func schedulesOverlap(a, b models.PriceSchedule) bool {
+	if a.StartsAt.Equal(b.StartsAt) {
+		return true
+	}
+	return a.StartsAt.Before(scheduleEnd(b)) && b.StartsAt.Before(scheduleEnd(a))
+}
+
+func scheduleEnd(schedule models.PriceSchedule) time.Time {
+	if schedule.EndsAt == nil {
+		return schedule.StartsAt
+	}
+	return *schedule.EndsAt
+}
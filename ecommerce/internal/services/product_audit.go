@@ -0,0 +1,123 @@
+package services
+
+import (
+	"log"
+	"reflect"
+	"slices"
+	"time"
+
+	"github.com/YugenDev/global-mobility-test/internal/models"
+	"github.com/YugenDev/global-mobility-test/internal/repositories"
+	"github.com/YugenDev/global-mobility-test/internal/utils"
+	"github.com/labstack/echo/v4"
+)
+
+func (s *ProductService) GetHistory(query models.AuditQuery) (models.AuditPage, error) {
+	if query.ProductID == "" {
+		return models.AuditPage{}, utils.ErrProductIDRequired
+	}
+	if query.Limit == 0 {
+		query.Limit = DefaultPageLimit
+	}
+	if query.Limit < 0 || query.Limit > MaxPageLimit {
+		return models.AuditPage{}, utils.ErrInvalidPageLimit
+	}
+
+	if query.Cursor != "" {
+		var after models.AuditCursor
+		if err := utils.DecodeCursor(query.Cursor, &after); err != nil {
+			return models.AuditPage{}, err
+		}
+		query.After = &after
+	}
+
+	limit := query.Limit
+	query.Limit = limit + 1
+
+	entries, err := s.AuditRepository.GetEntriesByProductID(query)
+	if err != nil {
+		return models.AuditPage{}, err
+	}
+
+	page := models.AuditPage{Entries: entries}
+	if page.Entries == nil {
+		page.Entries = []models.ProductAuditEntry{}
+	}
+	if len(entries) > limit {
+		page.Entries = entries[:limit]
+		page.HasMore = true
+
+		last := page.Entries[limit-1]
+		page.NextCursor = utils.EncodeCursor(models.AuditCursor{
+			CreatedAt: last.CreatedAt,
+			AuditID:   last.AuditID,
+		})
+	}
+
+	return page, nil
+}
+
+// recordAudit stores who changed which product fields, and the new price
+// when it changed. The product write has already succeeded by the time this
+// runs, so a failure is logged rather than returned to the caller.
+func (s *ProductService) recordAudit(c echo.Context, action string, before, after models.Product) {
+	recordProductAudit(s.AuditRepository, c, action, before, after)
+	if before.Price != after.Price {
+		s.recordPrice(c, after, "")
+	}
+}
+
+func recordProductAudit(auditRepo repositories.IAuditRepository, c echo.Context, action string, before, after models.Product) {
+	productID := after.ProductID
+	if productID == "" {
+		productID = before.ProductID
+	}
+
+	entry := &models.ProductAuditEntry{
+		AuditID:   utils.GenerateUniqueID(),
+		ProductID: productID,
+		Action:    action,
+		Changes:   diffProducts(before, after),
+		Actor:     utils.ActorFromContext(c),
+		RequestID: utils.RequestIDFromContext(c),
+		CreatedAt: time.Now(),
+	}
+
+	if err := auditRepo.CreateEntry(entry); err != nil {
+		log.Println("Error recording audit entry: ", err)
+	}
+}
+
+func diffProducts(before, after models.Product) []models.FieldChange {
+	changes := []models.FieldChange{}
+
+	if before.Name != after.Name {
+		changes = append(changes, models.FieldChange{Field: "name", Before: before.Name, After: after.Name})
+	}
+	if before.Description != after.Description {
+		changes = append(changes, models.FieldChange{Field: "description", Before: before.Description, After: after.Description})
+	}
+	if before.Price != after.Price {
+		changes = append(changes, models.FieldChange{Field: "price", Before: before.Price, After: after.Price})
+	}
+	if !slices.Equal(before.CategoryIDs, after.CategoryIDs) {
+		changes = append(changes, models.FieldChange{Field: "category_ids", Before: before.CategoryIDs, After: after.CategoryIDs})
+	}
+	if !reflect.DeepEqual(before.Prices, after.Prices) {
+		changes = append(changes, models.FieldChange{Field: "prices", Before: before.Prices, After: after.Prices})
+	}
+	if before.Stock != after.Stock {
+		changes = append(changes, models.FieldChange{Field: "stock", Before: before.Stock, After: after.Stock})
+	}
+	if !reflect.DeepEqual(before.Variants, after.Variants) {
+		changes = append(changes, models.FieldChange{Field: "variants", Before: before.Variants, After: after.Variants})
+	}
+	if !reflect.DeepEqual(before.Images, after.Images) {
+		changes = append(changes, models.FieldChange{Field: "images", Before: before.Images, After: after.Images})
+	}
+	if (before.DeletedAt == nil) != (after.DeletedAt == nil) {
+		changes = append(changes, models.FieldChange{Field: "deleted_at", Before: before.DeletedAt, After: after.DeletedAt})
+	}
+
+	return changes
+}
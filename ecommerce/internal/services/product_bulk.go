@@ -0,0 +1,175 @@
+package services
+
+import (
+	"github.com/YugenDev/global-mobility-test/internal/models"
+	"github.com/YugenDev/global-mobility-test/internal/utils"
+	"github.com/labstack/echo/v4"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// BulkProducts validates every operation the same way the single-product
+// endpoints do and writes the valid ones in one batch. Invalid operations are
+// reported individually and do not stop the rest of the batch.
+func (s *ProductService) BulkProducts(c echo.Context, ops []models.BulkOperation) (models.BulkResponse, error) {
+	if len(ops) == 0 {
+		return models.BulkResponse{}, utils.ErrBulkOperationsRequired
+	}
+	if len(ops) > models.MaxBulkOperations {
+		return models.BulkResponse{}, utils.ErrTooManyBulkOperations
+	}
+
+	results := make([]models.BulkResult, len(ops))
+	var writes []models.ProductWrite
+	var befores []models.Product
+	var indexes []int
+	seen := make(map[string]bool)
+
+	for i, op := range ops {
+		results[i] = models.BulkResult{Index: i, Op: op.Op, ProductID: op.ProductID}
+
+		id := op.ProductID
+		if op.Op == models.BulkOpCreate && op.Product != nil {
+			id = op.Product.ProductID
+		}
+		if id != "" && seen[id] {
+			setBulkError(&results[i], utils.ErrDuplicateBulkProduct)
+			continue
+		}
+		seen[id] = true
+
+		write, before, err := s.prepareBulkWrite(op)
+		if err != nil {
+			setBulkError(&results[i], err)
+			continue
+		}
+
+		results[i].ProductID = write.Product.ProductID
+		writes = append(writes, write)
+		befores = append(befores, before)
+		indexes = append(indexes, i)
+	}
+
+	errs, err := s.Repository.BulkWriteProducts(c, writes)
+	if err != nil {
+		return models.BulkResponse{}, err
+	}
+
+	for j, write := range writes {
+		result := &results[indexes[j]]
+		switch {
+		case errs[j] == utils.ErrBulkOperationSkipped:
+			result.Status = models.BulkStatusSkipped
+		case errs[j] != nil:
+			setBulkError(result, errs[j])
+		case write.Op == models.BulkOpCreate:
+			result.Status = models.BulkStatusCreated
+			result.Version = write.Product.Version
+			s.recordAudit(c, models.AuditActionCreate, models.Product{}, write.Product)
+		case write.Op == models.BulkOpUpdate:
+			result.Status = models.BulkStatusUpdated
+			result.Version = write.Product.Version
+			s.recordAudit(c, models.AuditActionUpdate, befores[j], write.Product)
+		case write.Op == models.BulkOpDelete:
+			result.Status = models.BulkStatusDeleted
+			s.recordAudit(c, models.AuditActionDelete, befores[j], write.Product)
+		}
+	}
+
+	return models.BulkResponse{Results: results}, nil
+}
+
+// prepareBulkWrite turns op into a repository write, returning the product as
+// it was before the write for the audit log.
+func (s *ProductService) prepareBulkWrite(op models.BulkOperation) (models.ProductWrite, models.Product, error) {
+	switch op.Op {
+	case models.BulkOpCreate:
+		if op.Product == nil {
+			return models.ProductWrite{}, models.Product{}, utils.ErrNullProductData
+		}
+		product := *op.Product
+		if err := validateNewProduct(&product); err != nil {
+			return models.ProductWrite{}, models.Product{}, err
+		}
+		if err := s.resolveCategories(&product); err != nil {
+			return models.ProductWrite{}, models.Product{}, err
+		}
+		if product.ProductID == "" {
+			product.ProductID = utils.GenerateUniqueID()
+		} else if _, err := s.Repository.GetProductByID(product.ProductID); err == nil {
+			return models.ProductWrite{}, models.Product{}, utils.ErrProductIDAlreadyExists
+		}
+		return models.ProductWrite{Op: op.Op, Product: product}, models.Product{}, nil
+
+	case models.BulkOpUpdate:
+		if op.ProductID == "" {
+			return models.ProductWrite{}, models.Product{}, utils.ErrProductIDRequired
+		}
+		if op.Product == nil {
+			return models.ProductWrite{}, models.Product{}, utils.ErrNullProductData
+		}
+		existing, err := s.Repository.GetProductByID(op.ProductID)
+		if err != nil {
+			return models.ProductWrite{}, models.Product{}, err
+		}
+		before := existing
+		patch := *op.Product
+		if op.Version != 0 {
+			patch.Version = op.Version
+		}
+		if err := applyProductUpdate(&existing, &patch); err != nil {
+			return models.ProductWrite{}, models.Product{}, err
+		}
+		if err := s.resolveCategories(&existing); err != nil {
+			return models.ProductWrite{}, models.Product{}, err
+		}
+		return models.ProductWrite{Op: op.Op, Product: existing}, before, nil
+
+	case models.BulkOpDelete:
+		if op.ProductID == "" {
+			return models.ProductWrite{}, models.Product{}, utils.ErrProductIDRequired
+		}
+		existing, err := s.Repository.GetProductByID(op.ProductID)
+		if err != nil {
+			return models.ProductWrite{}, models.Product{}, err
+		}
+		if op.Version != 0 && op.Version != existing.Version {
+			return models.ProductWrite{}, models.Product{}, utils.ErrProductVersionMismatch
+		}
+		return models.ProductWrite{Op: op.Op, Product: existing}, existing, nil
+	}
+
+	return models.ProductWrite{}, models.Product{}, utils.ErrInvalidBulkOperation
+}
+
+func setBulkError(result *models.BulkResult, err error) {
+	result.Status = models.BulkStatusError
+	result.Code = bulkErrorCode(err)
+	result.Error = err.Error()
+
+	if err == mongo.ErrNoDocuments {
+		result.Error = utils.ErrNoProductsFound.Error()
+	} else if result.Code == "internal_error" {
+		result.Error = utils.ErrInternalServer.Error()
+	}
+}
+
+func bulkErrorCode(err error) string {
+	switch err {
+	case utils.ErrProductNameRequired, utils.ErrProductDescriptionRequired, utils.ErrProductPriceInvalid,
+		utils.ErrProductStockInvalid, utils.ErrProductIDRequired, utils.ErrProductIDCannotBeChanged,
+		utils.ErrNullProductData, utils.ErrUnknownCategory, utils.ErrVariantSKURequired, utils.ErrVariantSKUExists,
+		utils.ErrVariantOptionsRequired, utils.ErrDuplicateVariantOptions, utils.ErrVariantPriceInvalid,
+		utils.ErrVariantStockInvalid, utils.ErrVariantsNotEditable, utils.ErrProductHasVariants, utils.ErrImagesNotEditable,
+		utils.ErrUnsupportedCurrency, utils.ErrCurrencyMismatch, utils.ErrInvalidPriceOverride, utils.ErrStockBelowReserved:
+		return "invalid_product"
+	case utils.ErrInvalidBulkOperation:
+		return "invalid_operation"
+	case mongo.ErrNoDocuments:
+		return "not_found"
+	case utils.ErrProductIDAlreadyExists, utils.ErrDuplicateBulkProduct:
+		return "duplicate_id"
+	case utils.ErrProductVersionMismatch:
+		return "version_conflict"
+	}
+	return "internal_error"
+}
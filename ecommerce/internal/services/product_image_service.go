@@ -0,0 +1,251 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/YugenDev/global-mobility-test/internal/models"
+	"github.com/YugenDev/global-mobility-test/internal/repositories"
+	"github.com/YugenDev/global-mobility-test/internal/utils"
+	"github.com/labstack/echo/v4"
+)
+
+type IProductImageService interface {
+	AddImage(c echo.Context, id string, version int, upload models.ImageUpload) (models.Product, error)
+	ReorderImages(c echo.Context, id string, version int, imageIDs []string) (models.Product, error)
+	DeleteImage(c echo.Context, id string, imageID string, version int) (models.Product, error)
+	OpenImage(id string, imageID string) (models.ProductImage, io.ReadCloser, error)
+}
+
+type ProductImageService struct {
+	Repository      repositories.IProductRepository
+	AuditRepository repositories.IAuditRepository
+	BlobStore       repositories.IBlobStore
+	MaxSize         int64
+}
+
+var _ IProductImageService = (*ProductImageService)(nil)
+
+func NewProductImageService(repo repositories.IProductRepository, auditRepo repositories.IAuditRepository, blobStore repositories.IBlobStore, maxSize int64) *ProductImageService {
+	return &ProductImageService{
+		Repository:      repo,
+		AuditRepository: auditRepo,
+		BlobStore:       blobStore,
+		MaxSize:         maxSize,
+	}
+}
+
+// AddImage stores the uploaded file and appends it to the product's images.
+// The file's type is sniffed from its content; a declared type must agree.
+func (s *ProductImageService) AddImage(c echo.Context, id string, version int, upload models.ImageUpload) (models.Product, error) {
+	if id == "" {
+		return models.Product{}, utils.ErrProductIDRequired
+	}
+	if upload.Content == nil {
+		return models.Product{}, utils.ErrImageRequired
+	}
+
+	data, err := io.ReadAll(io.LimitReader(upload.Content, s.MaxSize+1))
+	if err != nil {
+		return models.Product{}, err
+	}
+	if len(data) == 0 {
+		return models.Product{}, utils.ErrImageRequired
+	}
+	if int64(len(data)) > s.MaxSize {
+		return models.Product{}, utils.ErrImageTooLarge
+	}
+
+	contentType := http.DetectContentType(data)
+	extension, ok := models.ImageContentTypes[contentType]
+	if !ok {
+		return models.Product{}, utils.ErrUnsupportedImageType
+	}
+	if upload.ContentType != "" {
+		declared, _, err := mime.ParseMediaType(upload.ContentType)
+		if err != nil || (declared != contentType && declared != "application/octet-stream") {
+			return models.Product{}, utils.ErrUnsupportedImageType
+		}
+	}
+
+	product, err := s.Repository.GetProductByID(id)
+	if err != nil {
+		return models.Product{}, err
+	}
+	if version != 0 && version != product.Version {
+		return models.Product{}, utils.ErrProductVersionMismatch
+	}
+	if len(product.Images) >= models.MaxProductImages {
+		return models.Product{}, utils.ErrTooManyImages
+	}
+
+	imageID := utils.GenerateUniqueID()
+	image := models.ProductImage{
+		ImageID:     imageID,
+		URL:         fmt.Sprintf("/products/%s/images/%s", id, imageID),
+		AltText:     upload.AltText,
+		Position:    len(product.Images),
+		ContentType: contentType,
+		Size:        int64(len(data)),
+		Key:         "products/" + id + "/" + imageID + extension,
+		CreatedAt:   time.Now(),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	if err := s.BlobStore.Put(ctx, image.Key, bytes.NewReader(data)); err != nil {
+		log.Println("Error storing image: ", err)
+		return models.Product{}, err
+	}
+
+	updated, err := s.writeImages(c, product, append(slices.Clone(product.Images), image))
+	if err != nil {
+		deleteBlob(s.BlobStore, image.Key)
+		return models.Product{}, err
+	}
+
+	return updated, nil
+}
+
+// ReorderImages sets the image positions to the order of imageIDs, which must
+// name every image of the product exactly once.
+func (s *ProductImageService) ReorderImages(c echo.Context, id string, version int, imageIDs []string) (models.Product, error) {
+	if id == "" {
+		return models.Product{}, utils.ErrProductIDRequired
+	}
+
+	product, err := s.Repository.GetProductByID(id)
+	if err != nil {
+		return models.Product{}, err
+	}
+	if version != 0 && version != product.Version {
+		return models.Product{}, utils.ErrProductVersionMismatch
+	}
+	if len(imageIDs) != len(product.Images) {
+		return models.Product{}, utils.ErrInvalidImageOrder
+	}
+
+	images := make([]models.ProductImage, 0, len(imageIDs))
+	for _, imageID := range imageIDs {
+		index := imageIndex(product.Images, imageID)
+		if index < 0 || imageIndex(images, imageID) >= 0 {
+			return models.Product{}, utils.ErrInvalidImageOrder
+		}
+		image := product.Images[index]
+		image.Position = len(images)
+		images = append(images, image)
+	}
+
+	return s.writeImages(c, product, images)
+}
+
+func (s *ProductImageService) DeleteImage(c echo.Context, id string, imageID string, version int) (models.Product, error) {
+	if id == "" {
+		return models.Product{}, utils.ErrProductIDRequired
+	}
+
+	product, err := s.Repository.GetProductByID(id)
+	if err != nil {
+		return models.Product{}, err
+	}
+	if version != 0 && version != product.Version {
+		return models.Product{}, utils.ErrProductVersionMismatch
+	}
+
+	index := imageIndex(product.Images, imageID)
+	if index < 0 {
+		return models.Product{}, utils.ErrImageNotFound
+	}
+	removed := product.Images[index]
+
+	images := slices.Delete(slices.Clone(product.Images), index, index+1)
+	for i := range images {
+		images[i].Position = i
+	}
+
+	updated, err := s.writeImages(c, product, images)
+	if err != nil {
+		return models.Product{}, err
+	}
+
+	deleteBlob(s.BlobStore, removed.Key)
+	return updated, nil
+}
+
+// OpenImage returns the image's metadata and its content. The caller must
+// close the reader.
+func (s *ProductImageService) OpenImage(id string, imageID string) (models.ProductImage, io.ReadCloser, error) {
+	if id == "" {
+		return models.ProductImage{}, nil, utils.ErrProductIDRequired
+	}
+
+	product, err := s.Repository.GetProductByID(id)
+	if err != nil {
+		return models.ProductImage{}, nil, err
+	}
+
+	index := imageIndex(product.Images, imageID)
+	if index < 0 {
+		return models.ProductImage{}, nil, utils.ErrImageNotFound
+	}
+	image := product.Images[index]
+
+	content, err := s.BlobStore.Open(context.Background(), image.Key)
+	if err == utils.ErrBlobNotFound {
+		return models.ProductImage{}, nil, utils.ErrImageNotFound
+	}
+	if err != nil {
+		return models.ProductImage{}, nil, err
+	}
+
+	return image, content, nil
+}
+
+// writeImages saves images on the product if it is still at the version it
+// was read at and records the change.
+func (s *ProductImageService) writeImages(c echo.Context, product models.Product, images []models.ProductImage) (models.Product, error) {
+	result, err := s.Repository.UpdateImages(c, product.ProductID, product.Version, images)
+	if err != nil {
+		return models.Product{}, err
+	}
+	if result.MatchedCount == 0 {
+		return models.Product{}, utils.ErrProductVersionMismatch
+	}
+
+	updated, err := s.Repository.GetProductByID(product.ProductID)
+	if err != nil {
+		return models.Product{}, err
+	}
+
+	recordProductAudit(s.AuditRepository, c, models.AuditActionUpdate, product, updated)
+
+	return updated, nil
+}
+
+// deleteBlob removes a file that is no longer referenced. A failure only
+// leaves an orphaned file behind, so it is logged.
+func deleteBlob(blobStore repositories.IBlobStore, key string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err := blobStore.Delete(ctx, key); err != nil {
+		log.Println("Error deleting image file: ", err)
+	}
+}
+
+func imageIndex(images []models.ProductImage, imageID string) int {
+	for i, image := range images {
+		if image.ImageID == imageID {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,116 @@
+package services
+
+import (
+	"log"
+	"time"
+
+	"github.com/YugenDev/global-mobility-test/internal/models"
+	"github.com/YugenDev/global-mobility-test/internal/utils"
+	"github.com/labstack/echo/v4"
+)
+
+// SetPrice changes only the product's price, keeping its currency. A price
+// set by a schedule passes the schedule's ID so the history can point to it.
+func (s *ProductService) SetPrice(c echo.Context, id string, price models.Money, scheduleID string) (models.Product, error) {
+	if id == "" {
+		return models.Product{}, utils.ErrProductIDRequired
+	}
+	if err := validatePrice(price); err != nil {
+		return models.Product{}, err
+	}
+
+	product, err := s.Repository.GetProductByID(id)
+	if err != nil {
+		return models.Product{}, err
+	}
+	if price.Currency != product.Price.Currency {
+		return models.Product{}, utils.ErrCurrencyMismatch
+	}
+	if price == product.Price {
+		return product, nil
+	}
+
+	before := product
+	product.Price = price
+
+	result, err := s.Repository.UpdateProduct(c, id, &product)
+	if err != nil {
+		return models.Product{}, err
+	}
+	if result.MatchedCount == 0 {
+		return models.Product{}, utils.ErrProductVersionMismatch
+	}
+
+	recordProductAudit(s.AuditRepository, c, models.AuditActionUpdate, before, product)
+	s.recordPrice(c, product, scheduleID)
+
+	return product, nil
+}
+
+func (s *ProductService) GetPriceHistory(query models.PriceHistoryQuery) (models.PriceHistoryPage, error) {
+	if query.ProductID == "" {
+		return models.PriceHistoryPage{}, utils.ErrProductIDRequired
+	}
+	if query.Limit == 0 {
+		query.Limit = DefaultPageLimit
+	}
+	if query.Limit < 0 || query.Limit > MaxPageLimit {
+		return models.PriceHistoryPage{}, utils.ErrInvalidPageLimit
+	}
+
+	if query.Cursor != "" {
+		var after models.PriceHistoryCursor
+		if err := utils.DecodeCursor(query.Cursor, &after); err != nil {
+			return models.PriceHistoryPage{}, err
+		}
+		query.After = &after
+	}
+
+	limit := query.Limit
+	query.Limit = limit + 1
+
+	entries, err := s.PriceHistoryRepository.GetEntriesByProductID(query)
+	if err != nil {
+		return models.PriceHistoryPage{}, err
+	}
+
+	page := models.PriceHistoryPage{Entries: entries}
+	if page.Entries == nil {
+		page.Entries = []models.PriceHistoryEntry{}
+	}
+	if len(entries) > limit {
+		page.Entries = entries[:limit]
+		page.HasMore = true
+
+		last := page.Entries[limit-1]
+		page.NextCursor = utils.EncodeCursor(models.PriceHistoryCursor{
+			EffectiveAt: last.EffectiveAt,
+			EntryID:     last.EntryID,
+		})
+	}
+
+	return page, nil
+}
+
+// recordPrice stores product's current price as its effective price from now
+// on. As with audit entries, a failure is logged rather than returned.
+func (s *ProductService) recordPrice(c echo.Context, product models.Product, scheduleID string) {
+	source := models.PriceSourceManual
+	if scheduleID != "" {
+		source = models.PriceSourceSchedule
+	}
+
+	entry := &models.PriceHistoryEntry{
+		EntryID:     utils.GenerateUniqueID(),
+		ProductID:   product.ProductID,
+		Price:       product.Price,
+		Source:      source,
+		ScheduleID:  scheduleID,
+		Actor:       utils.ActorFromContext(c),
+		EffectiveAt: time.Now(),
+	}
+
+	if err := s.PriceHistoryRepository.CreateEntry(entry); err != nil {
+		log.Println("Error recording price history entry: ", err)
+	}
+}
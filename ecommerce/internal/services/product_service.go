@@ -1,6 +1,9 @@
 package services
 
 import (
+	"strings"
+	"time"
+
 	"github.com/YugenDev/global-mobility-test/internal/models"
 	"github.com/YugenDev/global-mobility-test/internal/repositories"
 	"github.com/YugenDev/global-mobility-test/internal/utils"
@@ -9,36 +12,58 @@ import (
 
 type IProductService interface {
 	CreateProduct(c echo.Context, product *models.Product) error
-	GetAll() ([]models.Product, error)
+	GetAll(query models.ProductQuery) (models.ProductPage, error)
+	GetTrash(query models.ProductQuery) (models.ProductPage, error)
 	GetByID(id string) (models.Product, error)
+	PriceIn(product models.Product, currency string) (models.Product, error)
 	UpdateProduct(c echo.Context, id string, product *models.Product) error
-	DeleteProduct(c echo.Context, id string) error
+	DeleteProduct(c echo.Context, id string, version int) error
+	RestoreProduct(c echo.Context, id string) (models.Product, error)
+	PurgeDeletedProducts(retention time.Duration, blobStore repositories.IBlobStore) (int64, error)
+	SearchProducts(query models.ProductSearchQuery) (models.ProductSearchPage, error)
+	EnsureSearchIndex() error
+	GetHistory(query models.AuditQuery) (models.AuditPage, error)
+	SetPrice(c echo.Context, id string, price models.Money, scheduleID string) (models.Product, error)
+	GetPriceHistory(query models.PriceHistoryQuery) (models.PriceHistoryPage, error)
+	BulkProducts(c echo.Context, ops []models.BulkOperation) (models.BulkResponse, error)
+	AdjustStock(c echo.Context, id string, delta int) (models.Product, error)
+	AddVariant(c echo.Context, id string, version int, variant models.Variant) (models.Product, error)
+	UpdateVariant(c echo.Context, id string, sku string, version int, patch models.Variant) (models.Product, error)
+	RemoveVariant(c echo.Context, id string, sku string, version int) (models.Product, error)
+	AdjustVariantStock(c echo.Context, id string, sku string, delta int) (models.Product, error)
 }
 
+const (
+	DefaultPageLimit = 20
+	MaxPageLimit     = 100
+)
+
 type ProductService struct {
-	Repository repositories.IProductRepository
+	Repository             repositories.IProductRepository
+	AuditRepository        repositories.IAuditRepository
+	CategoryRepository     repositories.ICategoryRepository
+	ExchangeRateRepository repositories.IExchangeRateRepository
+	PriceHistoryRepository repositories.IPriceHistoryRepository
 }
 
 var _ IProductService = (*ProductService)(nil)
 
-func NewProductService(repo repositories.IProductRepository) *ProductService {
+func NewProductService(repo repositories.IProductRepository, auditRepo repositories.IAuditRepository, categoryRepo repositories.ICategoryRepository, rateRepo repositories.IExchangeRateRepository, priceHistoryRepo repositories.IPriceHistoryRepository) *ProductService {
 	return &ProductService{
-		Repository: repo,
+		Repository:             repo,
+		AuditRepository:        auditRepo,
+		CategoryRepository:     categoryRepo,
+		ExchangeRateRepository: rateRepo,
+		PriceHistoryRepository: priceHistoryRepo,
 	}
 }
 
 func (s *ProductService) CreateProduct(c echo.Context, product *models.Product) error {
-	if product.Name == "" {
-		return utils.ErrProductNameRequired
-	}
-	if product.Description == "" {
-		return utils.ErrProductDescriptionRequired
-	}
-	if product.Price <= 0 {
-		return utils.ErrProductPriceInvalid
+	if err := validateNewProduct(product); err != nil {
+		return err
 	}
-	if product.Stock < 0 {
-		return utils.ErrProductStockInvalid
+	if err := s.resolveCategories(product); err != nil {
+		return err
 	}
 
 	if product.ProductID == "" {
@@ -51,18 +76,95 @@ func (s *ProductService) CreateProduct(c echo.Context, product *models.Product)
 	}
 
 	_, err := s.Repository.CreateProduct(c, product)
-	return err
+	if err != nil {
+		return err
+	}
+
+	s.recordAudit(c, models.AuditActionCreate, models.Product{}, *product)
+	return nil
+}
+
+func (s *ProductService) GetAll(query models.ProductQuery) (models.ProductPage, error) {
+	query.Trashed = false
+
+	page, err := s.listProducts(query)
+	if err != nil {
+		return models.ProductPage{}, err
+	}
+	if len(page.Products) == 0 && query.Cursor == "" {
+		return models.ProductPage{}, utils.ErrNoProductsFound
+	}
+
+	return page, nil
+}
+
+func (s *ProductService) GetTrash(query models.ProductQuery) (models.ProductPage, error) {
+	query.Trashed = true
+	return s.listProducts(query)
 }
 
-func (s *ProductService) GetAll() ([]models.Product, error) {
-	products, err := s.Repository.GetAllProducts()
+func (s *ProductService) listProducts(query models.ProductQuery) (models.ProductPage, error) {
+	if query.Limit == 0 {
+		query.Limit = DefaultPageLimit
+	}
+	if query.Limit < 0 || query.Limit > MaxPageLimit {
+		return models.ProductPage{}, utils.ErrInvalidPageLimit
+	}
+
+	if field, _ := query.SortSpec(); !models.ProductSortFields[field] {
+		return models.ProductPage{}, utils.ErrInvalidSortField
+	}
+	if query.Filter.MinPrice != nil && query.Filter.MaxPrice != nil && query.Filter.MinPrice.Amount > query.Filter.MaxPrice.Amount {
+		return models.ProductPage{}, utils.ErrInvalidPriceRange
+	}
+	if query.Currency != "" && !models.SupportedCurrency(query.Currency) {
+		return models.ProductPage{}, utils.ErrUnsupportedCurrency
+	}
+
+	if query.Cursor != "" {
+		var after models.ProductCursor
+		if err := utils.DecodeCursor(query.Cursor, &after); err != nil {
+			return models.ProductPage{}, err
+		}
+		if after.Sort != query.Sort {
+			return models.ProductPage{}, utils.ErrInvalidCursor
+		}
+		query.After = &after
+	}
+
+	limit := query.Limit
+	query.Limit = limit + 1
+
+	products, err := s.Repository.GetAllProducts(query)
 	if err != nil {
-		return nil, err
+		return models.ProductPage{}, err
+	}
+
+	page := models.ProductPage{Products: products}
+	if page.Products == nil {
+		page.Products = []models.Product{}
+	}
+	if len(products) > limit {
+		page.Products = products[:limit]
+		page.HasMore = true
+
+		last := page.Products[limit-1]
+		page.NextCursor = utils.EncodeCursor(models.ProductCursor{
+			Sort:      query.Sort,
+			Price:     last.Price.Amount,
+			Name:      last.Name,
+			CreatedAt: last.CreatedAt,
+			ProductID: last.ProductID,
+		})
 	}
-	if len(products) == 0 {
-		return nil, utils.ErrNoProductsFound
+
+	for i, product := range page.Products {
+		if page.Products[i], err = s.PriceIn(product, query.Currency); err != nil {
+			return models.ProductPage{}, err
+		}
 	}
-	return products, nil
+
+	return page, nil
 }
 
 func (s *ProductService) GetByID(id string) (models.Product, error) {
@@ -72,6 +174,48 @@ func (s *ProductService) GetByID(id string) (models.Product, error) {
 	return s.Repository.GetProductByID(id)
 }
 
+// PriceIn returns product with its price and variant prices in currency. An
+// explicit price override in currency is used as is; other prices are
+// converted with the exchange rate table. An empty currency leaves the
+// product unchanged.
+func (s *ProductService) PriceIn(product models.Product, currency string) (models.Product, error) {
+	currency = strings.ToUpper(currency)
+	if currency == "" || currency == product.Price.Currency {
+		return product, nil
+	}
+	if !models.SupportedCurrency(currency) {
+		return models.Product{}, utils.ErrUnsupportedCurrency
+	}
+
+	rates, err := s.ExchangeRateRepository.GetRates()
+	if err != nil {
+		return models.Product{}, err
+	}
+
+	price, ok := product.PriceOverride(currency)
+	if !ok {
+		if price, err = rates.Convert(product.Price, currency); err != nil {
+			return models.Product{}, err
+		}
+	}
+
+	variants := models.CloneVariants(product.Variants)
+	for i := range variants {
+		if variants[i].Price == nil {
+			continue
+		}
+		converted, err := rates.Convert(*variants[i].Price, currency)
+		if err != nil {
+			return models.Product{}, err
+		}
+		variants[i].Price = &converted
+	}
+
+	product.Price = price
+	product.Variants = variants
+	return product, nil
+}
+
 func (s *ProductService) UpdateProduct(c echo.Context, id string, product *models.Product) error {
 	if id == "" {
 		return utils.ErrProductIDRequired
@@ -82,34 +226,32 @@ func (s *ProductService) UpdateProduct(c echo.Context, id string, product *model
 		return err
 	}
 
-	if product.ProductID != "" && product.ProductID != id {
-		return utils.ErrProductIDCannotBeChanged
-	}
-
-	if product.Name != "" {
-		existingProduct.Name = product.Name
+	before := existingProduct
+	if err := applyProductUpdate(&existingProduct, product); err != nil {
+		return err
 	}
-	if product.Description != "" {
-		existingProduct.Description = product.Description
+	if err := s.resolveCategories(&existingProduct); err != nil {
+		return err
 	}
-	if product.Price != 0 {
-		if product.Price <= 0 {
-			return utils.ErrProductPriceInvalid
-		}
-		existingProduct.Price = product.Price
+
+	result, err := s.Repository.UpdateProduct(c, id, &existingProduct)
+	if err != nil {
+		return err
 	}
-	if product.Stock != 0 {
-		if product.Stock < 0 {
-			return utils.ErrProductStockInvalid
+	if result.MatchedCount == 0 {
+		if current, err := s.Repository.GetProductByID(id); err == nil && current.Reserved > existingProduct.Stock {
+			return utils.ErrStockBelowReserved
 		}
-		existingProduct.Stock = product.Stock
+		return utils.ErrProductVersionMismatch
 	}
 
-	_, err = s.Repository.UpdateProduct(c, id, &existingProduct)
-	return err
+	s.recordAudit(c, models.AuditActionUpdate, before, existingProduct)
+
+	*product = existingProduct
+	return nil
 }
 
-func (s *ProductService) DeleteProduct(c echo.Context, id string) error {
+func (s *ProductService) DeleteProduct(c echo.Context, id string, version int) error {
 	if id == "" {
 		return utils.ErrProductIDRequired
 	}
@@ -121,7 +263,275 @@ func (s *ProductService) DeleteProduct(c echo.Context, id string) error {
 	if product.ProductID == "" {
 		return utils.ErrNoProductsFound
 	}
+	if version != 0 && version != product.Version {
+		return utils.ErrProductVersionMismatch
+	}
+
+	result, err := s.Repository.DeleteProduct(c, id, product.Version)
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return utils.ErrProductVersionMismatch
+	}
+
+	deleted := product
+	now := time.Now()
+	deleted.DeletedAt = &now
+	s.recordAudit(c, models.AuditActionDelete, product, deleted)
+
+	return nil
+}
+
+func (s *ProductService) RestoreProduct(c echo.Context, id string) (models.Product, error) {
+	if id == "" {
+		return models.Product{}, utils.ErrProductIDRequired
+	}
+
+	result, err := s.Repository.RestoreProduct(c, id)
+	if err != nil {
+		return models.Product{}, err
+	}
+	if result.MatchedCount == 0 {
+		return models.Product{}, utils.ErrProductNotInTrash
+	}
+
+	product, err := s.Repository.GetProductByID(id)
+	if err != nil {
+		return models.Product{}, err
+	}
+
+	s.recordAudit(c, models.AuditActionRestore, product, product)
+
+	return product, nil
+}
+
+func (s *ProductService) AdjustStock(c echo.Context, id string, delta int) (models.Product, error) {
+	if id == "" {
+		return models.Product{}, utils.ErrProductIDRequired
+	}
+	if delta == 0 {
+		return models.Product{}, utils.ErrInvalidStockDelta
+	}
+
+	product, err := s.Repository.AdjustStock(c, id, delta)
+	if err != nil {
+		return models.Product{}, err
+	}
+
+	before := product
+	before.Stock -= delta
+	s.recordAudit(c, models.AuditActionStock, before, product)
+
+	return product, nil
+}
+
+// PurgeDeletedProducts permanently removes the products that have been in
+// the trash for longer than retention, along with their image files in
+// blobStore.
+func (s *ProductService) PurgeDeletedProducts(retention time.Duration, blobStore repositories.IBlobStore) (int64, error) {
+	if retention <= 0 {
+		return 0, utils.ErrInvalidTrashRetention
+	}
+
+	purged, err := s.Repository.PurgeDeletedProducts(time.Now().Add(-retention))
+	for _, product := range purged {
+		for _, image := range product.Images {
+			deleteBlob(blobStore, image.Key)
+		}
+	}
+
+	return int64(len(purged)), err
+}
+
+func (s *ProductService) SearchProducts(query models.ProductSearchQuery) (models.ProductSearchPage, error) {
+	if strings.TrimSpace(query.Text) == "" {
+		return models.ProductSearchPage{}, utils.ErrSearchQueryRequired
+	}
+	if query.Limit == 0 {
+		query.Limit = DefaultPageLimit
+	}
+	if query.Limit < 0 || query.Limit > MaxPageLimit {
+		return models.ProductSearchPage{}, utils.ErrInvalidPageLimit
+	}
+
+	if query.Cursor != "" {
+		var cursor models.ProductSearchCursor
+		if err := utils.DecodeCursor(query.Cursor, &cursor); err != nil {
+			return models.ProductSearchPage{}, err
+		}
+		if cursor.Text != query.Text || cursor.Offset < 0 {
+			return models.ProductSearchPage{}, utils.ErrInvalidCursor
+		}
+		query.Offset = cursor.Offset
+	}
+
+	limit := query.Limit
+	query.Limit = limit + 1
+
+	results, err := s.Repository.SearchProducts(query)
+	if err != nil {
+		return models.ProductSearchPage{}, err
+	}
+
+	page := models.ProductSearchPage{Results: results}
+	if page.Results == nil {
+		page.Results = []models.ProductSearchResult{}
+	}
+	if len(results) > limit {
+		page.Results = results[:limit]
+		page.HasMore = true
+		page.NextCursor = utils.EncodeCursor(models.ProductSearchCursor{
+			Text:   query.Text,
+			Offset: query.Offset + limit,
+		})
+	}
+
+	return page, nil
+}
+
+func (s *ProductService) EnsureSearchIndex() error {
+	return s.Repository.EnsureTextIndex()
+}
+
+func validateNewProduct(product *models.Product) error {
+	if product.Name == "" {
+		return utils.ErrProductNameRequired
+	}
+	if product.Description == "" {
+		return utils.ErrProductDescriptionRequired
+	}
+	if err := validatePrice(product.Price); err != nil {
+		return err
+	}
+	if err := validatePriceOverrides(product.Price, product.Prices); err != nil {
+		return err
+	}
+	if product.Stock < 0 {
+		return utils.ErrProductStockInvalid
+	}
+	if product.Images != nil {
+		return utils.ErrImagesNotEditable
+	}
+	if len(product.Variants) > 0 {
+		if err := validateVariants(product.Variants, product.Price.Currency); err != nil {
+			return err
+		}
+		product.Stock = models.VariantStock(product.Variants)
+	}
+	return nil
+}
+
+// validatePrice checks that a product price is positive and in a supported
+// currency.
+func validatePrice(price models.Money) error {
+	if price.Amount <= 0 {
+		return utils.ErrProductPriceInvalid
+	}
+	if !models.SupportedCurrency(price.Currency) {
+		return utils.ErrUnsupportedCurrency
+	}
+	return nil
+}
+
+// validatePriceOverrides checks the product's explicit prices in currencies
+// other than price's.
+func validatePriceOverrides(price models.Money, overrides []models.Money) error {
+	seen := map[string]bool{price.Currency: true}
+	for _, override := range overrides {
+		if !models.SupportedCurrency(override.Currency) {
+			return utils.ErrUnsupportedCurrency
+		}
+		if override.Amount <= 0 || seen[override.Currency] {
+			return utils.ErrInvalidPriceOverride
+		}
+		seen[override.Currency] = true
+	}
+	return nil
+}
+
+// applyProductUpdate merges the non-zero fields of patch into existing after
+// checking that the patch targets the same product at the same version.
+func applyProductUpdate(existing *models.Product, patch *models.Product) error {
+	if patch.ProductID != "" && patch.ProductID != existing.ProductID {
+		return utils.ErrProductIDCannotBeChanged
+	}
+	if patch.Version != 0 && patch.Version != existing.Version {
+		return utils.ErrProductVersionMismatch
+	}
+	if patch.Variants != nil {
+		return utils.ErrVariantsNotEditable
+	}
+	if patch.Images != nil {
+		return utils.ErrImagesNotEditable
+	}
+	if patch.Stock != 0 && len(existing.Variants) > 0 {
+		return utils.ErrProductHasVariants
+	}
+
+	if patch.Name != "" {
+		existing.Name = patch.Name
+	}
+	if patch.Description != "" {
+		existing.Description = patch.Description
+	}
+	if !patch.Price.IsZero() {
+		if err := validatePrice(patch.Price); err != nil {
+			return err
+		}
+		if patch.Price.Currency != existing.Price.Currency {
+			for _, variant := range existing.Variants {
+				if variant.Price != nil {
+					return utils.ErrCurrencyMismatch
+				}
+			}
+		}
+		existing.Price = patch.Price
+	}
+	if patch.Prices != nil {
+		existing.Prices = patch.Prices
+	}
+	if err := validatePriceOverrides(existing.Price, existing.Prices); err != nil {
+		return err
+	}
+	if patch.Stock != 0 {
+		if patch.Stock < 0 {
+			return utils.ErrProductStockInvalid
+		}
+		if patch.Stock < existing.Reserved {
+			return utils.ErrStockBelowReserved
+		}
+		existing.Stock = patch.Stock
+	}
+	if patch.CategoryIDs != nil {
+		existing.CategoryIDs = patch.CategoryIDs
+	}
+	return nil
+}
+
+// resolveCategories checks that every category on product exists and drops
+// duplicates, keeping the first occurrence.
+func (s *ProductService) resolveCategories(product *models.Product) error {
+	if product.CategoryIDs == nil {
+		return nil
+	}
+
+	ids := make([]string, 0, len(product.CategoryIDs))
+	seen := make(map[string]bool)
+	for _, id := range product.CategoryIDs {
+		if seen[id] {
+			continue
+		}
+		if _, err := s.CategoryRepository.GetCategoryByID(id); err != nil {
+			if err == utils.ErrCategoryNotFound || err == utils.ErrCategoryIDRequired {
+				return utils.ErrUnknownCategory
+			}
+			return err
+		}
+		seen[id] = true
+		ids = append(ids, id)
+	}
 
-	_, err = s.Repository.DeleteProduct(c, id)
-	return err
+	product.CategoryIDs = ids
+	return nil
 }
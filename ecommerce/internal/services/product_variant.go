@@ -0,0 +1,189 @@
+package services
+
+import (
+	"strings"
+
+	"github.com/YugenDev/global-mobility-test/internal/models"
+	"github.com/YugenDev/global-mobility-test/internal/utils"
+	"github.com/labstack/echo/v4"
+)
+
+func (s *ProductService) AddVariant(c echo.Context, id string, version int, variant models.Variant) (models.Product, error) {
+	return s.changeVariants(c, id, version, func(variants []models.Variant) ([]models.Variant, error) {
+		return append(variants, variant), nil
+	})
+}
+
+// UpdateVariant merges the non-zero fields of patch into the variant. The
+// SKU identifies the variant and cannot be changed.
+func (s *ProductService) UpdateVariant(c echo.Context, id string, sku string, version int, patch models.Variant) (models.Product, error) {
+	if patch.SKU != "" && patch.SKU != sku {
+		return models.Product{}, utils.ErrVariantSKUCannotBeChanged
+	}
+
+	return s.changeVariants(c, id, version, func(variants []models.Variant) ([]models.Variant, error) {
+		index := variantIndex(variants, sku)
+		if index < 0 {
+			return nil, utils.ErrVariantNotFound
+		}
+
+		if patch.Options != nil {
+			variants[index].Options = patch.Options
+		}
+		if patch.Price != nil {
+			variants[index].Price = patch.Price
+		}
+		if patch.Stock != 0 {
+			variants[index].Stock = patch.Stock
+		}
+		return variants, nil
+	})
+}
+
+func (s *ProductService) RemoveVariant(c echo.Context, id string, sku string, version int) (models.Product, error) {
+	return s.changeVariants(c, id, version, func(variants []models.Variant) ([]models.Variant, error) {
+		index := variantIndex(variants, sku)
+		if index < 0 {
+			return nil, utils.ErrVariantNotFound
+		}
+		return append(variants[:index], variants[index+1:]...), nil
+	})
+}
+
+func (s *ProductService) AdjustVariantStock(c echo.Context, id string, sku string, delta int) (models.Product, error) {
+	if id == "" {
+		return models.Product{}, utils.ErrProductIDRequired
+	}
+	if sku == "" {
+		return models.Product{}, utils.ErrVariantSKURequired
+	}
+	if delta == 0 {
+		return models.Product{}, utils.ErrInvalidStockDelta
+	}
+
+	product, err := s.Repository.AdjustVariantStock(c, id, sku, delta)
+	if err != nil {
+		return models.Product{}, err
+	}
+
+	before := product
+	before.Stock -= delta
+	before.Variants = models.CloneVariants(product.Variants)
+	before.Variants[variantIndex(before.Variants, sku)].Stock -= delta
+	s.recordAudit(c, models.AuditActionStock, before, product)
+
+	return product, nil
+}
+
+// changeVariants applies change to a copy of the product's variants, checks
+// the result and writes it back if the product is still at the version it
+// was read at. A non-zero version must also match the stored one.
+func (s *ProductService) changeVariants(c echo.Context, id string, version int, change func([]models.Variant) ([]models.Variant, error)) (models.Product, error) {
+	if id == "" {
+		return models.Product{}, utils.ErrProductIDRequired
+	}
+
+	product, err := s.Repository.GetProductByID(id)
+	if err != nil {
+		return models.Product{}, err
+	}
+	if version != 0 && version != product.Version {
+		return models.Product{}, utils.ErrProductVersionMismatch
+	}
+
+	variants, err := change(models.CloneVariants(product.Variants))
+	if err != nil {
+		return models.Product{}, err
+	}
+	if err := validateVariants(variants, product.Price.Currency); err != nil {
+		return models.Product{}, err
+	}
+
+	result, err := s.Repository.UpdateVariants(c, id, product.Version, variants)
+	if err != nil {
+		return models.Product{}, err
+	}
+	if result.MatchedCount == 0 {
+		return models.Product{}, utils.ErrProductVersionMismatch
+	}
+
+	updated, err := s.Repository.GetProductByID(id)
+	if err != nil {
+		return models.Product{}, err
+	}
+
+	s.recordAudit(c, models.AuditActionUpdate, product, updated)
+
+	return updated, nil
+}
+
+// validateVariants checks each variant on its own and that no two variants
+// share a SKU or the same option values. Price overrides must be in currency,
+// the product's currency.
+func validateVariants(variants []models.Variant, currency string) error {
+	skus := make(map[string]bool)
+	options := make(map[string]bool)
+	for i := range variants {
+		variant := &variants[i]
+		variant.SKU = strings.TrimSpace(variant.SKU)
+		if variant.SKU == "" {
+			return utils.ErrVariantSKURequired
+		}
+		if len(variant.Options) == 0 {
+			return utils.ErrVariantOptionsRequired
+		}
+		if variant.Price != nil {
+			if variant.Price.Amount <= 0 {
+				return utils.ErrVariantPriceInvalid
+			}
+			if variant.Price.Currency != currency {
+				return utils.ErrCurrencyMismatch
+			}
+		}
+		if variant.Stock < 0 {
+			return utils.ErrVariantStockInvalid
+		}
+
+		if skus[variant.SKU] {
+			return utils.ErrVariantSKUExists
+		}
+		skus[variant.SKU] = true
+
+		key := variant.OptionsKey()
+		if options[key] {
+			return utils.ErrDuplicateVariantOptions
+		}
+		options[key] = true
+	}
+	return nil
+}
+
+func variantIndex(variants []models.Variant, sku string) int {
+	for i, variant := range variants {
+		if variant.SKU == sku {
+			return i
+		}
+	}
+	return -1
+}
+
+// productOffer resolves the price and the units available for sale of the
+// product, or of its variant sku. Products with variants are only sold by
+// variant.
+func productOffer(product models.Product, sku string) (models.Money, int, error) {
+	if len(product.Variants) == 0 {
+		if sku != "" {
+			return models.Money{}, 0, utils.ErrVariantNotFound
+		}
+		return product.Price, product.Available(), nil
+	}
+
+	if sku == "" {
+		return models.Money{}, 0, utils.ErrVariantRequired
+	}
+	variant, ok := product.Variant(sku)
+	if !ok {
+		return models.Money{}, 0, utils.ErrVariantNotFound
+	}
+	return variant.EffectivePrice(product.Price), min(variant.Stock, product.Available()), nil
+}
@@ -0,0 +1,47 @@
+package services
+
+import (
+	"time"
+
+	"github.com/YugenDev/global-mobility-test/internal/models"
+	"github.com/YugenDev/global-mobility-test/internal/repositories"
+)
+
+type IRateLimiter interface {
+	Allow(client string, route string) (models.RateLimitResult, error)
+}
+
+type RateLimiter struct {
+	Store   repositories.IRateLimitStore
+	Default models.RateLimit
+	Routes  map[string]models.RateLimit
+}
+
+var _ IRateLimiter = (*RateLimiter)(nil)
+
+func NewRateLimiter(store repositories.IRateLimitStore, limit models.RateLimit, routes map[string]models.RateLimit) *RateLimiter {
+	return &RateLimiter{
+		Store:   store,
+		Default: limit,
+		Routes:  routes,
+	}
+}
+
+// Allow takes a token from client's bucket for route. Routes with their own
+// limit have their own bucket; every other route shares the default one.
+// Unlimited routes are always allowed and report a Limit of 0.
+func (l *RateLimiter) Allow(client string, route string) (models.RateLimitResult, error) {
+	key := client
+	limit, ok := l.Routes[route]
+	if ok {
+		key = route + "|" + client
+	} else {
+		limit = l.Default
+	}
+
+	if limit.Unlimited() {
+		return models.RateLimitResult{Allowed: true}, nil
+	}
+
+	return l.Store.Take(key, limit, time.Now())
+}
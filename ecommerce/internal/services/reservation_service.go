@@ -0,0 +1,205 @@
+package services
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/YugenDev/global-mobility-test/internal/models"
+	"github.com/YugenDev/global-mobility-test/internal/repositories"
+	"github.com/YugenDev/global-mobility-test/internal/utils"
+	"github.com/labstack/echo/v4"
+)
+
+type IReservationService interface {
+	Reserve(c echo.Context, productID string, quantity int) (models.Reservation, error)
+	GetByID(c echo.Context, id string) (models.Reservation, error)
+	Confirm(c echo.Context, id string) (models.Reservation, error)
+	Cancel(c echo.Context, id string) (models.Reservation, error)
+	ReleaseExpired(now time.Time) (int, error)
+}
+
+const expiredReservationBatchSize = 100
+
+type ReservationService struct {
+	ProductRepository repositories.IProductRepository
+	Repository        repositories.IReservationRepository
+	TTL               time.Duration
+}
+
+var _ IReservationService = (*ReservationService)(nil)
+
+func NewReservationService(productRepo repositories.IProductRepository, repo repositories.IReservationRepository, ttl time.Duration) *ReservationService {
+	return &ReservationService{
+		ProductRepository: productRepo,
+		Repository:        repo,
+		TTL:               ttl,
+	}
+}
+
+func (s *ReservationService) Reserve(c echo.Context, productID string, quantity int) (models.Reservation, error) {
+	if productID == "" {
+		return models.Reservation{}, utils.ErrProductIDRequired
+	}
+	if quantity <= 0 {
+		return models.Reservation{}, utils.ErrInvalidReservationQuantity
+	}
+
+	if _, err := s.ProductRepository.ReserveStock(c, productID, quantity); err != nil {
+		return models.Reservation{}, err
+	}
+
+	now := time.Now()
+	reservation := models.Reservation{
+		ReservationID: utils.GenerateUniqueID(),
+		ProductID:     productID,
+		OwnerID:       reservationOwner(c),
+		Quantity:      quantity,
+		Status:        models.ReservationStatusActive,
+		ExpiresAt:     now.Add(s.TTL),
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+
+	if err := s.Repository.CreateReservation(&reservation); err != nil {
+		if _, releaseErr := s.ProductRepository.ReleaseStock(c, productID, quantity); releaseErr != nil {
+			log.Println("Error releasing stock for failed reservation: ", releaseErr)
+		}
+		return models.Reservation{}, err
+	}
+
+	return reservation, nil
+}
+
+// GetByID returns the reservation if it belongs to the caller. Other
+// callers' reservations are reported as not found.
+func (s *ReservationService) GetByID(c echo.Context, id string) (models.Reservation, error) {
+	if id == "" {
+		return models.Reservation{}, utils.ErrReservationIDRequired
+	}
+	reservation, err := s.Repository.GetReservationByID(id)
+	if err != nil {
+		return models.Reservation{}, err
+	}
+	if reservation.OwnerID != reservationOwner(c) {
+		return models.Reservation{}, utils.ErrReservationNotFound
+	}
+	return reservation, nil
+}
+
+func (s *ReservationService) Confirm(c echo.Context, id string) (models.Reservation, error) {
+	reservation, err := s.GetByID(c, id)
+	if err != nil {
+		return models.Reservation{}, err
+	}
+	if reservation.Status != models.ReservationStatusActive {
+		return models.Reservation{}, utils.ErrReservationNotActive
+	}
+	if !reservation.ExpiresAt.After(time.Now()) {
+		return models.Reservation{}, utils.ErrReservationExpired
+	}
+
+	if err := s.transition(id, models.ReservationStatusConfirmed); err != nil {
+		return models.Reservation{}, err
+	}
+
+	if _, err := s.ProductRepository.CommitReservedStock(c, reservation.ProductID, reservation.Quantity); err != nil {
+		// Hand the reservation back to the sweeper, which releases its units
+		// once it expires.
+		if _, rollbackErr := s.Repository.TransitionReservation(id, models.ReservationStatusConfirmed, models.ReservationStatusActive); rollbackErr != nil {
+			log.Println("Error reactivating reservation after failed commit: ", rollbackErr)
+		}
+		return models.Reservation{}, err
+	}
+
+	reservation.Status = models.ReservationStatusConfirmed
+	return reservation, nil
+}
+
+func (s *ReservationService) Cancel(c echo.Context, id string) (models.Reservation, error) {
+	reservation, err := s.GetByID(c, id)
+	if err != nil {
+		return models.Reservation{}, err
+	}
+
+	if err := s.transition(id, models.ReservationStatusCancelled); err != nil {
+		return models.Reservation{}, err
+	}
+
+	if _, err := s.ProductRepository.ReleaseStock(c, reservation.ProductID, reservation.Quantity); err != nil {
+		return models.Reservation{}, err
+	}
+
+	reservation.Status = models.ReservationStatusCancelled
+	return reservation, nil
+}
+
+// ReleaseExpired marks every reservation that expired by now as expired and
+// gives its units back. It returns how many reservations were released.
+func (s *ReservationService) ReleaseExpired(now time.Time) (int, error) {
+	var released int
+	for {
+		reservations, err := s.Repository.GetExpiredReservations(now, expiredReservationBatchSize)
+		if err != nil {
+			return released, err
+		}
+
+		for _, reservation := range reservations {
+			result, err := s.Repository.TransitionReservation(reservation.ReservationID, models.ReservationStatusActive, models.ReservationStatusExpired)
+			if err != nil {
+				return released, err
+			}
+			if result.MatchedCount == 0 {
+				continue
+			}
+
+			if _, err := s.ProductRepository.ReleaseStock(nil, reservation.ProductID, reservation.Quantity); err != nil {
+				return released, err
+			}
+			released++
+		}
+
+		if len(reservations) < expiredReservationBatchSize {
+			return released, nil
+		}
+	}
+}
+
+// RunSweeper releases expired reservations every interval until ctx is done.
+func (s *ReservationService) RunSweeper(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			released, err := s.ReleaseExpired(now)
+			if err != nil {
+				log.Println("Error releasing expired reservations: ", err)
+			}
+			if released > 0 {
+				log.Printf("Released %d expired reservations", released)
+			}
+		}
+	}
+}
+
+// reservationOwner is the principal of the caller, or empty when the
+// reservation is made without one, e.g. by a job.
+func reservationOwner(c echo.Context) string {
+	identity, _ := IdentityFromContext(c)
+	return identity.Principal()
+}
+
+func (s *ReservationService) transition(id string, to string) error {
+	result, err := s.Repository.TransitionReservation(id, models.ReservationStatusActive, to)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return utils.ErrReservationNotActive
+	}
+	return nil
+}
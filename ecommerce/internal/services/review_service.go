@@ -0,0 +1,160 @@
+package services
+
+import (
+	"log"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/YugenDev/global-mobility-test/internal/models"
+	"github.com/YugenDev/global-mobility-test/internal/repositories"
+	"github.com/YugenDev/global-mobility-test/internal/utils"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type IReviewService interface {
+	Create(productID string, review *models.Review) error
+	GetReviews(query models.ReviewQuery) (models.ReviewPage, error)
+	Moderate(id string, status string) (models.Review, error)
+}
+
+const maxReviewTextLength = 5000
+
+type ReviewService struct {
+	Repository        repositories.IReviewRepository
+	ProductRepository repositories.IProductRepository
+}
+
+var _ IReviewService = (*ReviewService)(nil)
+
+func NewReviewService(repo repositories.IReviewRepository, productRepo repositories.IProductRepository) *ReviewService {
+	return &ReviewService{
+		Repository:        repo,
+		ProductRepository: productRepo,
+	}
+}
+
+// Create adds a pending review to the product. It does not count towards the
+// product's rating until it is approved.
+func (s *ReviewService) Create(productID string, review *models.Review) error {
+	if productID == "" {
+		return utils.ErrProductIDRequired
+	}
+	if review.Rating < 1 || review.Rating > 5 {
+		return utils.ErrInvalidReviewRating
+	}
+	review.Text = strings.TrimSpace(review.Text)
+	if review.Text == "" {
+		return utils.ErrReviewTextRequired
+	}
+	if utf8.RuneCountInString(review.Text) > maxReviewTextLength {
+		return utils.ErrReviewTextTooLong
+	}
+
+	if _, err := s.ProductRepository.GetProductByID(productID); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return utils.ErrNoProductsFound
+		}
+		return err
+	}
+
+	now := time.Now()
+	review.ReviewID = utils.GenerateUniqueID()
+	review.ProductID = productID
+	review.Author = strings.TrimSpace(review.Author)
+	review.Status = models.ReviewStatusPending
+	review.CreatedAt = now
+	review.UpdatedAt = now
+
+	return s.Repository.CreateReview(review)
+}
+
+func (s *ReviewService) GetReviews(query models.ReviewQuery) (models.ReviewPage, error) {
+	if query.Status != "" && !models.ReviewStatuses[query.Status] {
+		return models.ReviewPage{}, utils.ErrInvalidReviewStatus
+	}
+	if query.Limit == 0 {
+		query.Limit = DefaultPageLimit
+	}
+	if query.Limit < 0 || query.Limit > MaxPageLimit {
+		return models.ReviewPage{}, utils.ErrInvalidPageLimit
+	}
+
+	if query.Cursor != "" {
+		var after models.ReviewCursor
+		if err := utils.DecodeCursor(query.Cursor, &after); err != nil {
+			return models.ReviewPage{}, err
+		}
+		query.After = &after
+	}
+
+	limit := query.Limit
+	query.Limit = limit + 1
+
+	reviews, err := s.Repository.GetReviews(query)
+	if err != nil {
+		return models.ReviewPage{}, err
+	}
+
+	page := models.ReviewPage{Reviews: reviews}
+	if page.Reviews == nil {
+		page.Reviews = []models.Review{}
+	}
+	if len(reviews) > limit {
+		page.Reviews = reviews[:limit]
+		page.HasMore = true
+
+		last := page.Reviews[limit-1]
+		page.NextCursor = utils.EncodeCursor(models.ReviewCursor{
+			CreatedAt: last.CreatedAt,
+			ReviewID:  last.ReviewID,
+		})
+	}
+
+	return page, nil
+}
+
+// Moderate approves or rejects a review, moving its rating into or out of
+// the product's aggregate. The status change is claimed first so that a
+// review is counted once however many moderators act on it, and is undone
+// if the product's rating cannot be updated.
+func (s *ReviewService) Moderate(id string, status string) (models.Review, error) {
+	if status != models.ReviewStatusApproved && status != models.ReviewStatusRejected {
+		return models.Review{}, utils.ErrInvalidReviewStatus
+	}
+
+	review, err := s.Repository.GetReviewByID(id)
+	if err != nil {
+		return models.Review{}, err
+	}
+	if review.Status == status {
+		return review, nil
+	}
+
+	result, err := s.Repository.TransitionReview(id, review.Status, status)
+	if err != nil {
+		return models.Review{}, err
+	}
+	if result.MatchedCount == 0 {
+		return models.Review{}, utils.ErrReviewStatusChanged
+	}
+
+	count := 0
+	if status == models.ReviewStatusApproved {
+		count = 1
+	} else if review.Status == models.ReviewStatusApproved {
+		count = -1
+	}
+	if count != 0 {
+		if _, err := s.ProductRepository.AdjustRating(review.ProductID, count, count*review.Rating); err != nil {
+			if _, rollbackErr := s.Repository.TransitionReview(id, status, review.Status); rollbackErr != nil {
+				log.Println("Error rolling back review status: ", rollbackErr)
+			}
+			return models.Review{}, err
+		}
+	}
+
+	review.Status = status
+	review.UpdatedAt = time.Now()
+	return review, nil
+}
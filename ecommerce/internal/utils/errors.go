@@ -7,6 +7,7 @@ var (
 	ErrProductDescriptionRequired = errors.New("product description is required")
 	ErrProductPriceInvalid        = errors.New("product price must be greater than zero")
 	ErrProductStockInvalid        = errors.New("product stock cannot be negative")
+	ErrStockBelowReserved         = errors.New("product stock cannot be set below the units reserved")
 	ErrProductIDRequired          = errors.New("product ID is required")
 	ErrNoProductsFound            = errors.New("no products found")
 	ErrInternalServer             = errors.New("internal server error")
@@ -15,4 +16,137 @@ var (
 	ErrProductIDCannotBeChanged   = errors.New("product ID cannot be changed")
 	ErrDatabaseNotInitialized     = errors.New("database collection not initialized")
 	ErrNullProductData            = errors.New("product data cannot be nil")
+	ErrInvalidPageLimit           = errors.New("limit must be between 1 and 100")
+	ErrInvalidCursor              = errors.New("invalid pagination cursor")
+	ErrUnknownFilterField         = errors.New("unknown filter field")
+	ErrInvalidFilterValue         = errors.New("invalid filter value")
+	ErrInvalidSortField           = errors.New("invalid sort field")
+	ErrInvalidPriceRange          = errors.New("min_price cannot be greater than max_price")
+	ErrSearchQueryRequired        = errors.New("search query is required")
+	ErrProductVersionMismatch     = errors.New("product has been modified by another request")
+	ErrProductNotInTrash          = errors.New("product not found in trash")
+	ErrInvalidTrashRetention      = errors.New("trash retention must be a positive duration")
+	ErrBulkOperationsRequired     = errors.New("at least one bulk operation is required")
+	ErrTooManyBulkOperations      = errors.New("bulk request cannot exceed 500 operations")
+	ErrInvalidBulkOperation       = errors.New("op must be one of create, update or delete")
+	ErrDuplicateBulkProduct       = errors.New("product appears more than once in the bulk request")
+	ErrBulkOperationSkipped       = errors.New("operation skipped after an earlier write failed")
+	ErrInvalidStockDelta          = errors.New("delta must be a non-zero integer")
+	ErrInsufficientStock          = errors.New("insufficient stock")
+	ErrReservationIDRequired      = errors.New("reservation ID is required")
+	ErrReservationNotFound        = errors.New("reservation not found")
+	ErrReservationNotActive       = errors.New("reservation is no longer active")
+	ErrReservationExpired         = errors.New("reservation has expired")
+	ErrInvalidReservationQuantity = errors.New("quantity must be greater than zero")
+	ErrInvalidReservationDuration = errors.New("reservation durations must be positive")
+	ErrCartIDRequired             = errors.New("cart ID is required")
+	ErrCartNotFound               = errors.New("cart not found")
+	ErrCartItemNotFound           = errors.New("product is not in the cart")
+	ErrInvalidCartQuantity        = errors.New("quantity must be greater than zero")
+	ErrCartVersionMismatch        = errors.New("cart has been modified by another request")
+	ErrInvalidCartTTL             = errors.New("cart TTL must be a positive duration")
+	ErrInvalidCheckoutRequest     = errors.New("provide either cart_id or items")
+	ErrEmptyOrder                 = errors.New("order must contain at least one item")
+	ErrOrderIDRequired            = errors.New("order ID is required")
+	ErrOrderNotFound              = errors.New("order not found")
+	ErrInvalidOrderStatus         = errors.New("unknown order status")
+	ErrInvalidOrderTransition     = errors.New("order cannot move to the requested status")
+	ErrCategoryIDRequired         = errors.New("category ID is required")
+	ErrCategoryNotFound           = errors.New("category not found")
+	ErrCategoryNameRequired       = errors.New("category name is required")
+	ErrInvalidCategorySlug        = errors.New("slug may only contain lowercase letters, digits and hyphens")
+	ErrCategorySlugExists         = errors.New("category slug already exists")
+	ErrParentCategoryNotFound     = errors.New("parent category not found")
+	ErrCategoryCycle              = errors.New("category cannot be moved under itself or its descendants")
+	ErrCategoryHasChildren        = errors.New("category still has child categories")
+	ErrCategoryHasProducts        = errors.New("category still has products; pass reassign_to to move them")
+	ErrInvalidReassignCategory    = errors.New("reassign_to must be a different, existing category")
+	ErrUnknownCategory            = errors.New("unknown category ID")
+	ErrVariantSKURequired         = errors.New("variant SKU is required")
+	ErrVariantSKUExists           = errors.New("variant SKU already exists on this product")
+	ErrVariantSKUCannotBeChanged  = errors.New("variant SKU cannot be changed")
+	ErrVariantOptionsRequired     = errors.New("variant options are required")
+	ErrDuplicateVariantOptions    = errors.New("another variant already has these options")
+	ErrVariantPriceInvalid        = errors.New("variant price must be positive")
+	ErrVariantStockInvalid        = errors.New("variant stock cannot be negative")
+	ErrVariantNotFound            = errors.New("variant not found")
+	ErrVariantRequired            = errors.New("a variant SKU is required for this product")
+	ErrVariantsNotEditable        = errors.New("variants are changed through the variant endpoints")
+	ErrProductHasVariants         = errors.New("product stock is managed per variant")
+	ErrImageRequired              = errors.New("an image file is required")
+	ErrImageTooLarge              = errors.New("image exceeds the maximum upload size")
+	ErrUnsupportedImageType       = errors.New("image must be a JPEG, PNG, GIF or WebP file")
+	ErrImageNotFound              = errors.New("image not found")
+	ErrTooManyImages              = errors.New("product already has the maximum number of images")
+	ErrInvalidImageOrder          = errors.New("image_ids must list every image of the product exactly once")
+	ErrImagesNotEditable          = errors.New("images are changed through the image endpoints")
+	ErrInvalidMaxImageSize        = errors.New("maximum image size must be positive")
+	ErrBlobNotFound               = errors.New("blob not found")
+	ErrInvalidBlobKey             = errors.New("invalid blob key")
+	ErrInvalidMoneyAmount         = errors.New("amount must be a decimal number with no more places than the currency allows")
+	ErrUnsupportedCurrency        = errors.New("unsupported currency")
+	ErrCurrencyMismatch           = errors.New("all prices must be in the same currency")
+	ErrExchangeRateNotFound       = errors.New("no exchange rate for the requested currency")
+	ErrInvalidExchangeRate        = errors.New("exchange rates must be positive numbers for currencies other than the base")
+	ErrInvalidRoundingRule        = errors.New("rounding mode must be half_up, half_even, down or up, with a non-negative increment")
+	ErrInvalidPriceOverride       = errors.New("price overrides must be positive and list each currency other than the product's once")
+	ErrPriceScheduleIDRequired    = errors.New("price schedule ID is required")
+	ErrPriceScheduleNotFound      = errors.New("price schedule not found")
+	ErrInvalidPriceSchedule       = errors.New("starts_at is required and ends_at must be after starts_at and in the future")
+	ErrPriceScheduleOverlap       = errors.New("price schedule overlaps another schedule for this product")
+	ErrPriceScheduleFinished      = errors.New("price schedule has already ended or been cancelled")
+	ErrInvalidScheduleInterval    = errors.New("price schedule interval must be positive")
+	ErrCouponIDRequired           = errors.New("coupon ID is required")
+	ErrCouponCodeRequired         = errors.New("coupon code is required")
+	ErrInvalidCouponCode          = errors.New("coupon code must be 3 to 32 letters, digits, hyphens or underscores")
+	ErrCouponCodeExists           = errors.New("coupon code already exists")
+	ErrCouponNotFound             = errors.New("coupon not found")
+	ErrInvalidCouponType          = errors.New("coupon type must be percentage, fixed or buy_x_get_y")
+	ErrInvalidCouponDiscount      = errors.New("percentage coupons need a percent between 1 and 100, fixed coupons a positive amount, and buy_x_get_y coupons positive buy and get quantities")
+	ErrInvalidCouponRule          = errors.New("minimum subtotal must be positive and usage limit cannot be negative")
+	ErrInvalidCouponWindow        = errors.New("coupon ends_at must be after starts_at")
+	ErrCouponNotActive            = errors.New("coupon is not valid yet")
+	ErrCouponExpired              = errors.New("coupon has expired")
+	ErrCouponUsageLimitReached    = errors.New("coupon has reached its usage limit")
+	ErrCouponMinimumNotMet        = errors.New("subtotal is below the coupon's minimum")
+	ErrCouponNotApplicable        = errors.New("coupon does not apply to any of the items")
+	ErrReviewIDRequired           = errors.New("review ID is required")
+	ErrReviewNotFound             = errors.New("review not found")
+	ErrInvalidReviewRating        = errors.New("review rating must be between 1 and 5")
+	ErrReviewTextRequired         = errors.New("review text is required")
+	ErrReviewTextTooLong          = errors.New("review text must be at most 5000 characters")
+	ErrInvalidReviewStatus        = errors.New("review status must be pending, approved or rejected")
+	ErrReviewStatusChanged        = errors.New("review was moderated by another request")
+	ErrUserIDRequired             = errors.New("user ID is required")
+	ErrUserNotFound               = errors.New("user not found")
+	ErrInvalidEmail               = errors.New("a valid email address is required")
+	ErrInvalidPassword            = errors.New("password must be 8 to 72 bytes long")
+	ErrEmailTaken                 = errors.New("email is already registered")
+	ErrAdminEmailTaken            = errors.New("the admin email belongs to an account that is not an admin")
+	ErrInvalidCredentials         = errors.New("invalid email or password")
+	ErrInvalidToken               = errors.New("invalid or expired token")
+	ErrAuthenticationRequired     = errors.New("authentication required")
+	ErrJWTSecretRequired          = errors.New("JWT secret must be at least 32 bytes")
+	ErrInvalidTokenTTL            = errors.New("token TTL must be positive")
+	ErrPermissionDenied           = errors.New("missing permission")
+	ErrInvalidRole                = errors.New("role must be admin, catalog_editor or viewer")
+	ErrAPIKeyIDRequired           = errors.New("API key ID is required")
+	ErrAPIKeyNotFound             = errors.New("API key not found or already revoked")
+	ErrAPIKeyNameRequired         = errors.New("API key name is required")
+	ErrInvalidAPIKeyScope         = errors.New("API key scopes must be one or more of products:write and stock:adjust")
+	ErrInvalidAPIKeyExpiry        = errors.New("API key expires_at must be in the future")
+	ErrInvalidAPIKey              = errors.New("invalid, expired or revoked API key")
+	ErrMultipleCredentials        = errors.New("send either a bearer token or an API key, not both")
+	ErrInvalidRateLimit           = errors.New("rate limit must be requests/period, such as 120/1m, or off")
+	ErrRateLimitExceeded          = errors.New("too many requests")
+	ErrInvalidTrustedProxy        = errors.New("trusted proxies must be comma-separated IP addresses or CIDR ranges")
+	ErrInvalidIdempotencyKey      = errors.New("Idempotency-Key must be 1 to 255 characters")
+	ErrIdempotencyKeyReused       = errors.New("Idempotency-Key was already used for a different request")
+	ErrIdempotencyKeyInProgress   = errors.New("a request with this Idempotency-Key is still in progress")
+	ErrIdempotencyKeyExists       = errors.New("Idempotency-Key already exists")
+	ErrIdempotencyRecordNotFound  = errors.New("idempotency record not found")
+	ErrIdempotencyClaimLost       = errors.New("idempotency key was released or expired before the response was stored")
+	ErrInvalidIdempotencyTTL      = errors.New("idempotency TTL must be a positive duration")
+	ErrInvalidIdempotencyBodySize = errors.New("idempotency body size must be a positive number of bytes")
+	ErrIdempotentBodyTooLarge     = errors.New("request body is too large to be retried with an Idempotency-Key")
 )
@@ -0,0 +1,41 @@
+package utils
+
+import (
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	HeaderActor        = "X-Actor"
+	HeaderAPIKey       = "X-API-Key"
+	HeaderIdempotency  = "Idempotency-Key"
+	ContextKeyActor    = "actor"
+	ContextKeyIdentity = "identity"
+	AnonymousActor     = "anonymous"
+)
+
+// ActorFromContext identifies who is making the request: an identity stored
+// on the context takes precedence over the X-Actor header.
+func ActorFromContext(c echo.Context) string {
+	if c == nil {
+		return AnonymousActor
+	}
+	if actor, ok := c.Get(ContextKeyActor).(string); ok && actor != "" {
+		return actor
+	}
+	if c.Request() != nil {
+		if actor := c.Request().Header.Get(HeaderActor); actor != "" {
+			return actor
+		}
+	}
+	return AnonymousActor
+}
+
+func RequestIDFromContext(c echo.Context) string {
+	if c == nil || c.Request() == nil {
+		return ""
+	}
+	if id := c.Response().Header().Get(echo.HeaderXRequestID); id != "" {
+		return id
+	}
+	return c.Request().Header.Get(echo.HeaderXRequestID)
+}
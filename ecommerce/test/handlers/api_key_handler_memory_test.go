@@ -0,0 +1,64 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/YugenDev/global-mobility-test/internal/handlers"
+	"github.com/YugenDev/global-mobility-test/internal/models"
+	"github.com/YugenDev/global-mobility-test/internal/utils"
+	"github.com/YugenDev/global-mobility-test/test/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryStoreAPIKeys(t *testing.T) {
+	store := testutil.NewMemoryStore(t)
+	e := store.Server(handlers.Authenticate(store.Auth, store.APIKeys, testutil.Unlimited()))
+
+	store.Auth.EnsureAdmin("root@example.com", "super secret")
+	rec := e.Send(http.MethodPost, "/auth/login", `{"email":"root@example.com","password":"super secret"}`)
+	var tokens models.TokenPair
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &tokens))
+	admin := testutil.Bearer(tokens.AccessToken)
+
+	assert.Equal(t, http.StatusUnauthorized, e.Send(http.MethodGet, "/admin/api-keys", "").Code)
+	assert.Equal(t, http.StatusBadRequest, e.Send(http.MethodPost, "/admin/api-keys", `{"name":"warehouse","scopes":["users:manage"]}`, admin...).Code)
+	assert.Equal(t, http.StatusBadRequest, e.Send(http.MethodPost, "/admin/api-keys", `{"name":"warehouse","scopes":["stock:adjust"],"expires_at":"2000-01-01T00:00:00Z"}`, admin...).Code)
+
+	rec = e.Send(http.MethodPost, "/admin/api-keys", `{"name":"warehouse","scopes":["stock:adjust"]}`, admin...)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.NotContains(t, rec.Body.String(), "key_hash")
+	var warehouse models.CreatedAPIKey
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &warehouse))
+	assert.True(t, strings.HasPrefix(warehouse.Key, warehouse.Prefix))
+	assert.Equal(t, "root@example.com", warehouse.CreatedBy)
+	key := []string{utils.HeaderAPIKey, warehouse.Key}
+
+	product := `{"product_id":"1","name":"Shoes","description":"Running","price":25,"stock":2}`
+	assert.Equal(t, http.StatusCreated, e.Send(http.MethodPost, "/products", product, admin...).Code)
+
+	rec = e.Send(http.MethodPost, "/products", `{"product_id":"2","name":"Hat","description":"Wool","price":10,"stock":1}`, key...)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"permission":"products:write"`)
+	assert.Equal(t, http.StatusForbidden, e.Send(http.MethodGet, "/admin/api-keys", "", key...).Code)
+	assert.Equal(t, http.StatusOK, e.Send(http.MethodPost, "/products/1/stock/adjust", `{"delta":3}`, key...).Code)
+	assert.Equal(t, http.StatusUnauthorized, e.Send(http.MethodPost, "/products/1/stock/adjust", `{"delta":3}`, []string{utils.HeaderAPIKey, "ek_unknown"}...).Code)
+	assert.Equal(t, http.StatusUnauthorized, e.Send(http.MethodGet, "/products", "", append([]string{utils.HeaderAPIKey, warehouse.Key}, admin...)...).Code)
+
+	rec = e.Send(http.MethodGet, "/products/1/history", "")
+	assert.Contains(t, rec.Body.String(), `"actor":"api_key:`+warehouse.KeyID+`"`)
+
+	rec = e.Send(http.MethodGet, "/admin/api-keys", "", admin...)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var keys []models.APIKey
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &keys))
+	if assert.Len(t, keys, 1) {
+		assert.NotNil(t, keys[0].LastUsedAt)
+	}
+
+	assert.Equal(t, http.StatusNoContent, e.Send(http.MethodDelete, "/admin/api-keys/"+warehouse.KeyID, "", admin...).Code)
+	assert.Equal(t, http.StatusNotFound, e.Send(http.MethodDelete, "/admin/api-keys/"+warehouse.KeyID, "", admin...).Code)
+	assert.Equal(t, http.StatusUnauthorized, e.Send(http.MethodPost, "/products/1/stock/adjust", `{"delta":1}`, key...).Code)
+}
@@ -0,0 +1,132 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"slices"
+	"testing"
+
+	"github.com/YugenDev/global-mobility-test/internal/handlers"
+	"github.com/YugenDev/global-mobility-test/internal/models"
+	"github.com/YugenDev/global-mobility-test/test/testutil"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryStoreAuthentication(t *testing.T) {
+	store := testutil.NewMemoryStore(t)
+	e := store.Server(handlers.Authenticate(store.Auth, store.APIKeys, testutil.Unlimited()))
+
+	product := `{"product_id":"1","name":"Shoes","description":"Running","price":25,"stock":2}`
+	rec := e.Send(http.MethodPost, "/products", product)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Equal(t, "Bearer", rec.Header().Get(echo.HeaderWWWAuthenticate))
+
+	rec = e.Send(http.MethodPost, "/auth/register", `{"email":"Ada@Example.com","name":"Ada","password":"correct horse"}`)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.NotContains(t, rec.Body.String(), "password")
+	assert.Equal(t, http.StatusConflict, e.Send(http.MethodPost, "/auth/register", `{"email":"ada@example.com","password":"another one"}`).Code)
+	assert.Equal(t, http.StatusBadRequest, e.Send(http.MethodPost, "/auth/register", `{"email":"grace@example.com","password":"short"}`).Code)
+
+	assert.Equal(t, http.StatusUnauthorized, e.Send(http.MethodPost, "/auth/login", `{"email":"ada@example.com","password":"wrong password"}`).Code)
+	assert.Equal(t, http.StatusUnauthorized, e.Send(http.MethodPost, "/auth/login", `{"email":"nobody@example.com","password":"correct horse"}`).Code)
+
+	rec = e.Send(http.MethodPost, "/auth/login", `{"email":"ada@example.com","password":"correct horse"}`)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var tokens models.TokenPair
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &tokens))
+	assert.Equal(t, 60, tokens.ExpiresIn)
+
+	rec = e.Send(http.MethodPost, "/products", product, testutil.Bearer(tokens.AccessToken)...)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"permission":"products:write"`)
+	assert.Equal(t, http.StatusUnauthorized, e.Send(http.MethodPost, "/products", product, testutil.Bearer(tokens.RefreshToken)...).Code)
+	assert.Equal(t, http.StatusUnauthorized, e.Send(http.MethodGet, "/products", "", testutil.Bearer("not-a-token")...).Code)
+
+	rec = e.Send(http.MethodGet, "/auth/me", "", testutil.Bearer(tokens.AccessToken)...)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var ada models.User
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &ada))
+	assert.Equal(t, "ada@example.com", ada.Email)
+	assert.Equal(t, []string{models.RoleViewer}, ada.Roles)
+	assert.Equal(t, http.StatusUnauthorized, e.Send(http.MethodGet, "/auth/me", "").Code)
+
+	store.Auth.EnsureAdmin("root@example.com", "super secret")
+	rec = e.Send(http.MethodPost, "/auth/login", `{"email":"root@example.com","password":"super secret"}`)
+	var admin models.TokenPair
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &admin))
+
+	assert.Equal(t, http.StatusForbidden, e.Send(http.MethodGet, "/admin/users", "", testutil.Bearer(tokens.AccessToken)...).Code)
+	rec = e.Send(http.MethodGet, "/admin/users", "", testutil.Bearer(admin.AccessToken)...)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), ada.UserID)
+	assert.Equal(t, http.StatusBadRequest, e.Send(http.MethodPut, "/admin/users/"+ada.UserID+"/roles", `{"roles":["owner"]}`, testutil.Bearer(admin.AccessToken)...).Code)
+	assert.Equal(t, http.StatusNotFound, e.Send(http.MethodPut, "/admin/users/missing/roles", `{"roles":["viewer"]}`, testutil.Bearer(admin.AccessToken)...).Code)
+	assert.Equal(t, http.StatusOK, e.Send(http.MethodPut, "/admin/users/"+ada.UserID+"/roles", `{"roles":["catalog_editor"]}`, testutil.Bearer(admin.AccessToken)...).Code)
+
+	assert.Equal(t, http.StatusUnauthorized, e.Send(http.MethodPost, "/auth/refresh", `{"refresh_token":"`+tokens.AccessToken+`"}`).Code)
+	rec = e.Send(http.MethodPost, "/auth/refresh", `{"refresh_token":"`+tokens.RefreshToken+`"}`)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &tokens))
+
+	assert.Equal(t, http.StatusCreated, e.Send(http.MethodPost, "/products", product, testutil.Bearer(tokens.AccessToken)...).Code)
+	assert.Equal(t, http.StatusOK, e.Send(http.MethodGet, "/products", "").Code)
+	assert.Equal(t, http.StatusOK, e.Send(http.MethodPost, "/products/1/stock/adjust", `{"delta":1}`, testutil.Bearer(tokens.AccessToken)...).Code)
+
+	rec = e.Send(http.MethodGet, "/products/1/history", "")
+	assert.Contains(t, rec.Body.String(), `"actor":"ada@example.com"`)
+}
+
+func TestMemoryStoreAdminRoutesRequirePermissions(t *testing.T) {
+	store := testutil.NewMemoryStore(t)
+	e := store.Server(handlers.Authenticate(store.Auth, store.APIKeys, testutil.Unlimited()))
+
+	tokenFor := func(email string, roles ...string) string {
+		user, _ := store.Auth.Register(models.RegisterRequest{Email: email, Password: "correct horse"})
+		if len(roles) > 0 {
+			store.Auth.SetRoles(user.UserID, roles)
+		}
+		tokens, _ := store.Auth.Login(models.LoginRequest{Email: email, Password: "correct horse"})
+		return tokens.AccessToken
+	}
+	viewer := tokenFor("viewer@example.com")
+	editor := tokenFor("editor@example.com", models.RoleCatalogEditor)
+
+	tests := []struct {
+		method     string
+		path       string
+		permission string
+	}{
+		{http.MethodGet, "/products/trash", models.PermissionProductsWrite},
+		{http.MethodPost, "/categories", models.PermissionProductsWrite},
+		{http.MethodPut, "/categories/1", models.PermissionProductsWrite},
+		{http.MethodDelete, "/categories/1", models.PermissionProductsWrite},
+		{http.MethodPut, "/admin/exchange-rates", models.PermissionProductsWrite},
+		{http.MethodGet, "/admin/reviews", models.PermissionReviewsModerate},
+		{http.MethodPut, "/admin/reviews/1/status", models.PermissionReviewsModerate},
+		{http.MethodPost, "/admin/coupons", models.PermissionCouponsManage},
+		{http.MethodGet, "/admin/coupons", models.PermissionCouponsManage},
+		{http.MethodGet, "/admin/coupons/1", models.PermissionCouponsManage},
+		{http.MethodPut, "/admin/coupons/1", models.PermissionCouponsManage},
+		{http.MethodDelete, "/admin/coupons/1", models.PermissionCouponsManage},
+		{http.MethodPut, "/orders/1/status", models.PermissionOrdersManage},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, http.StatusUnauthorized, e.Send(tt.method, tt.path, "{}").Code, tt.method+" "+tt.path)
+		rec := e.Send(tt.method, tt.path, "{}", testutil.Bearer(viewer)...)
+		assert.Equal(t, http.StatusForbidden, rec.Code, tt.method+" "+tt.path)
+		assert.Contains(t, rec.Body.String(), `"permission":"`+tt.permission+`"`)
+		editorAllowed := slices.Contains(models.RolePermissions[models.RoleCatalogEditor], tt.permission)
+		assert.Equal(t, editorAllowed, e.Send(tt.method, tt.path, "{}", testutil.Bearer(editor)...).Code != http.StatusForbidden, tt.method+" "+tt.path)
+	}
+}
+
+func TestMemoryStoreReservationsAndCheckoutRequireUser(t *testing.T) {
+	e := testutil.NewMemoryStore(t).Server()
+
+	for _, path := range []string{"/products/1/reservations", "/reservations/1/confirm", "/reservations/1/cancel", "/checkout"} {
+		rec := e.Send(http.MethodPost, path, `{"quantity":1}`)
+		assert.Equal(t, http.StatusUnauthorized, rec.Code, path)
+	}
+}
@@ -0,0 +1,41 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/YugenDev/global-mobility-test/internal/models"
+	"github.com/YugenDev/global-mobility-test/test/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryStoreCart(t *testing.T) {
+	e := testutil.NewMemoryServer(t)
+
+	e.Send(http.MethodPost, "/products", `{"product_id":"1","name":"Shoes","description":"Running","price":25,"stock":5}`)
+
+	rec := e.Send(http.MethodPost, "/carts", "")
+	assert.Equal(t, http.StatusCreated, rec.Code)
+
+	var cart models.Cart
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &cart))
+
+	rec = e.Send(http.MethodPost, "/carts/"+cart.CartID+"/items", `{"product_id":"1","quantity":2}`)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &cart))
+	assert.Equal(t, usd("50"), cart.Total)
+
+	assert.Equal(t, http.StatusConflict, e.Send(http.MethodPut, "/carts/"+cart.CartID+"/items/1", `{"quantity":6}`).Code)
+	assert.Equal(t, http.StatusOK, e.Send(http.MethodPut, "/carts/"+cart.CartID+"/items/1", `{"quantity":5}`).Code)
+	assert.Equal(t, http.StatusNotFound, e.Send(http.MethodPost, "/carts/"+cart.CartID+"/items", `{"product_id":"2","quantity":1}`).Code)
+	assert.Equal(t, http.StatusBadRequest, e.Send(http.MethodPost, "/carts/"+cart.CartID+"/items", `{"product_id":"1","quantity":-1}`).Code)
+
+	rec = e.Send(http.MethodDelete, "/carts/"+cart.CartID+"/items/1", "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &cart))
+	assert.Equal(t, 0, cart.ItemCount)
+
+	assert.Equal(t, http.StatusNotFound, e.Send(http.MethodDelete, "/carts/"+cart.CartID+"/items/1", "").Code)
+	assert.Equal(t, http.StatusNotFound, e.Send(http.MethodGet, "/carts/missing", "").Code)
+}
@@ -0,0 +1,60 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/YugenDev/global-mobility-test/internal/models"
+	"github.com/YugenDev/global-mobility-test/test/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryStoreCategories(t *testing.T) {
+	e := testutil.NewMemoryServer(t)
+
+	var clothing, shoes models.Category
+	rec := e.Send(http.MethodPost, "/categories", `{"name":"Clothing"}`)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &clothing))
+
+	rec = e.Send(http.MethodPost, "/categories", `{"name":"Shoes","parent_id":"`+clothing.CategoryID+`"}`)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &shoes))
+
+	assert.Equal(t, http.StatusConflict, e.Send(http.MethodPost, "/categories", `{"name":"Shoes"}`).Code)
+	assert.Equal(t, http.StatusBadRequest, e.Send(http.MethodPost, "/categories", `{"name":""}`).Code)
+
+	rec = e.Send(http.MethodGet, "/categories/tree", "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var tree []models.CategoryNode
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &tree))
+	assert.Len(t, tree, 1)
+	assert.Equal(t, shoes.CategoryID, tree[0].Children[0].CategoryID)
+
+	assert.Equal(t, http.StatusCreated, e.Send(http.MethodPost, "/products", `{"product_id":"1","name":"Boot","description":"Leather","price":90,"stock":1,"category_ids":["`+shoes.CategoryID+`"]}`).Code)
+	assert.Equal(t, http.StatusBadRequest, e.Send(http.MethodPost, "/products", `{"product_id":"2","name":"Hat","description":"Wool","price":15,"stock":1,"category_ids":["missing"]}`).Code)
+
+	rec = e.Send(http.MethodGet, "/categories/"+clothing.CategoryID+"/products?include_descendants=true", "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var page models.ProductPage
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &page))
+	assert.Len(t, page.Products, 1)
+
+	rec = e.Send(http.MethodGet, "/categories/"+clothing.CategoryID+"/products", "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &page))
+	assert.Empty(t, page.Products)
+
+	assert.Equal(t, http.StatusOK, e.Send(http.MethodGet, "/products?category_id="+shoes.CategoryID, "").Code)
+	assert.Equal(t, http.StatusBadRequest, e.Send(http.MethodGet, "/categories/"+shoes.CategoryID+"/products?include_descendants=maybe", "").Code)
+
+	assert.Equal(t, http.StatusConflict, e.Send(http.MethodDelete, "/categories/"+clothing.CategoryID, "").Code)
+	assert.Equal(t, http.StatusConflict, e.Send(http.MethodDelete, "/categories/"+shoes.CategoryID, "").Code)
+	assert.Equal(t, http.StatusNoContent, e.Send(http.MethodDelete, "/categories/"+shoes.CategoryID+"?reassign_to="+clothing.CategoryID, "").Code)
+	assert.Equal(t, http.StatusNotFound, e.Send(http.MethodGet, "/categories/"+shoes.CategoryID, "").Code)
+
+	rec = e.Send(http.MethodGet, "/categories/"+clothing.CategoryID+"/products", "")
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &page))
+	assert.Len(t, page.Products, 1)
+}
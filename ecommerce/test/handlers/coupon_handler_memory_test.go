@@ -0,0 +1,56 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/YugenDev/global-mobility-test/internal/models"
+	"github.com/YugenDev/global-mobility-test/test/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryStoreCoupons(t *testing.T) {
+	e := testutil.NewMemoryServer(t)
+
+	e.Send(http.MethodPost, "/products", `{"product_id":"1","name":"Shoes","description":"Running","price":40,"stock":5}`)
+
+	rec := e.Send(http.MethodPost, "/admin/coupons", `{"code":"spring","type":"percentage","percent":25,"usage_limit":1}`)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	var coupon models.Coupon
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &coupon))
+	assert.Equal(t, "SPRING", coupon.Code)
+
+	assert.Equal(t, http.StatusConflict, e.Send(http.MethodPost, "/admin/coupons", `{"code":"SPRING","type":"fixed","amount":5}`).Code)
+	assert.Equal(t, http.StatusBadRequest, e.Send(http.MethodPost, "/admin/coupons", `{"code":"X","type":"percentage","percent":5}`).Code)
+	assert.Equal(t, http.StatusBadRequest, e.Send(http.MethodPost, "/admin/coupons", `{"code":"FREE","type":"free"}`).Code)
+
+	rec = e.Send(http.MethodPost, "/coupons/evaluate", `{"code":"SPRING","items":[{"product_id":"1","quantity":2}]}`)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var evaluation models.CouponEvaluation
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &evaluation))
+	assert.Equal(t, usd("20"), evaluation.Discount)
+	assert.Equal(t, usd("60"), evaluation.Total)
+	assert.Equal(t, http.StatusNotFound, e.Send(http.MethodPost, "/coupons/evaluate", `{"code":"NOPE","items":[{"product_id":"1","quantity":1}]}`).Code)
+
+	rec = e.Send(http.MethodPost, "/checkout", `{"items":[{"product_id":"1","quantity":1}],"coupon_code":"SPRING"}`)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"coupon_code":"SPRING"`)
+	assert.Equal(t, http.StatusConflict, e.Send(http.MethodPost, "/checkout", `{"items":[{"product_id":"1","quantity":1}],"coupon_code":"SPRING"}`).Code)
+
+	rec = e.Send(http.MethodPut, "/admin/coupons/"+coupon.CouponID, `{"code":"SPRING","type":"fixed","amount":5}`)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	rec = e.Send(http.MethodGet, "/admin/coupons/"+coupon.CouponID, "")
+	var updated models.Coupon
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &updated))
+	assert.Equal(t, models.CouponTypeFixed, updated.Type)
+	assert.Equal(t, 0, updated.Percent)
+	assert.Equal(t, 1, updated.UsageCount)
+
+	rec = e.Send(http.MethodGet, "/admin/coupons", "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"SPRING"`)
+
+	assert.Equal(t, http.StatusNoContent, e.Send(http.MethodDelete, "/admin/coupons/"+coupon.CouponID, "").Code)
+	assert.Equal(t, http.StatusNotFound, e.Send(http.MethodGet, "/admin/coupons/"+coupon.CouponID, "").Code)
+}
@@ -0,0 +1,58 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/YugenDev/global-mobility-test/internal/models"
+	"github.com/YugenDev/global-mobility-test/test/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryStoreProductsInOtherCurrencies(t *testing.T) {
+	e := testutil.NewMemoryServer(t)
+
+	assert.Equal(t, http.StatusCreated, e.Send(http.MethodPost, "/products", `{"product_id":"1","name":"Shoes","description":"Running","price":"50","stock":5}`).Code)
+	assert.Equal(t, http.StatusCreated, e.Send(http.MethodPost, "/products", `{"product_id":"2","name":"Hat","description":"Warm","price":"10","prices":[{"amount":"9.50","currency":"EUR"}],"stock":5}`).Code)
+
+	assert.Equal(t, http.StatusBadRequest, e.Send(http.MethodGet, "/products/1?currency=EUR", "").Code)
+
+	assert.Equal(t, http.StatusBadRequest, e.Send(http.MethodPut, "/admin/exchange-rates", `{"base":"USD","rates":{"EUR":-1}}`).Code)
+	rec := e.Send(http.MethodPut, "/admin/exchange-rates", `{"base":"USD","rates":{"EUR":0.9,"JPY":"150"},"rounding":{"JPY":{"mode":"up","increment":100}}}`)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	rec = e.Send(http.MethodGet, "/admin/exchange-rates", "")
+	var rates models.ExchangeRates
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &rates))
+	assert.Equal(t, json.Number("0.9"), rates.Rates["EUR"])
+
+	rec = e.Send(http.MethodGet, "/products/1?currency=eur", "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var product models.Product
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &product))
+	assert.Equal(t, models.Money{Amount: 4500, Currency: "EUR"}, product.Price)
+
+	rec = e.Send(http.MethodGet, "/products?currency=JPY&sort=price", "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var page models.ProductPage
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &page))
+	assert.Equal(t, models.Money{Amount: 1500, Currency: "JPY"}, page.Products[0].Price)
+	assert.Equal(t, models.Money{Amount: 7500, Currency: "JPY"}, page.Products[1].Price)
+
+	rec = e.Send(http.MethodGet, "/products?currency=EUR&sort=price", "")
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &page))
+	assert.Equal(t, models.Money{Amount: 950, Currency: "EUR"}, page.Products[0].Price)
+
+	// Product 1 is shown at a converted 45 EUR, but price filters only match
+	// a product's own price or an explicit price in the currency.
+	rec = e.Send(http.MethodGet, "/products?currency=EUR&min_price=1&max_price=100", "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	page = models.ProductPage{}
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &page))
+	if assert.Len(t, page.Products, 1) {
+		assert.Equal(t, "2", page.Products[0].ProductID)
+	}
+
+	assert.Equal(t, http.StatusBadRequest, e.Send(http.MethodGet, "/products?currency=XYZ", "").Code)
+}
@@ -0,0 +1,81 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/YugenDev/global-mobility-test/internal/handlers"
+	"github.com/YugenDev/global-mobility-test/internal/models"
+	"github.com/YugenDev/global-mobility-test/internal/utils"
+	"github.com/YugenDev/global-mobility-test/test/testutil"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryStoreIdempotency(t *testing.T) {
+	store := testutil.NewMemoryStore(t)
+	e := store.Server(testutil.SignedIn, handlers.Idempotency(store.Idempotency, 1024))
+
+	product := `{"name":"Shoes","description":"Running","price":25,"stock":2}`
+	first := e.Send(http.MethodPost, "/products", product, utils.HeaderIdempotency, "create-shoes")
+	assert.Equal(t, http.StatusCreated, first.Code)
+	assert.Empty(t, first.Header().Get(handlers.HeaderIdempotentReplayed))
+
+	again := e.Send(http.MethodPost, "/products", product, utils.HeaderIdempotency, "create-shoes")
+	assert.Equal(t, http.StatusCreated, again.Code)
+	assert.Equal(t, "true", again.Header().Get(handlers.HeaderIdempotentReplayed))
+	assert.Equal(t, first.Body.Bytes(), again.Body.Bytes())
+	assert.Equal(t, first.Header().Get(echo.HeaderContentType), again.Header().Get(echo.HeaderContentType))
+	assert.NotEmpty(t, first.Header().Get(handlers.HeaderETag))
+	assert.Equal(t, first.Header().Get(handlers.HeaderETag), again.Header().Get(handlers.HeaderETag))
+
+	var created models.Product
+	assert.NoError(t, json.Unmarshal(first.Body.Bytes(), &created))
+	id := created.ProductID
+	page, _ := store.Products.GetAll(models.ProductQuery{})
+	assert.Len(t, page.Products, 1)
+
+	rec := e.Send(http.MethodPost, "/products", `{"name":"Hat","description":"Wool","price":10,"stock":1}`, utils.HeaderIdempotency, "create-shoes")
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Equal(t, http.StatusBadRequest, e.Send(http.MethodPost, "/products", product, utils.HeaderIdempotency, strings.Repeat("k", 256)).Code)
+	large := `{"name":"Hat","description":"` + strings.Repeat("w", 1024) + `","price":10,"stock":1}`
+	assert.Equal(t, http.StatusRequestEntityTooLarge, e.Send(http.MethodPost, "/products", large, utils.HeaderIdempotency, "create-hat").Code)
+
+	assert.Equal(t, http.StatusOK, e.Send(http.MethodPost, "/products/"+id+"/stock/adjust", `{"delta":3}`, utils.HeaderIdempotency, "restock-1").Code)
+	assert.Equal(t, http.StatusOK, e.Send(http.MethodPost, "/products/"+id+"/stock/adjust", `{"delta":3}`, utils.HeaderIdempotency, "restock-1").Code)
+	assert.Equal(t, http.StatusUnprocessableEntity, e.Send(http.MethodPost, "/products/missing/stock/adjust", `{"delta":3}`, utils.HeaderIdempotency, "restock-1").Code)
+	shoes, _ := store.Products.GetByID(id)
+	assert.Equal(t, 5, shoes.Stock)
+
+	assert.Equal(t, http.StatusNotFound, e.Send(http.MethodPost, "/products/missing/stock/adjust", `{"delta":3}`, utils.HeaderIdempotency, "restock-2").Code)
+	assert.Equal(t, http.StatusOK, e.Send(http.MethodPost, "/products/"+id+"/stock/adjust", `{"delta":3}`, utils.HeaderIdempotency, "restock-2").Code)
+	again = e.Send(http.MethodPost, "/products/"+id+"/stock/adjust", `{"delta":3}`, utils.HeaderIdempotency, "restock-2")
+	assert.Equal(t, "true", again.Header().Get(handlers.HeaderIdempotentReplayed))
+	shoes, _ = store.Products.GetByID(id)
+	assert.Equal(t, 8, shoes.Stock)
+
+	assert.Equal(t, http.StatusOK, e.Send(http.MethodPost, "/products/"+id+"/stock/adjust", `{"delta":1}`).Code)
+	assert.Equal(t, http.StatusOK, e.Send(http.MethodPost, "/products/"+id+"/stock/adjust", `{"delta":1}`).Code)
+	shoes, _ = store.Products.GetByID(id)
+	assert.Equal(t, 10, shoes.Stock)
+}
+
+func TestMemoryStoreIdempotencySpoolsLargeBodies(t *testing.T) {
+	store := testutil.NewMemoryStore(t)
+	e := store.Server(testutil.SignedIn, handlers.Idempotency(store.Idempotency, 1<<20))
+
+	product := `{"name":"Shoes","description":"` + strings.Repeat("r", 100<<10) + `","price":25,"stock":2}`
+	first := e.Send(http.MethodPost, "/products", product, utils.HeaderIdempotency, "create-shoes")
+	assert.Equal(t, http.StatusCreated, first.Code)
+
+	again := e.Send(http.MethodPost, "/products", product, utils.HeaderIdempotency, "create-shoes")
+	assert.Equal(t, "true", again.Header().Get(handlers.HeaderIdempotentReplayed))
+	assert.Equal(t, first.Body.Bytes(), again.Body.Bytes())
+
+	changed := strings.Replace(product, "rrr", "rrs", 1)
+	assert.Equal(t, http.StatusUnprocessableEntity, e.Send(http.MethodPost, "/products", changed, utils.HeaderIdempotency, "create-shoes").Code)
+	page, _ := store.Products.GetAll(models.ProductQuery{})
+	assert.Len(t, page.Products, 1)
+}
@@ -0,0 +1,41 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/YugenDev/global-mobility-test/internal/models"
+	"github.com/YugenDev/global-mobility-test/test/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryStoreCheckout(t *testing.T) {
+	e := testutil.NewMemoryServer(t)
+
+	e.Send(http.MethodPost, "/products", `{"product_id":"1","name":"Shoes","description":"Running","price":25,"stock":2}`)
+
+	rec := e.Send(http.MethodPost, "/checkout", `{"items":[{"product_id":"1","quantity":2}]}`)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+
+	var order models.Order
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &order))
+	assert.Equal(t, usd("50"), order.Total)
+
+	assert.Equal(t, http.StatusConflict, e.Send(http.MethodPost, "/checkout", `{"items":[{"product_id":"1","quantity":1}]}`).Code)
+	assert.Equal(t, http.StatusBadRequest, e.Send(http.MethodPost, "/checkout", `{}`).Code)
+	assert.Equal(t, http.StatusNotFound, e.Send(http.MethodPost, "/checkout", `{"cart_id":"missing"}`).Code)
+
+	assert.Equal(t, http.StatusConflict, e.Send(http.MethodPut, "/orders/"+order.OrderID+"/status", `{"status":"delivered"}`).Code)
+	assert.Equal(t, http.StatusOK, e.Send(http.MethodPut, "/orders/"+order.OrderID+"/status", `{"status":"cancelled"}`).Code)
+
+	rec = e.Send(http.MethodGet, "/products/1", "")
+	var product models.Product
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &product))
+	assert.Equal(t, 2, product.Stock)
+
+	rec = e.Send(http.MethodGet, "/orders/"+order.OrderID, "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"status":"cancelled"`)
+	assert.Equal(t, http.StatusNotFound, e.Send(http.MethodGet, "/orders/missing", "").Code)
+}
@@ -0,0 +1,73 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/YugenDev/global-mobility-test/internal/models"
+	"github.com/YugenDev/global-mobility-test/test/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryStorePriceSchedules(t *testing.T) {
+	e := testutil.NewMemoryServer(t)
+
+	rec := e.Send(http.MethodPost, "/products", `{"product_id":"1","name":"Shirt","description":"Cotton","price":"20.00","stock":5}`)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+
+	start := time.Now().Add(time.Hour)
+	end := start.Add(72 * time.Hour)
+	window := func(price string, from, to time.Time) string {
+		return `{"price":"` + price + `","starts_at":"` + from.Format(time.RFC3339Nano) + `","ends_at":"` + to.Format(time.RFC3339Nano) + `"}`
+	}
+
+	rec = e.Send(http.MethodPost, "/products/1/price-schedule", window("15.00", start, end))
+	assert.Equal(t, http.StatusCreated, rec.Code)
+
+	var schedule models.PriceSchedule
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &schedule))
+	assert.Equal(t, models.PriceScheduleStatusPending, schedule.Status)
+
+	assert.Equal(t, http.StatusConflict, e.Send(http.MethodPost, "/products/1/price-schedule", window("12.00", start.Add(time.Hour), end.Add(time.Hour))).Code)
+	assert.Equal(t, http.StatusBadRequest, e.Send(http.MethodPost, "/products/1/price-schedule", window("12.00", end, start)).Code)
+	assert.Equal(t, http.StatusNotFound, e.Send(http.MethodPost, "/products/2/price-schedule", window("12.00", start, end)).Code)
+
+	rec = e.Send(http.MethodGet, "/products/1/price-schedule", "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), schedule.ScheduleID)
+
+	_, err := e.Store.PriceSchedules.ProcessDue(start)
+	assert.NoError(t, err)
+
+	rec = e.Send(http.MethodGet, "/products/1", "")
+	assert.Contains(t, rec.Body.String(), `"amount":"15.00"`)
+
+	rec = e.Send(http.MethodDelete, "/products/1/price-schedule/"+schedule.ScheduleID, "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"status":"cancelled"`)
+	assert.Equal(t, http.StatusConflict, e.Send(http.MethodDelete, "/products/1/price-schedule/"+schedule.ScheduleID, "").Code)
+	assert.Equal(t, http.StatusNotFound, e.Send(http.MethodDelete, "/products/1/price-schedule/missing", "").Code)
+
+	rec = e.Send(http.MethodGet, "/products/1/price-history?limit=2", "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var page models.PriceHistoryPage
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &page))
+	if assert.Len(t, page.Entries, 2) {
+		assert.Equal(t, "20.00", page.Entries[0].Price.String())
+		assert.Equal(t, schedule.ScheduleID, page.Entries[0].ScheduleID)
+		assert.Equal(t, "15.00", page.Entries[1].Price.String())
+	}
+	assert.True(t, page.HasMore)
+
+	rec = e.Send(http.MethodGet, "/products/1/price-history?cursor="+page.NextCursor, "")
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &page))
+	if assert.Len(t, page.Entries, 1) {
+		assert.Equal(t, models.PriceSourceManual, page.Entries[0].Source)
+	}
+	assert.False(t, page.HasMore)
+
+	assert.Equal(t, http.StatusBadRequest, e.Send(http.MethodGet, "/products/1/price-history?limit=0", "").Code)
+}
@@ -0,0 +1,215 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/YugenDev/global-mobility-test/internal/handlers"
+	"github.com/YugenDev/global-mobility-test/internal/models"
+	"github.com/YugenDev/global-mobility-test/internal/utils"
+	"github.com/YugenDev/global-mobility-test/test/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryStoreCreateAndGetProduct(t *testing.T) {
+	e := testutil.NewMemoryServer(t)
+
+	body := `{"product_id":"1","name":"Test Product","description":"Test Description","price":10,"stock":5}`
+	rec := e.Send(http.MethodPost, "/products", body)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+
+	rec = e.Send(http.MethodPost, "/products", body)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec = e.Send(http.MethodGet, "/products/1", "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var product models.Product
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &product))
+	assert.Equal(t, "Test Product", product.Name)
+
+	rec = e.Send(http.MethodGet, "/products/2", "")
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestMemoryStoreProductPriceIsExact(t *testing.T) {
+	e := testutil.NewMemoryServer(t)
+
+	body := `{"product_id":"1","name":"Test Product","description":"Test Description","price":{"amount":"19.99","currency":"EUR"},"stock":5}`
+	rec := e.Send(http.MethodPost, "/products", body)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+
+	var created map[string]interface{}
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &created))
+	assert.Equal(t, map[string]interface{}{"amount": "19.99", "currency": "EUR"}, created["price"])
+
+	for _, price := range []string{`"19.999"`, `{"amount":"5","currency":"XYZ"}`} {
+		body := `{"name":"Test Product","description":"Test Description","price":` + price + `,"stock":5}`
+		rec := e.Send(http.MethodPost, "/products", body)
+		assert.Equal(t, http.StatusBadRequest, rec.Code, price)
+	}
+}
+
+func TestMemoryStorePaginatesProducts(t *testing.T) {
+	e := testutil.NewMemoryServer(t)
+
+	for _, id := range []string{"1", "2", "3"} {
+		body := `{"product_id":"` + id + `","name":"Product","description":"Description","price":10,"stock":5}`
+		e.Send(http.MethodPost, "/products", body)
+	}
+
+	var ids []string
+	cursor := ""
+	for {
+		rec := e.Send(http.MethodGet, "/products?limit=2&cursor="+cursor, "")
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var page models.ProductPage
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &page))
+		for _, product := range page.Products {
+			ids = append(ids, product.ProductID)
+		}
+		if !page.HasMore {
+			break
+		}
+		cursor = page.NextCursor
+	}
+
+	assert.Equal(t, []string{"1", "2", "3"}, ids)
+}
+
+func TestMemoryStoreIfMatch(t *testing.T) {
+	e := testutil.NewMemoryServer(t)
+
+	body := `{"product_id":"1","name":"Test Product","description":"Test Description","price":10,"stock":5}`
+	e.Send(http.MethodPost, "/products", body)
+
+	rec := e.Send(http.MethodGet, "/products/1", "")
+	etag := rec.Header().Get(handlers.HeaderETag)
+	assert.Equal(t, `"1"`, etag)
+
+	rec = e.Send(http.MethodPut, "/products/1", `{"price":12}`, handlers.HeaderIfMatch, etag)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, `"2"`, rec.Header().Get(handlers.HeaderETag))
+
+	rec = e.Send(http.MethodPut, "/products/1", `{"price":15}`, handlers.HeaderIfMatch, etag)
+	assert.Equal(t, http.StatusPreconditionFailed, rec.Code)
+
+	rec = e.Send(http.MethodDelete, "/products/1", "", handlers.HeaderIfMatch, etag)
+	assert.Equal(t, http.StatusPreconditionFailed, rec.Code)
+
+	rec = e.Send(http.MethodDelete, "/products/1", "", handlers.HeaderIfMatch, `"2"`)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+}
+
+func TestMemoryStoreTrashAndRestore(t *testing.T) {
+	e := testutil.NewMemoryServer(t)
+
+	body := `{"product_id":"1","name":"Test Product","description":"Test Description","price":10,"stock":5}`
+	e.Send(http.MethodPost, "/products", body)
+
+	rec := e.Send(http.MethodDelete, "/products/1", "")
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	rec = e.Send(http.MethodGet, "/products/1", "")
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	rec = e.Send(http.MethodGet, "/products/trash", "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var page models.ProductPage
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &page))
+	assert.Len(t, page.Products, 1)
+
+	rec = e.Send(http.MethodPost, "/products/1/restore", "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	rec = e.Send(http.MethodPost, "/products/1/restore", "")
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	rec = e.Send(http.MethodGet, "/products/1", "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestMemoryStoreProductHistory(t *testing.T) {
+	e := testutil.NewMemoryServer(t)
+
+	body := `{"product_id":"1","name":"Test Product","description":"Test Description","price":10,"stock":5}`
+	e.Send(http.MethodPost, "/products", body)
+
+	e.Send(http.MethodPut, "/products/1", `{"price":12}`, utils.HeaderActor, "admin")
+
+	rec := e.Send(http.MethodGet, "/products/1/history?limit=1", "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var page models.AuditPage
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &page))
+	assert.True(t, page.HasMore)
+	if assert.Len(t, page.Entries, 1) {
+		assert.Equal(t, models.AuditActionUpdate, page.Entries[0].Action)
+		assert.Equal(t, "admin", page.Entries[0].Actor)
+	}
+
+	rec = e.Send(http.MethodGet, "/products/1/history?cursor="+page.NextCursor, "")
+
+	page = models.AuditPage{}
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &page))
+	assert.False(t, page.HasMore)
+	if assert.Len(t, page.Entries, 1) {
+		assert.Equal(t, models.AuditActionCreate, page.Entries[0].Action)
+		assert.Equal(t, utils.AnonymousActor, page.Entries[0].Actor)
+	}
+}
+
+func TestMemoryStoreBulkProducts(t *testing.T) {
+	e := testutil.NewMemoryServer(t)
+
+	body := `[
+		{"op":"create","product":{"product_id":"1","name":"Shoes","description":"Running","price":10,"stock":5}},
+		{"op":"create","product":{"product_id":"2","name":"Hat","description":"Warm","price":5}},
+		{"op":"update","product_id":"1","version":1,"product":{"price":12}},
+		{"op":"delete","product_id":"3"}
+	]`
+	rec := e.Send(http.MethodPost, "/products/bulk", body)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response models.BulkResponse
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	if assert.Len(t, response.Results, 4) {
+		assert.Equal(t, models.BulkStatusCreated, response.Results[0].Status)
+		assert.Equal(t, models.BulkStatusCreated, response.Results[1].Status)
+		assert.Equal(t, "duplicate_id", response.Results[2].Code)
+		assert.Equal(t, "not_found", response.Results[3].Code)
+	}
+
+	rec = e.Send(http.MethodPost, "/products/bulk", `[{"op":"update","product_id":"1","version":1,"product":{"price":12}}]`)
+
+	response = models.BulkResponse{}
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, models.BulkStatusUpdated, response.Results[0].Status)
+	assert.Equal(t, 2, response.Results[0].Version)
+
+	rec = e.Send(http.MethodPost, "/products/bulk", `[]`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestMemoryStoreAdjustStock(t *testing.T) {
+	e := testutil.NewMemoryServer(t)
+
+	body := `{"product_id":"1","name":"Test Product","description":"Test Description","price":10,"stock":5}`
+	e.Send(http.MethodPost, "/products", body)
+
+	rec := e.Send(http.MethodPost, "/products/1/stock/adjust", `{"delta":-4}`)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, `"2"`, rec.Header().Get(handlers.HeaderETag))
+
+	var product models.Product
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &product))
+	assert.Equal(t, 1, product.Stock)
+
+	assert.Equal(t, http.StatusConflict, e.Send(http.MethodPost, "/products/1/stock/adjust", `{"delta":-2}`).Code)
+	assert.Equal(t, http.StatusBadRequest, e.Send(http.MethodPost, "/products/1/stock/adjust", `{"delta":0}`).Code)
+	assert.Equal(t, http.StatusNotFound, e.Send(http.MethodPost, "/products/2/stock/adjust", `{"delta":1}`).Code)
+	assert.Equal(t, http.StatusOK, e.Send(http.MethodPost, "/products/1/stock/adjust", `{"delta":3}`).Code)
+}
@@ -6,22 +6,38 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/YugenDev/global-mobility-test/internal/handlers"
 	"github.com/YugenDev/global-mobility-test/internal/models"
+	"github.com/YugenDev/global-mobility-test/internal/repositories"
 	"github.com/YugenDev/global-mobility-test/internal/utils"
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
 
+// usd parses a USD amount such as "29.99", failing on malformed input.
+func usd(amount string) models.Money {
+	money, err := models.ParseMoney(amount, "USD")
+	if err != nil {
+		panic(err)
+	}
+	return money
+}
+
 type MockProductService struct {
 	mock.Mock
 }
 
-func (m *MockProductService) GetAll() ([]models.Product, error) {
-	args := m.Called()
-	return args.Get(0).([]models.Product), args.Error(1)
+func (m *MockProductService) GetAll(query models.ProductQuery) (models.ProductPage, error) {
+	args := m.Called(query)
+	return args.Get(0).(models.ProductPage), args.Error(1)
+}
+
+func (m *MockProductService) PriceIn(product models.Product, currency string) (models.Product, error) {
+	args := m.Called(product, currency)
+	return args.Get(0).(models.Product), args.Error(1)
 }
 
 func (m *MockProductService) GetByID(id string) (models.Product, error) {
@@ -42,18 +58,88 @@ func (m *MockProductService) UpdateProduct(c echo.Context, id string, product *m
 	return args.Error(0)
 }
 
-func (m *MockProductService) DeleteProduct(c echo.Context, id string) error {
-	args := m.Called(c, id)
+func (m *MockProductService) DeleteProduct(c echo.Context, id string, version int) error {
+	args := m.Called(c, id, version)
+	return args.Error(0)
+}
+
+func (m *MockProductService) SearchProducts(query models.ProductSearchQuery) (models.ProductSearchPage, error) {
+	args := m.Called(query)
+	return args.Get(0).(models.ProductSearchPage), args.Error(1)
+}
+
+func (m *MockProductService) EnsureSearchIndex() error {
+	args := m.Called()
 	return args.Error(0)
 }
 
+func (m *MockProductService) GetTrash(query models.ProductQuery) (models.ProductPage, error) {
+	args := m.Called(query)
+	return args.Get(0).(models.ProductPage), args.Error(1)
+}
+
+func (m *MockProductService) RestoreProduct(c echo.Context, id string) (models.Product, error) {
+	args := m.Called(c, id)
+	return args.Get(0).(models.Product), args.Error(1)
+}
+
+func (m *MockProductService) PurgeDeletedProducts(retention time.Duration, blobStore repositories.IBlobStore) (int64, error) {
+	args := m.Called(retention)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockProductService) GetHistory(query models.AuditQuery) (models.AuditPage, error) {
+	args := m.Called(query)
+	return args.Get(0).(models.AuditPage), args.Error(1)
+}
+
+func (m *MockProductService) SetPrice(c echo.Context, id string, price models.Money, scheduleID string) (models.Product, error) {
+	args := m.Called(c, id, price, scheduleID)
+	return args.Get(0).(models.Product), args.Error(1)
+}
+
+func (m *MockProductService) GetPriceHistory(query models.PriceHistoryQuery) (models.PriceHistoryPage, error) {
+	args := m.Called(query)
+	return args.Get(0).(models.PriceHistoryPage), args.Error(1)
+}
+
+func (m *MockProductService) BulkProducts(c echo.Context, ops []models.BulkOperation) (models.BulkResponse, error) {
+	args := m.Called(c, ops)
+	return args.Get(0).(models.BulkResponse), args.Error(1)
+}
+
+func (m *MockProductService) AdjustStock(c echo.Context, id string, delta int) (models.Product, error) {
+	args := m.Called(c, id, delta)
+	return args.Get(0).(models.Product), args.Error(1)
+}
+
+func (m *MockProductService) AddVariant(c echo.Context, id string, version int, variant models.Variant) (models.Product, error) {
+	args := m.Called(c, id, version, variant)
+	return args.Get(0).(models.Product), args.Error(1)
+}
+
+func (m *MockProductService) UpdateVariant(c echo.Context, id string, sku string, version int, patch models.Variant) (models.Product, error) {
+	args := m.Called(c, id, sku, version, patch)
+	return args.Get(0).(models.Product), args.Error(1)
+}
+
+func (m *MockProductService) RemoveVariant(c echo.Context, id string, sku string, version int) (models.Product, error) {
+	args := m.Called(c, id, sku, version)
+	return args.Get(0).(models.Product), args.Error(1)
+}
+
+func (m *MockProductService) AdjustVariantStock(c echo.Context, id string, sku string, delta int) (models.Product, error) {
+	args := m.Called(c, id, sku, delta)
+	return args.Get(0).(models.Product), args.Error(1)
+}
+
 func TestGetAllProducts(t *testing.T) {
 	mockService := new(MockProductService)
 	handler := handlers.NewProductHandler(mockService)
 	e := echo.New()
 
-	products := []models.Product{{ProductID: "1", Name: "Test Product"}}
-	mockService.On("GetAll").Return(products, nil)
+	page := models.ProductPage{Products: []models.Product{{ProductID: "1", Name: "Test Product"}}}
+	mockService.On("GetAll", models.ProductQuery{}).Return(page, nil)
 
 	req := httptest.NewRequest(http.MethodGet, "/products", nil)
 	rec := httptest.NewRecorder()
@@ -105,7 +191,7 @@ func TestCreateProductInvalidData(t *testing.T) {
 	product := &models.Product{
 		Name:        "",
 		Description: "",
-		Price:       -1,
+		Price:       usd("-1"),
 		Stock:       -1,
 	}
 
@@ -129,7 +215,7 @@ func TestGetProductByID(t *testing.T) {
 		ProductID:   "1",
 		Name:        "Test Product",
 		Description: "Test Description",
-		Price:       10.0,
+		Price:       usd("10"),
 		Stock:       5,
 	}
 
@@ -157,7 +243,7 @@ func TestGetAllProductsError(t *testing.T) {
 	handler := handlers.NewProductHandler(mockService)
 	e := echo.New()
 
-	mockService.On("GetAll").Return([]models.Product{}, utils.ErrNoProductsFound)
+	mockService.On("GetAll", mock.Anything).Return(models.ProductPage{}, utils.ErrNoProductsFound)
 
 	req := httptest.NewRequest(http.MethodGet, "/products", nil)
 	rec := httptest.NewRecorder()
@@ -175,7 +261,7 @@ func TestCreateProductSuccess(t *testing.T) {
 	product := &models.Product{
 		Name:        "Test Product",
 		Description: "Test Description",
-		Price:       10.0,
+		Price:       usd("10"),
 		Stock:       5,
 	}
 
@@ -201,7 +287,7 @@ func TestCreateProductIDExists(t *testing.T) {
 		ProductID:   "1",
 		Name:        "Test Product",
 		Description: "Test Description",
-		Price:       10.0,
+		Price:       usd("10"),
 		Stock:       5,
 	}
 
@@ -252,7 +338,7 @@ func TestGetAllProductsInternalError(t *testing.T) {
 	handler := handlers.NewProductHandler(mockService)
 	e := echo.New()
 
-	mockService.On("GetAll").Return([]models.Product{}, utils.ErrInternalServer)
+	mockService.On("GetAll", mock.Anything).Return(models.ProductPage{}, utils.ErrInternalServer)
 
 	req := httptest.NewRequest(http.MethodGet, "/products", nil)
 	rec := httptest.NewRecorder()
@@ -275,7 +361,7 @@ func TestCreateProductWithErrors(t *testing.T) {
 	product := &models.Product{
 		Name:        "Test Product",
 		Description: "Test Description",
-		Price:       10.0,
+		Price:       usd("10"),
 		Stock:       5,
 	}
 
@@ -301,7 +387,7 @@ func TestCreateProductEmptyNameField(t *testing.T) {
 	product := &models.Product{
 		Name:        "",
 		Description: "Test Description",
-		Price:       10.0,
+		Price:       usd("10"),
 		Stock:       5,
 	}
 
@@ -326,7 +412,7 @@ func TestCreateProductEmptyDescriptionField(t *testing.T) {
 	product := &models.Product{
 		Name:        "Test Product",
 		Description: "",
-		Price:       10.0,
+		Price:       usd("10"),
 		Stock:       5,
 	}
 
@@ -351,7 +437,7 @@ func TestCreateProductInvalidPrice(t *testing.T) {
 	product := &models.Product{
 		Name:        "Test Product",
 		Description: "Test Description",
-		Price:       0,
+		Price:       models.Money{},
 		Stock:       5,
 	}
 
@@ -376,7 +462,7 @@ func TestCreateProductInvalidStock(t *testing.T) {
 	product := &models.Product{
 		Name:        "Test Product",
 		Description: "Test Description",
-		Price:       10.0,
+		Price:       usd("10"),
 		Stock:       -1,
 	}
 
@@ -408,7 +494,7 @@ func TestUpdateProduct(t *testing.T) {
 			product: models.Product{
 				Name:        "Updated Product",
 				Description: "Updated Description",
-				Price:       20.0,
+				Price:       usd("20"),
 				Stock:       10,
 			},
 			setupMock: func(m *MockProductService) {
@@ -437,7 +523,7 @@ func TestUpdateProduct(t *testing.T) {
 			name: "Invalid Price",
 			id:   "1",
 			product: models.Product{
-				Price: -10,
+				Price: usd("-10"),
 			},
 			setupMock: func(m *MockProductService) {
 				m.On("GetByID", "1").Return(models.Product{ProductID: "1"}, nil)
@@ -449,7 +535,7 @@ func TestUpdateProduct(t *testing.T) {
 			name: "Invalid Stock",
 			id:   "1",
 			product: models.Product{
-				Price: 10,
+				Price: usd("10"),
 				Stock: -1,
 			},
 			setupMock: func(m *MockProductService) {
@@ -463,7 +549,7 @@ func TestUpdateProduct(t *testing.T) {
 			id:   "1",
 			product: models.Product{
 				ProductID: "2",
-				Price:     10,
+				Price:     usd("10"),
 				Stock:     5,
 			},
 			setupMock: func(m *MockProductService) {
@@ -476,7 +562,7 @@ func TestUpdateProduct(t *testing.T) {
 			name: "Internal Server Error",
 			id:   "1",
 			product: models.Product{
-				Price: 10,
+				Price: usd("10"),
 				Stock: 5,
 			},
 			setupMock: func(m *MockProductService) {
@@ -529,7 +615,7 @@ func TestDeleteProduct(t *testing.T) {
 			id:   "1",
 			setupMock: func(m *MockProductService) {
 				m.On("GetByID", "1").Return(models.Product{ProductID: "1"}, nil)
-				m.On("DeleteProduct", mock.Anything, "1").Return(nil)
+				m.On("DeleteProduct", mock.Anything, "1", 0).Return(nil)
 			},
 			expectedStatus: http.StatusNoContent,
 		},
@@ -554,7 +640,7 @@ func TestDeleteProduct(t *testing.T) {
 			id:   "1",
 			setupMock: func(m *MockProductService) {
 				m.On("GetByID", "1").Return(models.Product{ProductID: "1"}, nil)
-				m.On("DeleteProduct", mock.Anything, "1").Return(utils.ErrInternalServer)
+				m.On("DeleteProduct", mock.Anything, "1", 0).Return(utils.ErrInternalServer)
 			},
 			expectedStatus: http.StatusInternalServerError,
 			expectedMsg:    utils.ErrInternalServer.Error(),
@@ -588,3 +674,197 @@ func TestDeleteProduct(t *testing.T) {
 	}
 }
 
+func TestGetAllProductsInvalidLimit(t *testing.T) {
+	mockService := new(MockProductService)
+	handler := handlers.NewProductHandler(mockService)
+	e := echo.New()
+
+	req := httptest.NewRequest(http.MethodGet, "/products?limit=abc", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	if assert.NoError(t, handler.GetAllProducts(c)) {
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		var response map[string]string
+		json.Unmarshal(rec.Body.Bytes(), &response)
+		assert.Equal(t, utils.ErrInvalidPageLimit.Error(), response["message"])
+	}
+	mockService.AssertNotCalled(t, "GetAll", mock.Anything)
+}
+
+func TestGetAllProductsInvalidCursor(t *testing.T) {
+	mockService := new(MockProductService)
+	handler := handlers.NewProductHandler(mockService)
+	e := echo.New()
+
+	mockService.On("GetAll", models.ProductQuery{Limit: 5, Cursor: "bad"}).Return(models.ProductPage{}, utils.ErrInvalidCursor)
+
+	req := httptest.NewRequest(http.MethodGet, "/products?limit=5&cursor=bad", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	if assert.NoError(t, handler.GetAllProducts(c)) {
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	}
+}
+
+func TestGetAllProductsFilters(t *testing.T) {
+	tests := []struct {
+		name           string
+		url            string
+		setupMock      func(*MockProductService)
+		expectedStatus int
+	}{
+		{
+			name: "Valid Filters",
+			url:  "/products?min_price=5&max_price=50&in_stock=true&name=app&created_after=2024-01-01T00:00:00Z&sort=-price",
+			setupMock: func(m *MockProductService) {
+				m.On("GetAll", mock.MatchedBy(func(q models.ProductQuery) bool {
+					return q.Sort == "-price" && *q.Filter.MinPrice == usd("5") && *q.Filter.MaxPrice == usd("50") &&
+						*q.Filter.InStock && q.Filter.NamePrefix == "app" && q.Filter.CreatedAfter.Year() == 2024
+				})).Return(models.ProductPage{Products: []models.Product{}}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "Price Filters In Requested Currency",
+			url:  "/products?min_price=5.50&currency=eur",
+			setupMock: func(m *MockProductService) {
+				m.On("GetAll", mock.MatchedBy(func(q models.ProductQuery) bool {
+					return q.Currency == "EUR" && *q.Filter.MinPrice == models.Money{Amount: 550, Currency: "EUR"}
+				})).Return(models.ProductPage{Products: []models.Product{}}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Price Filter In Unsupported Currency",
+			url:            "/products?min_price=5&currency=XYZ",
+			setupMock:      func(m *MockProductService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Unknown Filter",
+			url:            "/products?color=red",
+			setupMock:      func(m *MockProductService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Invalid Filter Value",
+			url:            "/products?in_stock=maybe",
+			setupMock:      func(m *MockProductService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "Unknown Sort Field",
+			url:  "/products?sort=stock",
+			setupMock: func(m *MockProductService) {
+				m.On("GetAll", mock.Anything).Return(models.ProductPage{}, utils.ErrInvalidSortField)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockProductService)
+			tt.setupMock(mockService)
+			handler := handlers.NewProductHandler(mockService)
+			e := echo.New()
+
+			req := httptest.NewRequest(http.MethodGet, tt.url, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			assert.NoError(t, handler.GetAllProducts(c))
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSearchProducts(t *testing.T) {
+	mockService := new(MockProductService)
+	handler := handlers.NewProductHandler(mockService)
+	e := echo.New()
+
+	page := models.ProductSearchPage{Results: []models.ProductSearchResult{
+		{Product: models.Product{ProductID: "1", Name: "Red Shoes"}, Score: 1.5},
+	}}
+	mockService.On("SearchProducts", models.ProductSearchQuery{Text: "shoes", Limit: 5}).Return(page, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/products/search?q=shoes&limit=5", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	if assert.NoError(t, handler.SearchProducts(c)) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+		var response map[string]interface{}
+		json.Unmarshal(rec.Body.Bytes(), &response)
+		result := response["results"].([]interface{})[0].(map[string]interface{})
+		assert.Equal(t, "Red Shoes", result["name"])
+		assert.Equal(t, 1.5, result["score"])
+	}
+}
+
+func TestSearchProductsMissingQuery(t *testing.T) {
+	mockService := new(MockProductService)
+	handler := handlers.NewProductHandler(mockService)
+	e := echo.New()
+
+	mockService.On("SearchProducts", models.ProductSearchQuery{}).Return(models.ProductSearchPage{}, utils.ErrSearchQueryRequired)
+
+	req := httptest.NewRequest(http.MethodGet, "/products/search", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	if assert.NoError(t, handler.SearchProducts(c)) {
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	}
+}
+
+func TestUpdateProductPreconditionFailed(t *testing.T) {
+	mockService := new(MockProductService)
+	handler := handlers.NewProductHandler(mockService)
+	e := echo.New()
+
+	mockService.On("GetByID", "1").Return(models.Product{ProductID: "1", Version: 3}, nil)
+	mockService.On("UpdateProduct", mock.Anything, "1", mock.MatchedBy(func(p *models.Product) bool {
+		return p.Version == 2
+	})).Return(utils.ErrProductVersionMismatch)
+
+	req := httptest.NewRequest(http.MethodPut, "/", strings.NewReader(`{"name":"New"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set(handlers.HeaderIfMatch, `"2"`)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/products/:id")
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	if assert.NoError(t, handler.UpdateProduct(c)) {
+		assert.Equal(t, http.StatusPreconditionFailed, rec.Code)
+	}
+	mockService.AssertExpectations(t)
+}
+
+func TestDeleteProductPreconditionFailed(t *testing.T) {
+	mockService := new(MockProductService)
+	handler := handlers.NewProductHandler(mockService)
+	e := echo.New()
+
+	mockService.On("GetByID", "1").Return(models.Product{ProductID: "1", Version: 3}, nil)
+	mockService.On("DeleteProduct", mock.Anything, "1", -1).Return(utils.ErrProductVersionMismatch)
+
+	req := httptest.NewRequest(http.MethodDelete, "/", nil)
+	req.Header.Set(handlers.HeaderIfMatch, `"abc"`)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/products/:id")
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	if assert.NoError(t, handler.DeleteProduct(c)) {
+		assert.Equal(t, http.StatusPreconditionFailed, rec.Code)
+	}
+	mockService.AssertExpectations(t)
+}
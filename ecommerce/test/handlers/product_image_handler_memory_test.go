@@ -0,0 +1,67 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"testing"
+
+	"github.com/YugenDev/global-mobility-test/internal/models"
+	"github.com/YugenDev/global-mobility-test/internal/utils"
+	"github.com/YugenDev/global-mobility-test/test/testutil"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryStoreProductImages(t *testing.T) {
+	e := testutil.NewMemoryServer(t)
+
+	upload := func(path string, contentType string, content []byte) *httptest.ResponseRecorder {
+		var body bytes.Buffer
+		writer := multipart.NewWriter(&body)
+		writer.WriteField("alt_text", "Front view")
+		header := textproto.MIMEHeader{}
+		header.Set("Content-Disposition", `form-data; name="file"; filename="front.png"`)
+		header.Set("Content-Type", contentType)
+		part, _ := writer.CreatePart(header)
+		part.Write(content)
+		writer.Close()
+
+		req := httptest.NewRequest(http.MethodPost, path, &body)
+		req.Header.Set(echo.HeaderContentType, writer.FormDataContentType())
+		return e.Serve(req)
+	}
+
+	e.Send(http.MethodPost, "/products", `{"product_id":"1","name":"Shoes","description":"Running","price":25,"stock":5}`)
+	png := []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+	rec := upload("/products/1/images", "image/png", png)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	var product models.Product
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &product))
+	assert.Len(t, product.Images, 1)
+	assert.Equal(t, "Front view", product.Images[0].AltText)
+
+	assert.Equal(t, http.StatusBadRequest, upload("/products/1/images", "text/plain", []byte("hello")).Code)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, upload("/products/1/images", "image/png", append(png, make([]byte, testutil.MaxImageSize)...)).Code)
+	rec = upload("/products/1/images", "image/png", append(png, make([]byte, 1<<20)...))
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+	assert.Contains(t, rec.Body.String(), utils.ErrImageTooLarge.Error())
+	assert.Equal(t, http.StatusNotFound, upload("/products/missing/images", "image/png", png).Code)
+	assert.Equal(t, http.StatusBadRequest, e.Send(http.MethodPost, "/products/1/images", "").Code)
+
+	rec = e.Send(http.MethodGet, product.Images[0].URL, "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "image/png", rec.Header().Get(echo.HeaderContentType))
+	assert.Equal(t, png, rec.Body.Bytes())
+
+	assert.Equal(t, http.StatusBadRequest, e.Send(http.MethodPut, "/products/1/images/order", `{"image_ids":[]}`).Code)
+	assert.Equal(t, http.StatusOK, e.Send(http.MethodPut, "/products/1/images/order", `{"image_ids":["`+product.Images[0].ImageID+`"]}`).Code)
+	assert.Equal(t, http.StatusBadRequest, e.Send(http.MethodPut, "/products/1", `{"images":[]}`).Code)
+
+	assert.Equal(t, http.StatusOK, e.Send(http.MethodDelete, product.Images[0].URL, "").Code)
+	assert.Equal(t, http.StatusNotFound, e.Send(http.MethodGet, product.Images[0].URL, "").Code)
+}
@@ -0,0 +1,47 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/YugenDev/global-mobility-test/internal/models"
+	"github.com/YugenDev/global-mobility-test/test/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryStoreVariants(t *testing.T) {
+	e := testutil.NewMemoryServer(t)
+
+	rec := e.Send(http.MethodPost, "/products", `{"product_id":"tee","name":"Tee","description":"Cotton","price":20,"variants":[{"sku":"TEE-S","options":{"size":"S"},"stock":2}]}`)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+
+	rec = e.Send(http.MethodPost, "/products/tee/variants", `{"sku":"TEE-M","options":{"size":"M"},"price":22,"stock":3}`)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	var product models.Product
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &product))
+	assert.Equal(t, 5, product.Stock)
+	assert.Equal(t, `"2"`, rec.Header().Get("ETag"))
+
+	assert.Equal(t, http.StatusConflict, e.Send(http.MethodPost, "/products/tee/variants", `{"sku":"TEE-M","options":{"size":"L"}}`).Code)
+	assert.Equal(t, http.StatusConflict, e.Send(http.MethodPost, "/products/tee/variants", `{"sku":"TEE-L","options":{"size":"M"}}`).Code)
+	assert.Equal(t, http.StatusBadRequest, e.Send(http.MethodPost, "/products/tee/variants", `{"sku":"TEE-L"}`).Code)
+	assert.Equal(t, http.StatusPreconditionFailed, e.Send(http.MethodPut, "/products/tee/variants/TEE-M", `{"price":30}`, "If-Match", `"1"`).Code)
+	assert.Equal(t, http.StatusOK, e.Send(http.MethodPut, "/products/tee/variants/TEE-M", `{"price":30}`, "If-Match", `"2"`).Code)
+	assert.Equal(t, http.StatusNotFound, e.Send(http.MethodPut, "/products/tee/variants/TEE-XL", `{"price":30}`).Code)
+
+	rec = e.Send(http.MethodPost, "/products/tee/variants/TEE-S/stock/adjust", `{"delta":-2}`)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &product))
+	assert.Equal(t, 3, product.Stock)
+
+	assert.Equal(t, http.StatusConflict, e.Send(http.MethodPost, "/products/tee/variants/TEE-S/stock/adjust", `{"delta":-1}`).Code)
+	assert.Equal(t, http.StatusConflict, e.Send(http.MethodPost, "/products/tee/stock/adjust", `{"delta":1}`).Code)
+	assert.Equal(t, http.StatusBadRequest, e.Send(http.MethodPut, "/products/tee", `{"variants":[]}`).Code)
+
+	rec = e.Send(http.MethodDelete, "/products/tee/variants/TEE-S", "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &product))
+	assert.Len(t, product.Variants, 1)
+	assert.Equal(t, http.StatusNotFound, e.Send(http.MethodDelete, "/products/missing/variants/TEE-S", "").Code)
+}
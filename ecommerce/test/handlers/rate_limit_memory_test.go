@@ -0,0 +1,102 @@
+package handlers_test
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/YugenDev/global-mobility-test/internal/handlers"
+	"github.com/YugenDev/global-mobility-test/internal/models"
+	"github.com/YugenDev/global-mobility-test/internal/repositories"
+	"github.com/YugenDev/global-mobility-test/internal/services"
+	"github.com/YugenDev/global-mobility-test/internal/utils"
+	"github.com/YugenDev/global-mobility-test/test/testutil"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryStoreRateLimit(t *testing.T) {
+	limiter := services.NewRateLimiter(repositories.NewMemoryRateLimitStore(), models.RateLimit{Requests: 2, Period: time.Minute}, map[string]models.RateLimit{
+		"GET /products/search": {Requests: 1, Period: time.Minute},
+		"GET /products/:id":    {},
+	})
+
+	store := testutil.NewMemoryStore(t)
+	anonymous := store.Server(handlers.RateLimit(limiter))
+	user := store.Server(testutil.SignedIn, handlers.RateLimit(limiter))
+
+	send := func(e *testutil.Server, path, ip string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.RemoteAddr = ip + ":1234"
+		return e.Serve(req)
+	}
+
+	rec := send(anonymous, "/products", "192.0.2.1")
+	assert.NotEqual(t, http.StatusTooManyRequests, rec.Code)
+	assert.Equal(t, "2", rec.Header().Get(handlers.HeaderRateLimitLimit))
+	assert.Equal(t, "1", rec.Header().Get(handlers.HeaderRateLimitRemaining))
+	assert.Equal(t, "30", rec.Header().Get(handlers.HeaderRateLimitReset))
+
+	assert.NotEqual(t, http.StatusTooManyRequests, send(anonymous, "/products/trash", "192.0.2.1").Code)
+	rec = send(anonymous, "/products", "192.0.2.1")
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Equal(t, "0", rec.Header().Get(handlers.HeaderRateLimitRemaining))
+	assert.Equal(t, "30", rec.Header().Get(echo.HeaderRetryAfter))
+	assert.Contains(t, rec.Body.String(), "too many requests")
+
+	assert.NotEqual(t, http.StatusTooManyRequests, send(anonymous, "/products", "192.0.2.2").Code)
+	assert.NotEqual(t, http.StatusTooManyRequests, send(user, "/products", "192.0.2.1").Code)
+
+	rec = send(anonymous, "/products/1", "192.0.2.1")
+	assert.NotEqual(t, http.StatusTooManyRequests, rec.Code)
+	assert.Empty(t, rec.Header().Get(handlers.HeaderRateLimitLimit))
+
+	assert.NotEqual(t, http.StatusTooManyRequests, send(anonymous, "/products/search?q=shoe", "192.0.2.1").Code)
+	assert.Equal(t, http.StatusTooManyRequests, send(anonymous, "/products/search?q=shoe", "192.0.2.1").Code)
+}
+
+func TestMemoryStoreRateLimitFailedAuthentication(t *testing.T) {
+	limiter := services.NewRateLimiter(repositories.NewMemoryRateLimitStore(), models.RateLimit{Requests: 2, Period: time.Minute}, nil)
+	store := testutil.NewMemoryStore(t)
+	e := store.Server(handlers.Authenticate(store.Auth, store.APIKeys, limiter), handlers.RateLimit(limiter))
+
+	send := func(header, value, ip string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/products", nil)
+		req.RemoteAddr = ip + ":1234"
+		req.Header.Set(header, value)
+		return e.Serve(req)
+	}
+
+	rec := send(echo.HeaderAuthorization, "Bearer not-a-token", "192.0.2.1")
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Equal(t, "1", rec.Header().Get(handlers.HeaderRateLimitRemaining))
+	assert.Equal(t, http.StatusUnauthorized, send(utils.HeaderAPIKey, "not-a-key", "192.0.2.1").Code)
+
+	rec = send(echo.HeaderAuthorization, "Bearer not-a-token", "192.0.2.1")
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Equal(t, "30", rec.Header().Get(echo.HeaderRetryAfter))
+	assert.Equal(t, http.StatusTooManyRequests, send(echo.HeaderAccept, echo.MIMEApplicationJSON, "192.0.2.1").Code)
+
+	assert.Equal(t, http.StatusUnauthorized, send(echo.HeaderAuthorization, "Bearer not-a-token", "192.0.2.2").Code)
+}
+
+func TestIPExtractorOnlyTrustsConfiguredProxies(t *testing.T) {
+	_, gateway, _ := net.ParseCIDR("172.28.0.2/32")
+
+	realIP := func(extractor echo.IPExtractor, remote string) string {
+		req := httptest.NewRequest(http.MethodGet, "/products", nil)
+		req.RemoteAddr = remote + ":1234"
+		req.Header.Set(echo.HeaderXForwardedFor, "198.51.100.7, 203.0.113.9")
+		return extractor(req)
+	}
+
+	direct := handlers.IPExtractor(nil)
+	assert.Equal(t, "172.28.0.1", realIP(direct, "172.28.0.1"))
+
+	proxied := handlers.IPExtractor([]*net.IPNet{gateway})
+	assert.Equal(t, "203.0.113.9", realIP(proxied, "172.28.0.2"))
+	assert.Equal(t, "172.28.0.1", realIP(proxied, "172.28.0.1"))
+	assert.Equal(t, "127.0.0.1", realIP(proxied, "127.0.0.1"))
+}
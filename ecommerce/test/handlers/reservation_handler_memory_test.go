@@ -0,0 +1,51 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/YugenDev/global-mobility-test/internal/models"
+	"github.com/YugenDev/global-mobility-test/internal/utils"
+	"github.com/YugenDev/global-mobility-test/test/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryStoreReservations(t *testing.T) {
+	e := testutil.NewMemoryServer(t)
+
+	body := `{"product_id":"1","name":"Test Product","description":"Test Description","price":10,"stock":5}`
+	e.Send(http.MethodPost, "/products", body)
+
+	rec := e.Send(http.MethodPost, "/products/1/reservations", `{"quantity":4}`)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+
+	var reservation models.Reservation
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &reservation))
+	assert.NotEmpty(t, reservation.ReservationID)
+	assert.True(t, reservation.ExpiresAt.After(time.Now()))
+
+	assert.Equal(t, http.StatusConflict, e.Send(http.MethodPost, "/products/1/reservations", `{"quantity":2}`).Code)
+	assert.Equal(t, http.StatusBadRequest, e.Send(http.MethodPost, "/products/1/reservations", `{"quantity":0}`).Code)
+	assert.Equal(t, http.StatusNotFound, e.Send(http.MethodPost, "/products/2/reservations", `{"quantity":1}`).Code)
+
+	rec = e.Send(http.MethodGet, "/products/1", "")
+
+	var product map[string]interface{}
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &product))
+	assert.Equal(t, 4.0, product["reserved"])
+	assert.Equal(t, 1.0, product["available"])
+
+	rec = e.Send(http.MethodPut, "/products/1", `{"stock":3}`)
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	assert.Contains(t, rec.Body.String(), utils.ErrStockBelowReserved.Error())
+
+	assert.Equal(t, http.StatusOK, e.Send(http.MethodPost, "/reservations/"+reservation.ReservationID+"/confirm", "").Code)
+	assert.Equal(t, http.StatusConflict, e.Send(http.MethodPost, "/reservations/"+reservation.ReservationID+"/cancel", "").Code)
+	assert.Equal(t, http.StatusNotFound, e.Send(http.MethodPost, "/reservations/missing/cancel", "").Code)
+
+	rec = e.Send(http.MethodGet, "/reservations/"+reservation.ReservationID, "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"status":"confirmed"`)
+}
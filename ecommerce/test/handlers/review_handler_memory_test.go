@@ -0,0 +1,60 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/YugenDev/global-mobility-test/internal/models"
+	"github.com/YugenDev/global-mobility-test/test/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryStoreReviews(t *testing.T) {
+	e := testutil.NewMemoryServer(t)
+
+	e.Send(http.MethodPost, "/products", `{"product_id":"1","name":"Shoes","description":"Running","price":25,"stock":2,"rating_average":5,"rating_count":100}`)
+
+	rec := e.Send(http.MethodPost, "/products/1/reviews", `{"rating":4,"text":"Comfortable","author":"Sam"}`)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	var review models.Review
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &review))
+	assert.Equal(t, models.ReviewStatusPending, review.Status)
+
+	assert.Equal(t, http.StatusBadRequest, e.Send(http.MethodPost, "/products/1/reviews", `{"rating":0,"text":"Bad"}`).Code)
+	assert.Equal(t, http.StatusNotFound, e.Send(http.MethodPost, "/products/missing/reviews", `{"rating":3,"text":"Fine"}`).Code)
+
+	rec = e.Send(http.MethodGet, "/products/1/reviews", "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"reviews":[]`)
+
+	rec = e.Send(http.MethodGet, "/admin/reviews", "")
+	assert.Contains(t, rec.Body.String(), review.ReviewID)
+	assert.Equal(t, http.StatusBadRequest, e.Send(http.MethodGet, "/admin/reviews?status=spam", "").Code)
+
+	assert.Equal(t, http.StatusBadRequest, e.Send(http.MethodPut, "/admin/reviews/"+review.ReviewID+"/status", `{"status":"pending"}`).Code)
+	assert.Equal(t, http.StatusNotFound, e.Send(http.MethodPut, "/admin/reviews/missing/status", `{"status":"approved"}`).Code)
+	assert.Equal(t, http.StatusOK, e.Send(http.MethodPut, "/admin/reviews/"+review.ReviewID+"/status", `{"status":"approved"}`).Code)
+
+	rec = e.Send(http.MethodGet, "/products/1/reviews?limit=1", "")
+	assert.Contains(t, rec.Body.String(), review.ReviewID)
+
+	e.Send(http.MethodPost, "/products/1/reviews", `{"rating":1,"text":"Unmoderated"}`)
+	rec = e.Send(http.MethodPost, "/products/1/reviews", `{"rating":2,"text":"Rejected"}`)
+	var rejected models.Review
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &rejected))
+	e.Send(http.MethodPut, "/admin/reviews/"+rejected.ReviewID+"/status", `{"status":"rejected"}`)
+	for _, status := range []string{"", models.ReviewStatusPending, models.ReviewStatusRejected} {
+		rec = e.Send(http.MethodGet, "/products/1/reviews?status="+status, "")
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), review.ReviewID)
+		assert.NotContains(t, rec.Body.String(), "Unmoderated")
+		assert.NotContains(t, rec.Body.String(), "Rejected")
+	}
+	assert.Contains(t, e.Send(http.MethodGet, "/admin/reviews?status=rejected", "").Body.String(), rejected.ReviewID)
+
+	rec = e.Send(http.MethodGet, "/products/1", "")
+	assert.Contains(t, rec.Body.String(), `"rating_average":4`)
+	assert.Contains(t, rec.Body.String(), `"rating_count":1`)
+	assert.NotContains(t, rec.Body.String(), "rating_total")
+}
@@ -0,0 +1,90 @@
+package models_test
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/YugenDev/global-mobility-test/internal/models"
+	"github.com/YugenDev/global-mobility-test/internal/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func testRates() models.ExchangeRates {
+	return models.ExchangeRates{
+		Base: "USD",
+		Rates: map[string]json.Number{
+			"EUR": "0.92",
+			"GBP": "0.79",
+			"JPY": "151.37",
+			"CHF": "0.8843",
+		},
+		Rounding: map[string]models.RoundingRule{
+			"CHF": {Mode: models.RoundHalfUp, Increment: 5},
+			"JPY": {Mode: models.RoundDown, Increment: 10},
+		},
+	}
+}
+
+func TestExchangeRatesConvert(t *testing.T) {
+	rates := testRates()
+
+	tests := []struct {
+		from     models.Money
+		currency string
+		expected models.Money
+	}{
+		{models.Money{Amount: 2999, Currency: "USD"}, "EUR", models.Money{Amount: 2759, Currency: "EUR"}},
+		{models.Money{Amount: 2759, Currency: "EUR"}, "USD", models.Money{Amount: 2999, Currency: "USD"}},
+		{models.Money{Amount: 1000, Currency: "EUR"}, "GBP", models.Money{Amount: 859, Currency: "GBP"}},
+		{models.Money{Amount: 2999, Currency: "USD"}, "JPY", models.Money{Amount: 4530, Currency: "JPY"}},
+		{models.Money{Amount: 4540, Currency: "JPY"}, "USD", models.Money{Amount: 2999, Currency: "USD"}},
+		{models.Money{Amount: 1000, Currency: "USD"}, "CHF", models.Money{Amount: 885, Currency: "CHF"}},
+		{models.Money{Amount: 1000, Currency: "USD"}, "USD", models.Money{Amount: 1000, Currency: "USD"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.from.Currency+"->"+tt.currency, func(t *testing.T) {
+			converted, err := rates.Convert(tt.from, tt.currency)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, converted)
+		})
+	}
+}
+
+func TestExchangeRatesConvertErrors(t *testing.T) {
+	rates := testRates()
+
+	_, err := rates.Convert(models.Money{Amount: 100, Currency: "USD"}, "MXN")
+	assert.Equal(t, utils.ErrExchangeRateNotFound, err)
+
+	_, err = rates.Convert(models.Money{Amount: 100, Currency: "MXN"}, "USD")
+	assert.Equal(t, utils.ErrExchangeRateNotFound, err)
+
+	_, err = rates.Convert(models.Money{Amount: 100, Currency: "USD"}, "XYZ")
+	assert.Equal(t, utils.ErrUnsupportedCurrency, err)
+}
+
+func TestRoundingRuleModes(t *testing.T) {
+	tests := []struct {
+		rule     models.RoundingRule
+		value    *big.Rat
+		expected int64
+	}{
+		{models.RoundingRule{}, big.NewRat(25, 10), 3},
+		{models.RoundingRule{}, big.NewRat(-25, 10), -3},
+		{models.RoundingRule{Mode: models.RoundHalfEven}, big.NewRat(25, 10), 2},
+		{models.RoundingRule{Mode: models.RoundHalfEven}, big.NewRat(35, 10), 4},
+		{models.RoundingRule{Mode: models.RoundHalfEven}, big.NewRat(251, 100), 3},
+		{models.RoundingRule{Mode: models.RoundDown}, big.NewRat(29, 10), 2},
+		{models.RoundingRule{Mode: models.RoundUp}, big.NewRat(21, 10), 3},
+		{models.RoundingRule{Mode: models.RoundUp}, big.NewRat(2, 1), 2},
+		{models.RoundingRule{Mode: models.RoundHalfUp, Increment: 5}, big.NewRat(1237, 1), 1235},
+		{models.RoundingRule{Mode: models.RoundHalfUp, Increment: 5}, big.NewRat(12375, 10), 1240},
+		{models.RoundingRule{Mode: models.RoundUp, Increment: 100}, big.NewRat(1201, 1), 1300},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, tt.rule.Round(tt.value), "%s %s", tt.rule.Mode, tt.value.RatString())
+	}
+}
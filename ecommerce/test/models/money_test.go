@@ -0,0 +1,125 @@
+package models_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/YugenDev/global-mobility-test/internal/models"
+	"github.com/YugenDev/global-mobility-test/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		value    string
+		currency string
+		expected models.Money
+		err      error
+	}{
+		{"29.99", "USD", models.Money{Amount: 2999, Currency: "USD"}, nil},
+		{"29.9", "usd", models.Money{Amount: 2990, Currency: "USD"}, nil},
+		{"29", "EUR", models.Money{Amount: 2900, Currency: "EUR"}, nil},
+		{"1500", "JPY", models.Money{Amount: 1500, Currency: "JPY"}, nil},
+		{"1.250", "KWD", models.Money{Amount: 1250, Currency: "KWD"}, nil},
+		{"-0.05", "USD", models.Money{Amount: -5, Currency: "USD"}, nil},
+		{"29.999", "USD", models.Money{}, utils.ErrInvalidMoneyAmount},
+		{"15.5", "JPY", models.Money{}, utils.ErrInvalidMoneyAmount},
+		{"12.", "USD", models.Money{}, utils.ErrInvalidMoneyAmount},
+		{".5", "USD", models.Money{}, utils.ErrInvalidMoneyAmount},
+		{"1e3", "USD", models.Money{}, utils.ErrInvalidMoneyAmount},
+		{"10", "XYZ", models.Money{}, utils.ErrUnsupportedCurrency},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value+" "+tt.currency, func(t *testing.T) {
+			money, err := models.ParseMoney(tt.value, tt.currency)
+			assert.Equal(t, tt.err, err)
+			assert.Equal(t, tt.expected, money)
+		})
+	}
+}
+
+func TestMoneyString(t *testing.T) {
+	assert.Equal(t, "29.99", models.Money{Amount: 2999, Currency: "USD"}.String())
+	assert.Equal(t, "0.05", models.Money{Amount: 5, Currency: "USD"}.String())
+	assert.Equal(t, "-1.50", models.Money{Amount: -150, Currency: "EUR"}.String())
+	assert.Equal(t, "1500", models.Money{Amount: 1500, Currency: "JPY"}.String())
+	assert.Equal(t, "0.001", models.Money{Amount: 1, Currency: "KWD"}.String())
+}
+
+func TestMoneyArithmeticIsExact(t *testing.T) {
+	price := models.Money{Amount: 10, Currency: "USD"}
+
+	total := models.Money{Currency: "USD"}
+	for i := 0; i < 3; i++ {
+		total = total.Plus(price)
+	}
+
+	assert.Equal(t, "0.30", total.String())
+	assert.Equal(t, models.Money{Amount: 30, Currency: "USD"}, price.Times(3))
+}
+
+func TestMoneyJSON(t *testing.T) {
+	data, err := json.Marshal(models.Money{Amount: 2999, Currency: "USD"})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"amount":"29.99","currency":"USD"}`, string(data))
+
+	inputs := map[string]models.Money{
+		`{"amount":"19.99","currency":"EUR"}`: {Amount: 1999, Currency: "EUR"},
+		`{"amount":19.99,"currency":"eur"}`:   {Amount: 1999, Currency: "EUR"},
+		`{"amount":"19.99"}`:                  {Amount: 1999, Currency: "USD"},
+		`"19.99"`:                             {Amount: 1999, Currency: "USD"},
+		`0.3`:                                 {Amount: 30, Currency: "USD"},
+	}
+	for input, expected := range inputs {
+		var money models.Money
+		assert.NoError(t, json.Unmarshal([]byte(input), &money), input)
+		assert.Equal(t, expected, money, input)
+	}
+
+	var money models.Money
+	assert.ErrorIs(t, json.Unmarshal([]byte(`"19.999"`), &money), utils.ErrInvalidMoneyAmount)
+	assert.ErrorIs(t, json.Unmarshal([]byte(`{"amount":"1","currency":"XYZ"}`), &money), utils.ErrUnsupportedCurrency)
+	assert.Error(t, json.Unmarshal([]byte(`true`), &money))
+}
+
+func TestMoneyBSON(t *testing.T) {
+	data, err := bson.Marshal(models.Product{Price: models.Money{Amount: 2999, Currency: "EUR"}})
+	assert.NoError(t, err)
+
+	var product models.Product
+	assert.NoError(t, bson.Unmarshal(data, &product))
+	assert.Equal(t, models.Money{Amount: 2999, Currency: "EUR"}, product.Price)
+
+	data, err = bson.Marshal(models.Product{Name: "No price"})
+	assert.NoError(t, err)
+	raw := bson.Raw(data)
+	_, err = raw.LookupErr("price")
+	assert.Error(t, err, "an unset price is omitted")
+}
+
+func TestMoneyBSONReadsLegacyNumbers(t *testing.T) {
+	legacy := []bson.M{
+		{"price": 29.99, "variants": bson.A{bson.M{"sku": "A", "price": 0.1}}},
+		{"price": int32(30)},
+		{"price": int64(30)},
+	}
+	expected := []models.Money{
+		{Amount: 2999, Currency: "USD"},
+		{Amount: 3000, Currency: "USD"},
+		{Amount: 3000, Currency: "USD"},
+	}
+
+	for i, document := range legacy {
+		data, err := bson.Marshal(document)
+		assert.NoError(t, err)
+
+		var product models.Product
+		assert.NoError(t, bson.Unmarshal(data, &product))
+		assert.Equal(t, expected[i], product.Price)
+		if i == 0 {
+			assert.Equal(t, &models.Money{Amount: 10, Currency: "USD"}, product.Variants[0].Price)
+		}
+	}
+}
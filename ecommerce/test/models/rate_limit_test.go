@@ -0,0 +1,44 @@
+package models_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/YugenDev/global-mobility-test/internal/models"
+	"github.com/YugenDev/global-mobility-test/internal/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRateLimit(t *testing.T) {
+	limit, err := models.ParseRateLimit(" 120/1m ")
+	assert.NoError(t, err)
+	assert.Equal(t, models.RateLimit{Requests: 120, Period: time.Minute}, limit)
+
+	limit, err = models.ParseRateLimit("off")
+	assert.NoError(t, err)
+	assert.True(t, limit.Unlimited())
+
+	for _, value := range []string{"", "120", "0/1m", "-1/1m", "10/0s", "ten/1m", "10/minute"} {
+		_, err := models.ParseRateLimit(value)
+		assert.Equal(t, utils.ErrInvalidRateLimit, err, value)
+	}
+}
+
+func TestParseRateLimitRoutes(t *testing.T) {
+	routes, err := models.ParseRateLimitRoutes("get /products=20/1m, POST /auth/login=5/1m,GET /healthcheck=off")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]models.RateLimit{
+		"GET /products":    {Requests: 20, Period: time.Minute},
+		"POST /auth/login": {Requests: 5, Period: time.Minute},
+		"GET /healthcheck": {},
+	}, routes)
+
+	routes, err = models.ParseRateLimitRoutes("")
+	assert.NoError(t, err)
+	assert.Empty(t, routes)
+
+	_, err = models.ParseRateLimitRoutes("/products=20/1m")
+	assert.Equal(t, utils.ErrInvalidRateLimit, err)
+	_, err = models.ParseRateLimitRoutes("GET /products")
+	assert.Equal(t, utils.ErrInvalidRateLimit, err)
+}
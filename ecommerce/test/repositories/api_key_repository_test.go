@@ -0,0 +1,44 @@
+package repositories_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/YugenDev/global-mobility-test/internal/models"
+	"github.com/YugenDev/global-mobility-test/internal/repositories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestRevokeAPIKeyOnlyMatchesActiveKeys(t *testing.T) {
+	mockCollection := new(MockCollection)
+	repo := repositories.APIKeyRepository{Collection: mockCollection}
+
+	filter := bson.M{"key_id": "k-1", "revoked_at": bson.M{"$exists": false}}
+	mockCollection.On("UpdateOne", mock.Anything, filter, mock.Anything).Return(&mongo.UpdateResult{MatchedCount: 1}, nil)
+
+	result, err := repo.RevokeAPIKey("k-1", time.Now())
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), result.MatchedCount)
+	mockCollection.AssertExpectations(t)
+}
+
+func TestMemoryAPIKeys(t *testing.T) {
+	repo := repositories.NewMemoryAPIKeyRepository()
+	assert.NoError(t, repo.CreateAPIKey(&models.APIKey{KeyID: "k-1", KeyHash: "hash"}))
+
+	now := time.Now()
+	repo.TouchAPIKey("k-1", now)
+	repo.TouchAPIKey("k-1", now.Add(-time.Hour))
+	key, err := repo.GetAPIKeyByHash("hash")
+	assert.NoError(t, err)
+	assert.True(t, key.LastUsedAt.Equal(now))
+
+	result, _ := repo.RevokeAPIKey("k-1", now)
+	assert.Equal(t, int64(1), result.MatchedCount)
+	result, _ = repo.RevokeAPIKey("k-1", now.Add(time.Hour))
+	assert.Equal(t, int64(0), result.MatchedCount)
+}
@@ -0,0 +1,88 @@
+package repositories_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/YugenDev/global-mobility-test/internal/models"
+	"github.com/YugenDev/global-mobility-test/internal/repositories"
+	"github.com/YugenDev/global-mobility-test/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestCreateAuditEntry(t *testing.T) {
+	mockCollection := new(MockCollection)
+	repo := repositories.AuditRepository{Collection: mockCollection}
+
+	entry := &models.ProductAuditEntry{AuditID: "a1", ProductID: "p1", Action: models.AuditActionUpdate}
+	mockCollection.On("InsertOne", mock.Anything, entry).Return(&mongo.InsertOneResult{}, nil)
+
+	assert.NoError(t, repo.CreateEntry(entry))
+	mockCollection.AssertExpectations(t)
+}
+
+func TestCreateAuditEntry_DatabaseError(t *testing.T) {
+	mockCollection := new(MockCollection)
+	repo := repositories.AuditRepository{Collection: mockCollection}
+
+	expectedError := errors.New("database error")
+	mockCollection.On("InsertOne", mock.Anything, mock.Anything).Return(nil, expectedError)
+
+	assert.Equal(t, expectedError, repo.CreateEntry(&models.ProductAuditEntry{}))
+}
+
+func TestGetAuditEntriesByProductID(t *testing.T) {
+	mockCollection := new(MockCollection)
+	repo := repositories.AuditRepository{Collection: mockCollection}
+
+	docs := []interface{}{
+		models.ProductAuditEntry{AuditID: "a2", ProductID: "p1", Action: models.AuditActionUpdate},
+		models.ProductAuditEntry{AuditID: "a1", ProductID: "p1", Action: models.AuditActionCreate},
+	}
+	cursor, err := mongo.NewCursorFromDocuments(docs, nil, nil)
+	assert.NoError(t, err)
+
+	mockCollection.On("Find", mock.Anything, bson.M{"product_id": "p1"}).Return(cursor, nil)
+
+	entries, err := repo.GetEntriesByProductID(models.AuditQuery{ProductID: "p1"})
+
+	assert.NoError(t, err)
+	assert.Len(t, entries, 2)
+	assert.Equal(t, "a2", entries[0].AuditID)
+	mockCollection.AssertExpectations(t)
+}
+
+func TestGetAuditEntriesByProductID_EmptyID(t *testing.T) {
+	repo := repositories.AuditRepository{Collection: new(MockCollection)}
+
+	_, err := repo.GetEntriesByProductID(models.AuditQuery{})
+	assert.Equal(t, utils.ErrProductIDRequired, err)
+}
+
+func TestMemoryAuditRepository_NewestFirst(t *testing.T) {
+	repo := repositories.NewMemoryAuditRepository()
+	now := time.Now()
+
+	repo.CreateEntry(&models.ProductAuditEntry{AuditID: "a1", ProductID: "p1", CreatedAt: now})
+	repo.CreateEntry(&models.ProductAuditEntry{AuditID: "a2", ProductID: "p1", CreatedAt: now.Add(time.Second)})
+	repo.CreateEntry(&models.ProductAuditEntry{AuditID: "a3", ProductID: "p2", CreatedAt: now})
+	repo.CreateEntry(&models.ProductAuditEntry{AuditID: "a4", ProductID: "p1", CreatedAt: now.Add(2 * time.Second)})
+
+	entries, err := repo.GetEntriesByProductID(models.AuditQuery{ProductID: "p1", Limit: 2})
+	assert.NoError(t, err)
+	assert.Len(t, entries, 2)
+	assert.Equal(t, "a4", entries[0].AuditID)
+	assert.Equal(t, "a2", entries[1].AuditID)
+
+	entries, err = repo.GetEntriesByProductID(models.AuditQuery{
+		ProductID: "p1",
+		After:     &models.AuditCursor{CreatedAt: entries[1].CreatedAt, AuditID: entries[1].AuditID},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+	assert.Equal(t, "a1", entries[0].AuditID)
+}
@@ -0,0 +1,40 @@
+package repositories_test
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/YugenDev/global-mobility-test/internal/repositories"
+	"github.com/YugenDev/global-mobility-test/internal/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocalBlobStore(t *testing.T) {
+	store := repositories.NewLocalBlobStore(t.TempDir())
+	ctx := context.Background()
+
+	assert.NoError(t, store.Put(ctx, "products/1/image.png", strings.NewReader("content")))
+
+	file, err := store.Open(ctx, "products/1/image.png")
+	assert.NoError(t, err)
+	content, _ := io.ReadAll(file)
+	file.Close()
+	assert.Equal(t, "content", string(content))
+
+	assert.NoError(t, store.Delete(ctx, "products/1/image.png"))
+	assert.NoError(t, store.Delete(ctx, "products/1/image.png"))
+
+	_, err = store.Open(ctx, "products/1/image.png")
+	assert.Equal(t, utils.ErrBlobNotFound, err)
+}
+
+func TestLocalBlobStore_RejectsEscapingKeys(t *testing.T) {
+	store := repositories.NewLocalBlobStore(t.TempDir())
+	ctx := context.Background()
+
+	for _, key := range []string{"", "/etc/passwd", "../outside", "products/../../outside", "products//image"} {
+		assert.Equal(t, utils.ErrInvalidBlobKey, store.Put(ctx, key, strings.NewReader("x")), key)
+	}
+}
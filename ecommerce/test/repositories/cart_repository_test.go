@@ -0,0 +1,70 @@
+package repositories_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/YugenDev/global-mobility-test/internal/models"
+	"github.com/YugenDev/global-mobility-test/internal/repositories"
+	"github.com/YugenDev/global-mobility-test/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestUpdateCart(t *testing.T) {
+	mockCollection := new(MockCollection)
+	repo := repositories.CartRepository{Collection: mockCollection}
+
+	mockResult := &mongo.UpdateResult{MatchedCount: 1, ModifiedCount: 1}
+	mockCollection.On("UpdateOne", mock.Anything, bson.M{"cart_id": "cart-1", "version": 2}, mock.Anything).Return(mockResult, nil)
+
+	cart := &models.Cart{CartID: "cart-1", Version: 2}
+	result, err := repo.UpdateCart(cart)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), result.MatchedCount)
+	assert.Equal(t, 3, cart.Version)
+	mockCollection.AssertExpectations(t)
+}
+
+func TestGetCartByID_NotFound(t *testing.T) {
+	mockCollection := new(MockCollection)
+	repo := repositories.CartRepository{Collection: mockCollection}
+
+	mockSingleResult := mongo.NewSingleResultFromDocument(bson.M{}, mongo.ErrNoDocuments, nil)
+	mockCollection.On("FindOne", mock.Anything, mock.Anything).Return(mockSingleResult)
+
+	_, err := repo.GetCartByID("missing")
+
+	assert.Equal(t, utils.ErrCartNotFound, err)
+}
+
+func TestMemoryCartExpires(t *testing.T) {
+	repo := repositories.NewMemoryCartRepository()
+
+	repo.CreateCart(&models.Cart{CartID: "live", ExpiresAt: time.Now().Add(time.Hour)})
+	repo.CreateCart(&models.Cart{CartID: "stale", ExpiresAt: time.Now().Add(-time.Second)})
+
+	_, err := repo.GetCartByID("live")
+	assert.NoError(t, err)
+
+	_, err = repo.GetCartByID("stale")
+	assert.Equal(t, utils.ErrCartNotFound, err)
+}
+
+func TestMemoryUpdateCart_VersionMismatch(t *testing.T) {
+	repo := repositories.NewMemoryCartRepository()
+
+	repo.CreateCart(&models.Cart{CartID: "cart-1", ExpiresAt: time.Now().Add(time.Hour)})
+
+	first, _ := repo.GetCartByID("cart-1")
+	second, _ := repo.GetCartByID("cart-1")
+
+	result, _ := repo.UpdateCart(&first)
+	assert.Equal(t, int64(1), result.MatchedCount)
+
+	result, _ = repo.UpdateCart(&second)
+	assert.Equal(t, int64(0), result.MatchedCount)
+}
@@ -0,0 +1,97 @@
+package repositories_test
+
+import (
+	"testing"
+
+	"github.com/YugenDev/global-mobility-test/internal/models"
+	"github.com/YugenDev/global-mobility-test/internal/repositories"
+	"github.com/YugenDev/global-mobility-test/internal/utils"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestGetCategoryByID_NotFound(t *testing.T) {
+	mockCollection := new(MockCollection)
+	repo := repositories.CategoryRepository{Collection: mockCollection}
+
+	mockSingleResult := mongo.NewSingleResultFromDocument(bson.M{}, mongo.ErrNoDocuments, nil)
+	mockCollection.On("FindOne", mock.Anything, bson.M{"category_id": "missing"}).Return(mockSingleResult)
+
+	_, err := repo.GetCategoryByID("missing")
+
+	assert.Equal(t, utils.ErrCategoryNotFound, err)
+}
+
+func TestUpdateCategory_MovesToRoot(t *testing.T) {
+	mockCollection := new(MockCollection)
+	repo := repositories.CategoryRepository{Collection: mockCollection}
+
+	mockResult := &mongo.UpdateResult{MatchedCount: 1}
+	mockCollection.On("UpdateOne", mock.Anything, bson.M{"category_id": "shoes"}, mock.MatchedBy(func(update bson.M) bool {
+		_, unset := update["$unset"]
+		return unset
+	})).Return(mockResult, nil)
+
+	result, err := repo.UpdateCategory(&models.Category{CategoryID: "shoes", Name: "Shoes", Slug: "shoes"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), result.MatchedCount)
+	mockCollection.AssertExpectations(t)
+}
+
+func TestReassignCategory(t *testing.T) {
+	mockCollection := new(MockCollection)
+	repo := repositories.ProductRepository{Collection: mockCollection}
+
+	before := models.Product{ProductID: "1", CategoryIDs: []string{"old"}, Version: 2}
+	mockCollection.On("FindOneAndUpdate", mock.Anything, bson.M{"product_id": "1", "category_ids": "old"}, mock.Anything).Return(mongo.NewSingleResultFromDocument(before, nil, nil))
+	mockCollection.On("FindOneAndUpdate", mock.Anything, bson.M{"product_id": "2", "category_ids": "old"}, mock.Anything).Return(mongo.NewSingleResultFromDocument(bson.M{}, mongo.ErrNoDocuments, nil))
+
+	product, err := repo.ReassignCategory("1", "old", "new")
+	assert.NoError(t, err)
+	assert.Equal(t, before, product)
+
+	_, err = repo.ReassignCategory("2", "old", "new")
+	assert.Equal(t, mongo.ErrNoDocuments, err)
+	mockCollection.AssertExpectations(t)
+}
+
+func TestMemoryCategory_UniqueSlug(t *testing.T) {
+	repo := repositories.NewMemoryCategoryRepository()
+
+	assert.NoError(t, repo.CreateCategory(&models.Category{CategoryID: "1", Name: "Shoes", Slug: "shoes"}))
+	assert.Equal(t, utils.ErrCategorySlugExists, repo.CreateCategory(&models.Category{CategoryID: "2", Name: "Shoes", Slug: "shoes"}))
+
+	assert.NoError(t, repo.CreateCategory(&models.Category{CategoryID: "2", Name: "Hats", Slug: "hats"}))
+	_, err := repo.UpdateCategory(&models.Category{CategoryID: "2", Name: "Hats", Slug: "shoes"})
+	assert.Equal(t, utils.ErrCategorySlugExists, err)
+}
+
+func TestMemoryReassignCategory(t *testing.T) {
+	repo := repositories.NewMemoryProductRepository()
+	c := echo.New().NewContext(nil, nil)
+
+	repo.CreateProduct(c, &models.Product{ProductID: "1", Name: "Shoes", CategoryIDs: []string{"old", "new"}})
+	repo.CreateProduct(c, &models.Product{ProductID: "2", Name: "Hat", CategoryIDs: []string{"old"}})
+	repo.CreateProduct(c, &models.Product{ProductID: "3", Name: "Bag", CategoryIDs: []string{"other"}})
+
+	for _, id := range []string{"1", "2"} {
+		before, err := repo.ReassignCategory(id, "old", "new")
+		assert.NoError(t, err)
+		assert.Contains(t, before.CategoryIDs, "old")
+	}
+	_, err := repo.ReassignCategory("3", "old", "new")
+	assert.Equal(t, mongo.ErrNoDocuments, err)
+	_, err = repo.ReassignCategory("1", "old", "new")
+	assert.Equal(t, mongo.ErrNoDocuments, err)
+
+	first, _ := repo.GetProductByID("1")
+	second, _ := repo.GetProductByID("2")
+	third, _ := repo.GetProductByID("3")
+	assert.Equal(t, []string{"new"}, first.CategoryIDs)
+	assert.Equal(t, []string{"new"}, second.CategoryIDs)
+	assert.Equal(t, []string{"other"}, third.CategoryIDs)
+}
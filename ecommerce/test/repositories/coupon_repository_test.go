@@ -0,0 +1,70 @@
+package repositories_test
+
+import (
+	"testing"
+
+	"github.com/YugenDev/global-mobility-test/internal/models"
+	"github.com/YugenDev/global-mobility-test/internal/repositories"
+	"github.com/YugenDev/global-mobility-test/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestIncrementCouponUsage(t *testing.T) {
+	mockCollection := new(MockCollection)
+	repo := repositories.CouponRepository{Collection: mockCollection}
+
+	mockCollection.On("UpdateOne", mock.Anything, mock.MatchedBy(func(filter bson.M) bool {
+		return filter["coupon_id"] == "c-1" && len(filter["$or"].(bson.A)) == 2
+	}), bson.M{"$inc": bson.M{"usage_count": 1}}).Return(&mongo.UpdateResult{}, nil)
+
+	result, err := repo.IncrementUsage("c-1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), result.MatchedCount)
+	mockCollection.AssertExpectations(t)
+}
+
+func TestUpdateCouponUnsetsClearedRules(t *testing.T) {
+	mockCollection := new(MockCollection)
+	repo := repositories.CouponRepository{Collection: mockCollection}
+
+	mockCollection.On("UpdateOne", mock.Anything, bson.M{"coupon_id": "c-1"}, mock.MatchedBy(func(update bson.M) bool {
+		set := update["$set"].(bson.M)
+		unset := update["$unset"].(bson.M)
+		_, countSet := set["usage_count"]
+		_, amountUnset := unset["amount"]
+		return set["percent"] == 10 && amountUnset && !countSet
+	})).Return(&mongo.UpdateResult{MatchedCount: 1}, nil)
+
+	_, err := repo.UpdateCoupon(&models.Coupon{CouponID: "c-1", Code: "SAVE10", Type: models.CouponTypePercentage, Percent: 10})
+
+	assert.NoError(t, err)
+	mockCollection.AssertExpectations(t)
+}
+
+func TestMemoryCouponUsage(t *testing.T) {
+	repo := repositories.NewMemoryCouponRepository()
+
+	assert.NoError(t, repo.CreateCoupon(&models.Coupon{CouponID: "c-1", Code: "ONCE", UsageLimit: 1}))
+	assert.Equal(t, utils.ErrCouponCodeExists, repo.CreateCoupon(&models.Coupon{CouponID: "c-2", Code: "ONCE"}))
+
+	result, _ := repo.IncrementUsage("c-1")
+	assert.Equal(t, int64(1), result.MatchedCount)
+	result, _ = repo.IncrementUsage("c-1")
+	assert.Equal(t, int64(0), result.MatchedCount)
+
+	result, _ = repo.DecrementUsage("c-1")
+	assert.Equal(t, int64(1), result.MatchedCount)
+	result, _ = repo.DecrementUsage("c-1")
+	assert.Equal(t, int64(0), result.MatchedCount)
+
+	coupon, err := repo.GetCouponByCode("ONCE")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, coupon.UsageCount)
+
+	_, err = repo.GetCouponByCode("MISSING")
+	assert.Equal(t, utils.ErrCouponNotFound, err)
+}
@@ -0,0 +1,73 @@
+package repositories_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/YugenDev/global-mobility-test/internal/models"
+	"github.com/YugenDev/global-mobility-test/internal/repositories"
+	"github.com/YugenDev/global-mobility-test/internal/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileExchangeRateRepository(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rates", "exchange-rates.json")
+
+	repo, err := repositories.NewFileExchangeRateRepository(path)
+	assert.NoError(t, err)
+
+	rates, err := repo.GetRates()
+	assert.NoError(t, err)
+	assert.Equal(t, models.DefaultCurrency, rates.Base)
+	assert.Empty(t, rates.Rates)
+
+	saved := models.ExchangeRates{
+		Base:     "USD",
+		Rates:    map[string]json.Number{"EUR": "0.92"},
+		Rounding: map[string]models.RoundingRule{"EUR": {Mode: models.RoundHalfEven}},
+	}
+	assert.NoError(t, repo.SaveRates(saved))
+
+	saved.Rates["EUR"] = "5"
+	rates, _ = repo.GetRates()
+	assert.Equal(t, json.Number("0.92"), rates.Rates["EUR"])
+
+	reloaded, err := repositories.NewFileExchangeRateRepository(path)
+	assert.NoError(t, err)
+	rates, _ = reloaded.GetRates()
+	assert.Equal(t, json.Number("0.92"), rates.Rates["EUR"])
+	assert.Equal(t, models.RoundHalfEven, rates.Rounding["EUR"].Mode)
+}
+
+func TestFileExchangeRateRepository_RejectsInvalidFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "exchange-rates.json")
+	assert.NoError(t, os.WriteFile(path, []byte("not json"), 0o644))
+
+	_, err := repositories.NewFileExchangeRateRepository(path)
+	assert.Error(t, err)
+
+	for file, expected := range map[string]error{
+		`{"base":"USD","rates":{"EUR":"0"}}`:                              utils.ErrInvalidExchangeRate,
+		`{"base":"USD","rates":{"EUR":"-0.92"}}`:                          utils.ErrInvalidExchangeRate,
+		`{"base":"USD","rates":{"XXX":"2"}}`:                              utils.ErrUnsupportedCurrency,
+		`{"base":"USD","rates":{},"rounding":{"EUR":{"mode":"nearest"}}}`: utils.ErrInvalidRoundingRule,
+	} {
+		assert.NoError(t, os.WriteFile(path, []byte(file), 0o644))
+		_, err = repositories.NewFileExchangeRateRepository(path)
+		assert.Equal(t, expected, err, file)
+	}
+}
+
+func TestFileExchangeRateRepository_NormalizesFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "exchange-rates.json")
+	assert.NoError(t, os.WriteFile(path, []byte(`{"base":"usd","rates":{"eur":"0.92"},"rounding":{"chf":{"increment":5}}}`), 0o644))
+
+	repo, err := repositories.NewFileExchangeRateRepository(path)
+	assert.NoError(t, err)
+	rates, _ := repo.GetRates()
+	assert.Equal(t, "USD", rates.Base)
+	assert.Equal(t, json.Number("0.92"), rates.Rates["EUR"])
+	assert.Equal(t, models.RoundingRule{Mode: models.RoundHalfUp, Increment: 5}, rates.Rounding["CHF"])
+}
@@ -0,0 +1,50 @@
+package repositories_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/YugenDev/global-mobility-test/internal/models"
+	"github.com/YugenDev/global-mobility-test/internal/repositories"
+	"github.com/YugenDev/global-mobility-test/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestClaimIdempotencyKeyTaken(t *testing.T) {
+	mockCollection := new(MockCollection)
+	repo := repositories.IdempotencyRepository{Collection: mockCollection}
+
+	now := time.Now()
+	filter := bson.M{"key": "ip:1|k", "expires_at": bson.M{"$lte": now}}
+	duplicate := mongo.WriteException{WriteErrors: []mongo.WriteError{{Code: 11000}}}
+	mockCollection.On("UpdateOne", mock.Anything, filter, mock.Anything).Return(nil, duplicate)
+
+	err := repo.ClaimIdempotencyKey(&models.IdempotencyRecord{Key: "ip:1|k", CreatedAt: now, ExpiresAt: now.Add(time.Minute)})
+
+	assert.Equal(t, utils.ErrIdempotencyKeyExists, err)
+	mockCollection.AssertExpectations(t)
+}
+
+func TestMemoryIdempotencyRecords(t *testing.T) {
+	repo := repositories.NewMemoryIdempotencyRepository()
+	now := time.Now()
+
+	record := models.IdempotencyRecord{Key: "k", Fingerprint: "a", CreatedAt: now, ExpiresAt: now.Add(time.Minute)}
+	assert.NoError(t, repo.ClaimIdempotencyKey(&record))
+	assert.Equal(t, utils.ErrIdempotencyKeyExists, repo.ClaimIdempotencyKey(&record))
+
+	result, _ := repo.CompleteIdempotencyRecord(&models.IdempotencyRecord{Key: "k", Fingerprint: "b", Status: 201})
+	assert.Equal(t, int64(0), result.MatchedCount)
+	result, _ = repo.CompleteIdempotencyRecord(&models.IdempotencyRecord{Key: "k", Fingerprint: "a", Status: 201, Body: []byte("{}"), ExpiresAt: now.Add(time.Hour)})
+	assert.Equal(t, int64(1), result.MatchedCount)
+
+	stored, err := repo.GetIdempotencyRecord("k")
+	assert.NoError(t, err)
+	assert.Equal(t, 201, stored.Status)
+
+	later := models.IdempotencyRecord{Key: "k", Fingerprint: "c", CreatedAt: now.Add(2 * time.Hour), ExpiresAt: now.Add(3 * time.Hour)}
+	assert.NoError(t, repo.ClaimIdempotencyKey(&later))
+}
@@ -0,0 +1,60 @@
+package repositories_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/YugenDev/global-mobility-test/internal/models"
+	"github.com/YugenDev/global-mobility-test/internal/repositories"
+	"github.com/YugenDev/global-mobility-test/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestTransitionOrder(t *testing.T) {
+	mockCollection := new(MockCollection)
+	repo := repositories.OrderRepository{Collection: mockCollection}
+
+	at := time.Now()
+	mockResult := &mongo.UpdateResult{MatchedCount: 1, ModifiedCount: 1}
+	mockCollection.On("UpdateOne", mock.Anything, bson.M{"order_id": "order-1", "status": models.OrderStatusPending}, bson.M{
+		"$set":  bson.M{"status": models.OrderStatusPaid, "updated_at": at},
+		"$push": bson.M{"history": models.OrderStatusChange{Status: models.OrderStatusPaid, At: at}},
+	}).Return(mockResult, nil)
+
+	result, err := repo.TransitionOrder("order-1", models.OrderStatusPending, models.OrderStatusPaid, at)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), result.MatchedCount)
+	mockCollection.AssertExpectations(t)
+}
+
+func TestGetOrderByID_NotFound(t *testing.T) {
+	mockCollection := new(MockCollection)
+	repo := repositories.OrderRepository{Collection: mockCollection}
+
+	mockSingleResult := mongo.NewSingleResultFromDocument(bson.M{}, mongo.ErrNoDocuments, nil)
+	mockCollection.On("FindOne", mock.Anything, bson.M{"order_id": "missing"}).Return(mockSingleResult)
+
+	_, err := repo.GetOrderByID("missing")
+
+	assert.Equal(t, utils.ErrOrderNotFound, err)
+}
+
+func TestMemoryTransitionOrder(t *testing.T) {
+	repo := repositories.NewMemoryOrderRepository()
+
+	repo.CreateOrder(&models.Order{OrderID: "order-1", Status: models.OrderStatusPending})
+
+	result, _ := repo.TransitionOrder("order-1", models.OrderStatusPending, models.OrderStatusPaid, time.Now())
+	assert.Equal(t, int64(1), result.MatchedCount)
+
+	result, _ = repo.TransitionOrder("order-1", models.OrderStatusPending, models.OrderStatusCancelled, time.Now())
+	assert.Equal(t, int64(0), result.MatchedCount)
+
+	order, _ := repo.GetOrderByID("order-1")
+	assert.Equal(t, models.OrderStatusPaid, order.Status)
+	assert.Len(t, order.History, 1)
+}
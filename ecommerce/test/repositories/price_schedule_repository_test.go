@@ -0,0 +1,83 @@
+package repositories_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/YugenDev/global-mobility-test/internal/models"
+	"github.com/YugenDev/global-mobility-test/internal/repositories"
+	"github.com/YugenDev/global-mobility-test/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestTransitionPriceSchedule(t *testing.T) {
+	mockCollection := new(MockCollection)
+	repo := repositories.PriceScheduleRepository{Collection: mockCollection}
+
+	revert := models.Money{Amount: 2999, Currency: "USD"}
+	mockResult := &mongo.UpdateResult{MatchedCount: 1, ModifiedCount: 1}
+	mockCollection.On("UpdateOne", mock.Anything, bson.M{"schedule_id": "s-1", "status": models.PriceScheduleStatusPending}, mock.MatchedBy(func(update bson.M) bool {
+		set := update["$set"].(bson.M)
+		return set["status"] == models.PriceScheduleStatusActive && set["revert_price"] == revert
+	})).Return(mockResult, nil)
+
+	result, err := repo.TransitionSchedule("s-1", models.PriceScheduleStatusPending, models.PriceScheduleStatusActive, &revert)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), result.MatchedCount)
+	mockCollection.AssertExpectations(t)
+}
+
+func TestGetSchedulesToEnd(t *testing.T) {
+	mockCollection := new(MockCollection)
+	repo := repositories.PriceScheduleRepository{Collection: mockCollection}
+
+	now := time.Now()
+	cursor, err := mongo.NewCursorFromDocuments([]interface{}{models.PriceSchedule{ScheduleID: "s-1"}}, nil, nil)
+	assert.NoError(t, err)
+	mockCollection.On("Find", mock.Anything, bson.M{"status": models.PriceScheduleStatusActive, "ends_at": bson.M{"$lte": now}}).Return(cursor, nil)
+
+	schedules, err := repo.GetSchedulesToEnd(now, 10)
+
+	assert.NoError(t, err)
+	assert.Len(t, schedules, 1)
+	mockCollection.AssertExpectations(t)
+}
+
+func TestMemoryPriceScheduleTransitions(t *testing.T) {
+	repo := repositories.NewMemoryPriceScheduleRepository()
+
+	now := time.Now()
+	end := now.Add(time.Hour)
+	repo.CreateSchedule(&models.PriceSchedule{ScheduleID: "later", ProductID: "1", Status: models.PriceScheduleStatusPending, StartsAt: now.Add(-time.Minute), EndsAt: &end})
+	repo.CreateSchedule(&models.PriceSchedule{ScheduleID: "first", ProductID: "1", Status: models.PriceScheduleStatusPending, StartsAt: now.Add(-time.Hour)})
+	repo.CreateSchedule(&models.PriceSchedule{ScheduleID: "future", ProductID: "1", Status: models.PriceScheduleStatusPending, StartsAt: now.Add(time.Hour)})
+
+	due, err := repo.GetSchedulesToStart(now, 0)
+	assert.NoError(t, err)
+	if assert.Len(t, due, 2) {
+		assert.Equal(t, "first", due[0].ScheduleID)
+		assert.Equal(t, "later", due[1].ScheduleID)
+	}
+
+	revert := models.Money{Amount: 1000, Currency: "USD"}
+	result, _ := repo.TransitionSchedule("later", models.PriceScheduleStatusPending, models.PriceScheduleStatusActive, &revert)
+	assert.Equal(t, int64(1), result.MatchedCount)
+	result, _ = repo.TransitionSchedule("later", models.PriceScheduleStatusPending, models.PriceScheduleStatusCancelled, nil)
+	assert.Equal(t, int64(0), result.MatchedCount)
+
+	schedule, _ := repo.GetScheduleByID("later")
+	assert.Equal(t, &revert, schedule.RevertPrice)
+
+	ending, _ := repo.GetSchedulesToEnd(end, 0)
+	assert.Len(t, ending, 1)
+
+	all, _ := repo.GetSchedulesByProductID("1")
+	assert.Len(t, all, 3)
+
+	_, err = repo.GetScheduleByID("missing")
+	assert.Equal(t, utils.ErrPriceScheduleNotFound, err)
+}
@@ -0,0 +1,463 @@
+package repositories_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/YugenDev/global-mobility-test/internal/models"
+	"github.com/YugenDev/global-mobility-test/internal/repositories"
+	"github.com/YugenDev/global-mobility-test/internal/utils"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// usd parses a USD amount such as "29.99", failing on malformed input.
+func usd(amount string) models.Money {
+	money, err := models.ParseMoney(amount, "USD")
+	if err != nil {
+		panic(err)
+	}
+	return money
+}
+
+func TestMemoryCreateAndGetProduct(t *testing.T) {
+	repo := repositories.NewMemoryProductRepository()
+	c := echo.New().NewContext(nil, nil)
+
+	product := &models.Product{ProductID: "test-id", Name: "Test Product", Price: usd("10"), Stock: 5}
+	result, err := repo.CreateProduct(c, product)
+
+	assert.NoError(t, err)
+	assert.NotNil(t, result.InsertedID)
+	assert.False(t, product.CreatedAt.IsZero())
+
+	found, err := repo.GetProductByID("test-id")
+	assert.NoError(t, err)
+	assert.Equal(t, "Test Product", found.Name)
+}
+
+func TestMemoryCreateProduct_DuplicateID(t *testing.T) {
+	repo := repositories.NewMemoryProductRepository()
+	c := echo.New().NewContext(nil, nil)
+
+	_, err := repo.CreateProduct(c, &models.Product{ProductID: "test-id"})
+	assert.NoError(t, err)
+
+	result, err := repo.CreateProduct(c, &models.Product{ProductID: "test-id"})
+	assert.Nil(t, result)
+	assert.Equal(t, utils.ErrProductIDAlreadyExists, err)
+}
+
+func TestMemoryCreateProduct_NilProduct(t *testing.T) {
+	repo := repositories.NewMemoryProductRepository()
+
+	result, err := repo.CreateProduct(echo.New().NewContext(nil, nil), nil)
+
+	assert.Nil(t, result)
+	assert.Equal(t, utils.ErrNullProductData, err)
+}
+
+func TestMemoryGetProductByID_NotFound(t *testing.T) {
+	repo := repositories.NewMemoryProductRepository()
+
+	result, err := repo.GetProductByID("missing")
+
+	assert.Equal(t, mongo.ErrNoDocuments, err)
+	assert.Equal(t, models.Product{}, result)
+}
+
+func TestMemoryGetAllProducts_OrderedByCreation(t *testing.T) {
+	repo := repositories.NewMemoryProductRepository()
+	c := echo.New().NewContext(nil, nil)
+
+	empty, err := repo.GetAllProducts(models.ProductQuery{})
+	assert.NoError(t, err)
+	assert.Nil(t, empty)
+
+	repo.CreateProduct(c, &models.Product{ProductID: "b"})
+	repo.CreateProduct(c, &models.Product{ProductID: "a"})
+
+	products, err := repo.GetAllProducts(models.ProductQuery{})
+	assert.NoError(t, err)
+	assert.Len(t, products, 2)
+	assert.Equal(t, "b", products[0].ProductID)
+	assert.Equal(t, "a", products[1].ProductID)
+}
+
+func TestMemoryUpdateProduct(t *testing.T) {
+	repo := repositories.NewMemoryProductRepository()
+	c := echo.New().NewContext(nil, nil)
+
+	repo.CreateProduct(c, &models.Product{ProductID: "test-id", Name: "Old", Description: "Keep", Price: usd("10")})
+
+	update := &models.Product{Name: "New", Version: 1}
+	result, err := repo.UpdateProduct(c, "test-id", update)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), result.MatchedCount)
+	assert.Equal(t, 2, update.Version)
+
+	result, err = repo.UpdateProduct(c, "test-id", &models.Product{Name: "Stale", Version: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), result.MatchedCount)
+
+	found, _ := repo.GetProductByID("test-id")
+	assert.Equal(t, "New", found.Name)
+	assert.Equal(t, "Keep", found.Description)
+	assert.Equal(t, usd("10"), found.Price)
+	assert.Equal(t, 2, found.Version)
+
+	result, err = repo.UpdateProduct(c, "missing", &models.Product{Name: "New"})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), result.MatchedCount)
+}
+
+func TestMemoryDeleteProduct(t *testing.T) {
+	repo := repositories.NewMemoryProductRepository()
+	c := echo.New().NewContext(nil, nil)
+
+	repo.CreateProduct(c, &models.Product{ProductID: "test-id"})
+
+	result, err := repo.DeleteProduct(c, "test-id", 2)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), result.DeletedCount)
+
+	result, err = repo.DeleteProduct(c, "test-id", 1)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), result.DeletedCount)
+
+	result, err = repo.DeleteProduct(c, "test-id", 1)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), result.DeletedCount)
+
+	_, err = repo.DeleteProduct(c, "", 1)
+	assert.Equal(t, utils.ErrProductIDRequired, err)
+}
+
+func TestMemoryRepository_ConcurrentAccess(t *testing.T) {
+	repo := repositories.NewMemoryProductRepository()
+	c := echo.New().NewContext(nil, nil)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			repo.CreateProduct(c, &models.Product{ProductID: utils.GenerateUniqueID()})
+			repo.GetAllProducts(models.ProductQuery{})
+		}()
+	}
+	wg.Wait()
+
+	products, err := repo.GetAllProducts(models.ProductQuery{})
+	assert.NoError(t, err)
+	assert.Len(t, products, 50)
+}
+
+func TestMemoryGetAllProducts_Pagination(t *testing.T) {
+	repo := repositories.NewMemoryProductRepository()
+	c := echo.New().NewContext(nil, nil)
+
+	for _, id := range []string{"1", "2", "3"} {
+		repo.CreateProduct(c, &models.Product{ProductID: id})
+	}
+
+	first, err := repo.GetAllProducts(models.ProductQuery{Limit: 2})
+	assert.NoError(t, err)
+	assert.Len(t, first, 2)
+
+	last := first[len(first)-1]
+	rest, err := repo.GetAllProducts(models.ProductQuery{
+		Limit: 2,
+		After: &models.ProductCursor{CreatedAt: last.CreatedAt, ProductID: last.ProductID},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, rest, 1)
+	assert.Equal(t, "3", rest[0].ProductID)
+}
+
+func TestMemoryGetAllProducts_PriceFilterInOtherCurrency(t *testing.T) {
+	repo := repositories.NewMemoryProductRepository()
+	c := echo.New().NewContext(nil, nil)
+
+	eur := func(amount string) models.Money {
+		money, _ := models.ParseMoney(amount, "EUR")
+		return money
+	}
+	repo.CreateProduct(c, &models.Product{ProductID: "1", Name: "Boot", Price: eur("30")})
+	repo.CreateProduct(c, &models.Product{ProductID: "2", Name: "Shoe", Price: usd("30"), Prices: []models.Money{eur("25")}})
+	repo.CreateProduct(c, &models.Product{ProductID: "3", Name: "Sock", Price: usd("30")})
+	repo.CreateProduct(c, &models.Product{ProductID: "4", Name: "Hat", Price: eur("50")})
+
+	minPrice := eur("20")
+	maxPrice := eur("40")
+	products, err := repo.GetAllProducts(models.ProductQuery{
+		Sort:   "name",
+		Filter: models.ProductFilter{MinPrice: &minPrice, MaxPrice: &maxPrice},
+	})
+	assert.NoError(t, err)
+	if assert.Len(t, products, 2) {
+		assert.Equal(t, "1", products[0].ProductID)
+		assert.Equal(t, "2", products[1].ProductID)
+	}
+}
+
+func TestMemoryGetAllProducts_FilterAndSort(t *testing.T) {
+	repo := repositories.NewMemoryProductRepository()
+	c := echo.New().NewContext(nil, nil)
+
+	repo.CreateProduct(c, &models.Product{ProductID: "1", Name: "Apple", Price: usd("5"), Stock: 1})
+	repo.CreateProduct(c, &models.Product{ProductID: "2", Name: "Apricot", Price: usd("15"), Stock: 0})
+	repo.CreateProduct(c, &models.Product{ProductID: "3", Name: "Banana", Price: usd("10"), Stock: 3})
+	repo.CreateProduct(c, &models.Product{ProductID: "4", Name: "avocado", Price: usd("20"), Stock: 2})
+
+	minPrice := usd("6")
+	inStock := true
+	products, err := repo.GetAllProducts(models.ProductQuery{
+		Sort:   "-price",
+		Filter: models.ProductFilter{MinPrice: &minPrice, InStock: &inStock},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, products, 2)
+	assert.Equal(t, "4", products[0].ProductID)
+	assert.Equal(t, "3", products[1].ProductID)
+
+	products, err = repo.GetAllProducts(models.ProductQuery{
+		Sort:   "name",
+		Filter: models.ProductFilter{NamePrefix: "ap"},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, products, 2)
+	assert.Equal(t, "Apple", products[0].Name)
+	assert.Equal(t, "Apricot", products[1].Name)
+
+	products, err = repo.GetAllProducts(models.ProductQuery{
+		Sort:  "-price",
+		Limit: 2,
+		After: &models.ProductCursor{Sort: "-price", Price: 1500, ProductID: "2"},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, products, 2)
+	assert.Equal(t, "3", products[0].ProductID)
+	assert.Equal(t, "1", products[1].ProductID)
+}
+
+func TestMemorySearchProducts(t *testing.T) {
+	repo := repositories.NewMemoryProductRepository()
+	c := echo.New().NewContext(nil, nil)
+
+	repo.CreateProduct(c, &models.Product{ProductID: "1", Name: "Rack", Description: "Holds your shoes"})
+	repo.CreateProduct(c, &models.Product{ProductID: "2", Name: "Running Shoes", Description: "Light shoes"})
+	repo.CreateProduct(c, &models.Product{ProductID: "3", Name: "Hat", Description: "Warm"})
+
+	results, err := repo.SearchProducts(models.ProductSearchQuery{Text: "Shoes"})
+	assert.NoError(t, err)
+	assert.Len(t, results, 2)
+	assert.Equal(t, "2", results[0].ProductID)
+	assert.Greater(t, results[0].Score, results[1].Score)
+
+	results, err = repo.SearchProducts(models.ProductSearchQuery{Text: "shoes", Offset: 1, Limit: 5})
+	assert.NoError(t, err)
+	assert.Len(t, results, 1)
+	assert.Equal(t, "1", results[0].ProductID)
+}
+
+func TestMemorySoftDeleteAndRestore(t *testing.T) {
+	repo := repositories.NewMemoryProductRepository()
+	c := echo.New().NewContext(nil, nil)
+
+	repo.CreateProduct(c, &models.Product{ProductID: "1", Name: "Shoes"})
+	repo.CreateProduct(c, &models.Product{ProductID: "2", Name: "Hat"})
+
+	result, err := repo.DeleteProduct(c, "1", 1)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), result.DeletedCount)
+
+	_, err = repo.GetProductByID("1")
+	assert.Equal(t, mongo.ErrNoDocuments, err)
+
+	live, _ := repo.GetAllProducts(models.ProductQuery{})
+	assert.Len(t, live, 1)
+	assert.Equal(t, "2", live[0].ProductID)
+
+	trash, _ := repo.GetAllProducts(models.ProductQuery{Trashed: true})
+	assert.Len(t, trash, 1)
+	assert.NotNil(t, trash[0].DeletedAt)
+
+	matches, _ := repo.SearchProducts(models.ProductSearchQuery{Text: "shoes"})
+	assert.Empty(t, matches)
+
+	_, err = repo.CreateProduct(c, &models.Product{ProductID: "1"})
+	assert.Equal(t, utils.ErrProductIDAlreadyExists, err)
+
+	restored, err := repo.RestoreProduct(c, "1")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), restored.MatchedCount)
+
+	product, err := repo.GetProductByID("1")
+	assert.NoError(t, err)
+	assert.Nil(t, product.DeletedAt)
+	assert.Equal(t, 3, product.Version)
+
+	restored, err = repo.RestoreProduct(c, "1")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), restored.MatchedCount)
+}
+
+func TestMemoryPurgeDeletedProducts(t *testing.T) {
+	repo := repositories.NewMemoryProductRepository()
+	c := echo.New().NewContext(nil, nil)
+
+	repo.CreateProduct(c, &models.Product{ProductID: "1"})
+	repo.CreateProduct(c, &models.Product{ProductID: "2"})
+	repo.DeleteProduct(c, "1", 1)
+
+	purged, err := repo.PurgeDeletedProducts(time.Now().Add(-time.Hour))
+	assert.NoError(t, err)
+	assert.Empty(t, purged)
+
+	purged, err = repo.PurgeDeletedProducts(time.Now())
+	assert.NoError(t, err)
+	if assert.Len(t, purged, 1) {
+		assert.Equal(t, "1", purged[0].ProductID)
+	}
+
+	trash, _ := repo.GetAllProducts(models.ProductQuery{Trashed: true})
+	assert.Empty(t, trash)
+
+	_, err = repo.CreateProduct(c, &models.Product{ProductID: "1"})
+	assert.NoError(t, err)
+}
+
+func TestMemoryBulkWriteProducts(t *testing.T) {
+	repo := repositories.NewMemoryProductRepository()
+	c := echo.New().NewContext(nil, nil)
+
+	repo.CreateProduct(c, &models.Product{ProductID: "1", Name: "Old"})
+	repo.CreateProduct(c, &models.Product{ProductID: "2"})
+
+	writes := []models.ProductWrite{
+		{Op: models.BulkOpUpdate, Product: models.Product{ProductID: "1", Name: "New", Version: 1}},
+		{Op: models.BulkOpDelete, Product: models.Product{ProductID: "2", Version: 7}},
+		{Op: models.BulkOpCreate, Product: models.Product{ProductID: "3"}},
+		{Op: models.BulkOpCreate, Product: models.Product{ProductID: "1"}},
+		{Op: models.BulkOpDelete, Product: models.Product{ProductID: "3", Version: 1}},
+	}
+	errs, err := repo.BulkWriteProducts(c, writes)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []error{nil, utils.ErrProductVersionMismatch, nil, utils.ErrProductIDAlreadyExists, utils.ErrBulkOperationSkipped}, errs)
+
+	updated, _ := repo.GetProductByID("1")
+	assert.Equal(t, "New", updated.Name)
+	assert.Equal(t, 2, updated.Version)
+
+	_, err = repo.GetProductByID("2")
+	assert.NoError(t, err)
+
+	created, err := repo.GetProductByID("3")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, created.Version)
+}
+
+func TestMemoryAdjustStock_Concurrent(t *testing.T) {
+	repo := repositories.NewMemoryProductRepository()
+	c := echo.New().NewContext(nil, nil)
+
+	repo.CreateProduct(c, &models.Product{ProductID: "1", Stock: 10})
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var insufficient int
+	for i := 0; i < 15; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := repo.AdjustStock(c, "1", -1); err == utils.ErrInsufficientStock {
+				mu.Lock()
+				insufficient++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	product, _ := repo.GetProductByID("1")
+	assert.Equal(t, 0, product.Stock)
+	assert.Equal(t, 11, product.Version)
+	assert.Equal(t, 5, insufficient)
+
+	_, err := repo.AdjustStock(c, "missing", 1)
+	assert.Equal(t, mongo.ErrNoDocuments, err)
+}
+
+func TestMemoryReserveAndCommitStock(t *testing.T) {
+	repo := repositories.NewMemoryProductRepository()
+	c := echo.New().NewContext(nil, nil)
+
+	repo.CreateProduct(c, &models.Product{ProductID: "1", Stock: 5})
+
+	product, err := repo.ReserveStock(c, "1", 3)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, product.Available())
+
+	_, err = repo.ReserveStock(c, "1", 3)
+	assert.Equal(t, utils.ErrInsufficientStock, err)
+	_, err = repo.AdjustStock(c, "1", -3)
+	assert.Equal(t, utils.ErrInsufficientStock, err)
+
+	result, err := repo.UpdateProduct(c, "1", &models.Product{Name: "Renamed", Reserved: 0, Version: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), result.MatchedCount)
+
+	product, err = repo.CommitReservedStock(c, "1", 2)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, product.Stock)
+	assert.Equal(t, 1, product.Reserved)
+
+	result, err = repo.ReleaseStock(c, "1", 1)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), result.MatchedCount)
+
+	result, _ = repo.ReleaseStock(c, "1", 1)
+	assert.Equal(t, int64(0), result.MatchedCount)
+
+	product, _ = repo.GetProductByID("1")
+	assert.Equal(t, 3, product.Available())
+}
+
+func TestMemoryReserveStockRejectsProductsWithVariants(t *testing.T) {
+	repo := repositories.NewMemoryProductRepository()
+	c := echo.New().NewContext(nil, nil)
+
+	repo.CreateProduct(c, &models.Product{ProductID: "1", Stock: 5})
+	repo.ReserveStock(c, "1", 3)
+	repo.UpdateVariants(c, "1", 1, []models.Variant{{SKU: "A", Options: map[string]string{"size": "M"}, Stock: 5}})
+
+	_, err := repo.ReserveStock(c, "1", 1)
+	assert.Equal(t, utils.ErrProductHasVariants, err)
+	_, err = repo.CommitReservedStock(c, "1", 3)
+	assert.Equal(t, mongo.ErrNoDocuments, err)
+
+	product, _ := repo.GetProductByID("1")
+	assert.Equal(t, 5, product.Stock)
+	assert.Equal(t, 5, product.Variants[0].Stock)
+}
+
+func TestMemoryCommitReservedStockSkipsDeletedProducts(t *testing.T) {
+	repo := repositories.NewMemoryProductRepository()
+	c := echo.New().NewContext(nil, nil)
+
+	repo.CreateProduct(c, &models.Product{ProductID: "1", Stock: 5})
+	repo.ReserveStock(c, "1", 3)
+	repo.DeleteProduct(c, "1", 1)
+
+	_, err := repo.CommitReservedStock(c, "1", 3)
+	assert.Equal(t, mongo.ErrNoDocuments, err)
+
+	trash, _ := repo.GetAllProducts(models.ProductQuery{Trashed: true})
+	assert.Len(t, trash, 1)
+	assert.Equal(t, 5, trash[0].Stock)
+	assert.Equal(t, 3, trash[0].Reserved)
+}
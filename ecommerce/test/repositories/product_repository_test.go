@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/YugenDev/global-mobility-test/internal/models"
 	"github.com/YugenDev/global-mobility-test/internal/repositories"
@@ -44,6 +45,16 @@ func (m *MockCollection) FindOne(ctx context.Context, filter interface{}, opts .
 	return args.Get(0).(*mongo.SingleResult)
 }
 
+func (m *MockCollection) FindOneAndUpdate(ctx context.Context, filter interface{}, update interface{}, opts ...*options.FindOneAndUpdateOptions) *mongo.SingleResult {
+	args := m.Called(ctx, filter, update)
+	return args.Get(0).(*mongo.SingleResult)
+}
+
+func (m *MockCollection) FindOneAndDelete(ctx context.Context, filter interface{}, opts ...*options.FindOneAndDeleteOptions) *mongo.SingleResult {
+	args := m.Called(ctx, filter)
+	return args.Get(0).(*mongo.SingleResult)
+}
+
 func (m *MockCollection) UpdateOne(ctx context.Context, filter interface{}, update interface{}, opts ...*options.UpdateOptions) (*mongo.UpdateResult, error) {
 	args := m.Called(ctx, filter, update)
 	if args.Get(0) == nil {
@@ -52,6 +63,14 @@ func (m *MockCollection) UpdateOne(ctx context.Context, filter interface{}, upda
 	return args.Get(0).(*mongo.UpdateResult), args.Error(1)
 }
 
+func (m *MockCollection) UpdateMany(ctx context.Context, filter interface{}, update interface{}, opts ...*options.UpdateOptions) (*mongo.UpdateResult, error) {
+	args := m.Called(ctx, filter, update)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*mongo.UpdateResult), args.Error(1)
+}
+
 func (m *MockCollection) DeleteOne(ctx context.Context, filter interface{}, opts ...*options.DeleteOptions) (*mongo.DeleteResult, error) {
 	args := m.Called(ctx, filter)
 	if args.Get(0) == nil {
@@ -60,14 +79,35 @@ func (m *MockCollection) DeleteOne(ctx context.Context, filter interface{}, opts
 	return args.Get(0).(*mongo.DeleteResult), args.Error(1)
 }
 
+func (m *MockCollection) DeleteMany(ctx context.Context, filter interface{}, opts ...*options.DeleteOptions) (*mongo.DeleteResult, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*mongo.DeleteResult), args.Error(1)
+}
+
+func (m *MockCollection) Indexes() mongo.IndexView {
+	m.Called()
+	return mongo.IndexView{}
+}
+
+func (m *MockCollection) BulkWrite(ctx context.Context, models []mongo.WriteModel, opts ...*options.BulkWriteOptions) (*mongo.BulkWriteResult, error) {
+	args := m.Called(ctx, models)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*mongo.BulkWriteResult), args.Error(1)
+}
+
 func TestDeleteProduct(t *testing.T) {
 	mockCollection := new(MockCollection)
 	repo := repositories.ProductRepository{Collection: mockCollection}
 
-	mockResult := &mongo.DeleteResult{DeletedCount: 1}
-	mockCollection.On("DeleteOne", mock.Anything, mock.Anything).Return(mockResult, nil)
+	mockResult := &mongo.UpdateResult{MatchedCount: 1, ModifiedCount: 1}
+	mockCollection.On("UpdateOne", mock.Anything, bson.M{"product_id": "test-id", "version": 1, "deleted_at": nil}, mock.Anything).Return(mockResult, nil)
 
-	result, err := repo.DeleteProduct(echo.New().NewContext(nil, nil), "test-id")
+	result, err := repo.DeleteProduct(echo.New().NewContext(nil, nil), "test-id", 1)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
@@ -79,7 +119,7 @@ func TestDeleteProduct_EmptyID(t *testing.T) {
 	mockCollection := new(MockCollection)
 	repo := repositories.ProductRepository{Collection: mockCollection}
 
-	result, err := repo.DeleteProduct(echo.New().NewContext(nil, nil), "")
+	result, err := repo.DeleteProduct(echo.New().NewContext(nil, nil), "", 1)
 
 	assert.Error(t, err)
 	assert.Nil(t, result)
@@ -107,7 +147,7 @@ func TestGetAllProducts(t *testing.T) {
 
 	mockCollection.On("Find", mock.Anything, mock.Anything).Return(cursor, nil)
 
-	result, err := repo.GetAllProducts()
+	result, err := repo.GetAllProducts(models.ProductQuery{})
 
 	assert.NoError(t, err)
 	assert.Len(t, result, 2)
@@ -122,7 +162,7 @@ func TestGetAllProducts_DatabaseError(t *testing.T) {
 	expectedError := errors.New("database error")
 	mockCollection.On("Find", mock.Anything, mock.Anything).Return(nil, expectedError)
 
-	result, err := repo.GetAllProducts()
+	result, err := repo.GetAllProducts(models.ProductQuery{})
 
 	assert.Error(t, err)
 	assert.Nil(t, result)
@@ -135,9 +175,9 @@ func TestDeleteProduct_DatabaseError(t *testing.T) {
 	repo := repositories.ProductRepository{Collection: mockCollection}
 
 	expectedError := errors.New("database error")
-	mockCollection.On("DeleteOne", mock.Anything, mock.Anything).Return(nil, expectedError)
+	mockCollection.On("UpdateOne", mock.Anything, mock.Anything, mock.Anything).Return(nil, expectedError)
 
-	result, err := repo.DeleteProduct(echo.New().NewContext(nil, nil), "test-id")
+	result, err := repo.DeleteProduct(echo.New().NewContext(nil, nil), "test-id", 1)
 
 	assert.Error(t, err)
 	assert.Nil(t, result)
@@ -155,7 +195,7 @@ func TestGetAllProducts_CursorError(t *testing.T) {
 
 	mockCollection.On("Find", mock.Anything, mock.Anything).Return(cursor, nil)
 
-	result, err := repo.GetAllProducts()
+	result, err := repo.GetAllProducts(models.ProductQuery{})
 
 	assert.Error(t, err)
 	assert.Nil(t, result)
@@ -228,7 +268,7 @@ func TestGetProductByID(t *testing.T) {
 	}
 
 	mockSingleResult := mongo.NewSingleResultFromDocument(product, nil, nil)
-	mockCollection.On("FindOne", mock.Anything, bson.M{"product_id": "test-id"}).Return(mockSingleResult)
+	mockCollection.On("FindOne", mock.Anything, bson.M{"product_id": "test-id", "deleted_at": nil}).Return(mockSingleResult)
 
 	result, err := repo.GetProductByID("test-id")
 
@@ -256,7 +296,7 @@ func TestGetProductByID_DatabaseError(t *testing.T) {
 
 	expectedError := errors.New("database error")
 	mockSingleResult := mongo.NewSingleResultFromDocument(bson.M{}, expectedError, nil)
-	mockCollection.On("FindOne", mock.Anything, bson.M{"product_id": "test-id"}).Return(mockSingleResult)
+	mockCollection.On("FindOne", mock.Anything, bson.M{"product_id": "test-id", "deleted_at": nil}).Return(mockSingleResult)
 
 	result, err := repo.GetProductByID("test-id")
 
@@ -272,10 +312,11 @@ func TestUpdateProduct(t *testing.T) {
 	product := &models.Product{
 		ProductID: "test-id",
 		Name:      "Updated Product",
+		Version:   3,
 	}
 
 	mockResult := &mongo.UpdateResult{MatchedCount: 1, ModifiedCount: 1}
-	mockCollection.On("UpdateOne", mock.Anything, bson.M{"product_id": "test-id"}, mock.Anything).Return(mockResult, nil)
+	mockCollection.On("UpdateOne", mock.Anything, bson.M{"product_id": "test-id", "version": 3, "deleted_at": nil}, mock.Anything).Return(mockResult, nil)
 
 	result, err := repo.UpdateProduct(echo.New().NewContext(nil, nil), "test-id", product)
 
@@ -283,6 +324,29 @@ func TestUpdateProduct(t *testing.T) {
 	assert.NotNil(t, result)
 	assert.Equal(t, int64(1), result.MatchedCount)
 	assert.Equal(t, int64(1), result.ModifiedCount)
+	assert.Equal(t, 4, product.Version)
+	mockCollection.AssertExpectations(t)
+}
+
+func TestUpdateProduct_StockMustCoverReserved(t *testing.T) {
+	mockCollection := new(MockCollection)
+	repo := repositories.ProductRepository{Collection: mockCollection}
+
+	product := &models.Product{ProductID: "test-id", Stock: 3, Version: 3}
+
+	filter := bson.M{
+		"product_id": "test-id",
+		"version":    3,
+		"deleted_at": nil,
+		"$expr":      bson.M{"$lte": bson.A{bson.M{"$ifNull": bson.A{"$reserved", 0}}, 3}},
+	}
+	mockCollection.On("UpdateOne", mock.Anything, filter, mock.Anything).Return(&mongo.UpdateResult{}, nil)
+
+	result, err := repo.UpdateProduct(echo.New().NewContext(nil, nil), "test-id", product)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), result.MatchedCount)
+	assert.Equal(t, 3, product.Version)
 	mockCollection.AssertExpectations(t)
 }
 
@@ -320,10 +384,11 @@ func TestUpdateProduct_DatabaseError(t *testing.T) {
 	product := &models.Product{
 		ProductID: "test-id",
 		Name:      "Updated Product",
+		Version:   3,
 	}
 
 	expectedError := errors.New("database error")
-	mockCollection.On("UpdateOne", mock.Anything, bson.M{"product_id": "test-id"}, mock.Anything).Return(nil, expectedError)
+	mockCollection.On("UpdateOne", mock.Anything, bson.M{"product_id": "test-id", "version": 3, "deleted_at": nil}, mock.Anything).Return(nil, expectedError)
 
 	result, err := repo.UpdateProduct(echo.New().NewContext(nil, nil), "test-id", product)
 
@@ -332,3 +397,268 @@ func TestUpdateProduct_DatabaseError(t *testing.T) {
 	assert.Equal(t, expectedError, err)
 	mockCollection.AssertExpectations(t)
 }
+
+func TestGetAllProducts_BuildsFilter(t *testing.T) {
+	mockCollection := new(MockCollection)
+	repo := repositories.ProductRepository{Collection: mockCollection}
+
+	cursor, err := mongo.NewCursorFromDocuments(nil, nil, nil)
+	assert.NoError(t, err)
+
+	minPrice := usd("10")
+	inStock := true
+	mockCollection.On("Find", mock.Anything, bson.M{"$and": bson.A{
+		bson.M{"deleted_at": nil},
+		bson.M{"$or": bson.A{
+			bson.M{"price.currency": "USD", "price.amount": bson.M{"$gte": int64(1000)}},
+			bson.M{"prices": bson.M{"$elemMatch": bson.M{"currency": "USD", "amount": bson.M{"$gte": int64(1000)}}}},
+		}},
+		bson.M{"stock": bson.M{"$gt": 0}},
+	}}).Return(cursor, nil)
+
+	_, err = repo.GetAllProducts(models.ProductQuery{
+		Sort:   "price",
+		Filter: models.ProductFilter{MinPrice: &minPrice, InStock: &inStock},
+	})
+
+	assert.NoError(t, err)
+	mockCollection.AssertExpectations(t)
+}
+
+func TestSearchProducts(t *testing.T) {
+	mockCollection := new(MockCollection)
+	repo := repositories.ProductRepository{Collection: mockCollection}
+
+	docs := []interface{}{
+		bson.M{"product_id": "1", "name": "Red Shoes", "score": 4.5},
+		bson.M{"product_id": "2", "name": "Shoe Rack", "score": 1.5},
+	}
+	cursor, err := mongo.NewCursorFromDocuments(docs, nil, nil)
+	assert.NoError(t, err)
+
+	mockCollection.On("Find", mock.Anything, bson.M{"$text": bson.M{"$search": "shoes"}, "deleted_at": nil}).Return(cursor, nil)
+
+	results, err := repo.SearchProducts(models.ProductSearchQuery{Text: "shoes", Limit: 10})
+
+	assert.NoError(t, err)
+	assert.Len(t, results, 2)
+	assert.Equal(t, "1", results[0].ProductID)
+	assert.Equal(t, 4.5, results[0].Score)
+	mockCollection.AssertExpectations(t)
+}
+
+func TestSearchProducts_EmptyQuery(t *testing.T) {
+	mockCollection := new(MockCollection)
+	repo := repositories.ProductRepository{Collection: mockCollection}
+
+	results, err := repo.SearchProducts(models.ProductSearchQuery{})
+
+	assert.Nil(t, results)
+	assert.Equal(t, utils.ErrSearchQueryRequired, err)
+}
+
+func TestRestoreProduct(t *testing.T) {
+	mockCollection := new(MockCollection)
+	repo := repositories.ProductRepository{Collection: mockCollection}
+
+	mockResult := &mongo.UpdateResult{MatchedCount: 1, ModifiedCount: 1}
+	mockCollection.On("UpdateOne", mock.Anything, bson.M{"product_id": "test-id", "deleted_at": bson.M{"$ne": nil}}, mock.Anything).Return(mockResult, nil)
+
+	result, err := repo.RestoreProduct(echo.New().NewContext(nil, nil), "test-id")
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), result.MatchedCount)
+	mockCollection.AssertExpectations(t)
+}
+
+func TestPurgeDeletedProducts(t *testing.T) {
+	mockCollection := new(MockCollection)
+	repo := repositories.ProductRepository{Collection: mockCollection}
+
+	before := time.Now()
+	filter := bson.M{"deleted_at": bson.M{"$lte": before}}
+	mockCollection.On("FindOneAndDelete", mock.Anything, filter).Return(mongo.NewSingleResultFromDocument(models.Product{ProductID: "1"}, nil, nil)).Once()
+	mockCollection.On("FindOneAndDelete", mock.Anything, filter).Return(mongo.NewSingleResultFromDocument(models.Product{ProductID: "2"}, nil, nil)).Once()
+	mockCollection.On("FindOneAndDelete", mock.Anything, filter).Return(mongo.NewSingleResultFromDocument(bson.M{}, mongo.ErrNoDocuments, nil)).Once()
+
+	purged, err := repo.PurgeDeletedProducts(before)
+
+	assert.NoError(t, err)
+	if assert.Len(t, purged, 2) {
+		assert.Equal(t, "1", purged[0].ProductID)
+		assert.Equal(t, "2", purged[1].ProductID)
+	}
+	mockCollection.AssertExpectations(t)
+}
+
+func TestBulkWriteProducts(t *testing.T) {
+	mockCollection := new(MockCollection)
+	repo := repositories.ProductRepository{Collection: mockCollection}
+
+	mockCollection.On("BulkWrite", mock.Anything, mock.MatchedBy(func(models []mongo.WriteModel) bool {
+		return len(models) == 2
+	})).Return(&mongo.BulkWriteResult{InsertedCount: 1, MatchedCount: 1, ModifiedCount: 1}, nil)
+
+	writes := []models.ProductWrite{
+		{Op: models.BulkOpCreate, Product: models.Product{ProductID: "new"}},
+		{Op: models.BulkOpUpdate, Product: models.Product{ProductID: "existing", Version: 3}},
+	}
+	errs, err := repo.BulkWriteProducts(echo.New().NewContext(nil, nil), writes)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []error{nil, nil}, errs)
+	assert.Equal(t, 1, writes[0].Product.Version)
+	assert.Equal(t, 4, writes[1].Product.Version)
+	mockCollection.AssertExpectations(t)
+}
+
+func TestBulkWriteProducts_DuplicateKeySkipsRest(t *testing.T) {
+	mockCollection := new(MockCollection)
+	repo := repositories.ProductRepository{Collection: mockCollection}
+
+	bulkErr := mongo.BulkWriteException{WriteErrors: []mongo.BulkWriteError{
+		{WriteError: mongo.WriteError{Index: 1, Code: 11000, Message: "duplicate key"}},
+	}}
+	mockCollection.On("BulkWrite", mock.Anything, mock.Anything).Return(&mongo.BulkWriteResult{InsertedCount: 1}, bulkErr)
+
+	writes := []models.ProductWrite{
+		{Op: models.BulkOpCreate, Product: models.Product{ProductID: "a"}},
+		{Op: models.BulkOpCreate, Product: models.Product{ProductID: "b"}},
+		{Op: models.BulkOpDelete, Product: models.Product{ProductID: "c", Version: 1}},
+	}
+	errs, err := repo.BulkWriteProducts(echo.New().NewContext(nil, nil), writes)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []error{nil, utils.ErrProductIDAlreadyExists, utils.ErrBulkOperationSkipped}, errs)
+	mockCollection.AssertExpectations(t)
+}
+
+func TestBulkWriteProducts_VersionConflict(t *testing.T) {
+	mockCollection := new(MockCollection)
+	repo := repositories.ProductRepository{Collection: mockCollection}
+
+	mockCollection.On("BulkWrite", mock.Anything, mock.Anything).Return(&mongo.BulkWriteResult{MatchedCount: 1}, nil)
+	moved := mongo.NewSingleResultFromDocument(models.Product{ProductID: "moved", Version: 5}, nil, nil)
+	mockCollection.On("FindOne", mock.Anything, bson.M{"product_id": "moved"}).Return(moved)
+	applied := mongo.NewSingleResultFromDocument(models.Product{ProductID: "applied", Version: 2}, nil, nil)
+	mockCollection.On("FindOne", mock.Anything, bson.M{"product_id": "applied"}).Return(applied)
+
+	writes := []models.ProductWrite{
+		{Op: models.BulkOpUpdate, Product: models.Product{ProductID: "moved", Version: 1}},
+		{Op: models.BulkOpUpdate, Product: models.Product{ProductID: "applied", Version: 1}},
+	}
+	errs, err := repo.BulkWriteProducts(echo.New().NewContext(nil, nil), writes)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []error{utils.ErrProductVersionMismatch, nil}, errs)
+	assert.Equal(t, 2, writes[1].Product.Version)
+	mockCollection.AssertExpectations(t)
+}
+
+func TestBulkWriteProducts_DatabaseError(t *testing.T) {
+	mockCollection := new(MockCollection)
+	repo := repositories.ProductRepository{Collection: mockCollection}
+
+	mockCollection.On("BulkWrite", mock.Anything, mock.Anything).Return(nil, errors.New("connection reset"))
+
+	errs, err := repo.BulkWriteProducts(echo.New().NewContext(nil, nil), []models.ProductWrite{
+		{Op: models.BulkOpCreate, Product: models.Product{ProductID: "a"}},
+	})
+
+	assert.Error(t, err)
+	assert.Nil(t, errs)
+}
+
+func TestAdjustStock(t *testing.T) {
+	mockCollection := new(MockCollection)
+	repo := repositories.ProductRepository{Collection: mockCollection}
+
+	updated := mongo.NewSingleResultFromDocument(models.Product{ProductID: "test-id", Stock: 2, Version: 3}, nil, nil)
+	mockCollection.On("FindOneAndUpdate", mock.Anything, bson.M{
+		"product_id": "test-id",
+		"deleted_at": nil,
+		"variants.0": bson.M{"$exists": false},
+		"$expr":      bson.M{"$gte": bson.A{bson.M{"$subtract": bson.A{"$stock", bson.M{"$ifNull": bson.A{"$reserved", 0}}}}, 3}},
+	}, mock.Anything).Return(updated)
+
+	product, err := repo.AdjustStock(echo.New().NewContext(nil, nil), "test-id", -3)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, product.Stock)
+	mockCollection.AssertExpectations(t)
+}
+
+func TestCommitReservedStock(t *testing.T) {
+	mockCollection := new(MockCollection)
+	repo := repositories.ProductRepository{Collection: mockCollection}
+
+	updated := mongo.NewSingleResultFromDocument(models.Product{ProductID: "test-id", Stock: 2, Version: 3}, nil, nil)
+	mockCollection.On("FindOneAndUpdate", mock.Anything, bson.M{
+		"product_id": "test-id",
+		"deleted_at": nil,
+		"reserved":   bson.M{"$gte": 3},
+		"stock":      bson.M{"$gte": 3},
+		"variants.0": bson.M{"$exists": false},
+	}, mock.Anything).Return(updated)
+
+	product, err := repo.CommitReservedStock(echo.New().NewContext(nil, nil), "test-id", 3)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, product.Stock)
+	mockCollection.AssertExpectations(t)
+}
+
+func TestAdjustStock_Insufficient(t *testing.T) {
+	mockCollection := new(MockCollection)
+	repo := repositories.ProductRepository{Collection: mockCollection}
+
+	noMatch := mongo.NewSingleResultFromDocument(bson.M{}, mongo.ErrNoDocuments, nil)
+	mockCollection.On("FindOneAndUpdate", mock.Anything, mock.Anything, mock.Anything).Return(noMatch)
+	existing := mongo.NewSingleResultFromDocument(models.Product{ProductID: "test-id", Stock: 1}, nil, nil)
+	mockCollection.On("FindOne", mock.Anything, bson.M{"product_id": "test-id", "deleted_at": nil}).Return(existing)
+
+	_, err := repo.AdjustStock(echo.New().NewContext(nil, nil), "test-id", -3)
+
+	assert.Equal(t, utils.ErrInsufficientStock, err)
+	mockCollection.AssertExpectations(t)
+}
+
+func TestAdjustVariantStock(t *testing.T) {
+	mockCollection := new(MockCollection)
+	repo := repositories.ProductRepository{Collection: mockCollection}
+
+	updated := mongo.NewSingleResultFromDocument(models.Product{
+		ProductID: "test-id",
+		Stock:     4,
+		Variants:  []models.Variant{{SKU: "S", Stock: 1}, {SKU: "M", Stock: 3}},
+	}, nil, nil)
+	mockCollection.On("FindOneAndUpdate", mock.Anything, bson.M{
+		"product_id": "test-id",
+		"deleted_at": nil,
+		"variants":   bson.M{"$elemMatch": bson.M{"sku": "S", "stock": bson.M{"$gte": 2}}},
+		"$expr":      bson.M{"$gte": bson.A{bson.M{"$subtract": bson.A{"$stock", bson.M{"$ifNull": bson.A{"$reserved", 0}}}}, 2}},
+	}, mock.MatchedBy(func(update bson.M) bool {
+		inc := update["$inc"].(bson.M)
+		return inc["variants.$.stock"] == -2 && inc["stock"] == -2
+	})).Return(updated)
+
+	product, err := repo.AdjustVariantStock(echo.New().NewContext(nil, nil), "test-id", "S", -2)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 4, product.Stock)
+	mockCollection.AssertExpectations(t)
+}
+
+func TestAdjustVariantStock_UnknownSKU(t *testing.T) {
+	mockCollection := new(MockCollection)
+	repo := repositories.ProductRepository{Collection: mockCollection}
+
+	noMatch := mongo.NewSingleResultFromDocument(bson.M{}, mongo.ErrNoDocuments, nil)
+	mockCollection.On("FindOneAndUpdate", mock.Anything, mock.Anything, mock.Anything).Return(noMatch)
+	existing := mongo.NewSingleResultFromDocument(models.Product{ProductID: "test-id", Variants: []models.Variant{{SKU: "M", Stock: 3}}}, nil, nil)
+	mockCollection.On("FindOne", mock.Anything, bson.M{"product_id": "test-id", "deleted_at": nil}).Return(existing)
+
+	_, err := repo.AdjustVariantStock(echo.New().NewContext(nil, nil), "test-id", "S", 1)
+
+	assert.Equal(t, utils.ErrVariantNotFound, err)
+}
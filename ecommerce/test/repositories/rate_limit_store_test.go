@@ -0,0 +1,42 @@
+package repositories_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/YugenDev/global-mobility-test/internal/models"
+	"github.com/YugenDev/global-mobility-test/internal/repositories"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryRateLimitStoreRefillsTokens(t *testing.T) {
+	store := repositories.NewMemoryRateLimitStore()
+	limit := models.RateLimit{Requests: 2, Period: 2 * time.Second}
+	now := time.Now()
+
+	result, err := store.Take("ip:1", limit, now)
+	assert.NoError(t, err)
+	assert.True(t, result.Allowed)
+	assert.Equal(t, 2, result.Limit)
+	assert.Equal(t, 1, result.Remaining)
+	assert.Equal(t, time.Second, result.Reset)
+
+	result, _ = store.Take("ip:1", limit, now)
+	assert.True(t, result.Allowed)
+	assert.Equal(t, 0, result.Remaining)
+
+	result, _ = store.Take("ip:1", limit, now.Add(500*time.Millisecond))
+	assert.False(t, result.Allowed)
+	assert.Equal(t, 500*time.Millisecond, result.RetryAfter)
+
+	result, _ = store.Take("ip:2", limit, now)
+	assert.True(t, result.Allowed)
+
+	result, _ = store.Take("ip:1", limit, now.Add(time.Second))
+	assert.True(t, result.Allowed)
+	assert.Equal(t, 0, result.Remaining)
+
+	result, _ = store.Take("ip:1", limit, now.Add(time.Hour))
+	assert.True(t, result.Allowed)
+	assert.Equal(t, 1, result.Remaining)
+}
@@ -0,0 +1,79 @@
+package repositories_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/YugenDev/global-mobility-test/internal/models"
+	"github.com/YugenDev/global-mobility-test/internal/repositories"
+	"github.com/YugenDev/global-mobility-test/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestGetReservationByID_NotFound(t *testing.T) {
+	mockCollection := new(MockCollection)
+	repo := repositories.ReservationRepository{Collection: mockCollection}
+
+	mockSingleResult := mongo.NewSingleResultFromDocument(bson.M{}, mongo.ErrNoDocuments, nil)
+	mockCollection.On("FindOne", mock.Anything, bson.M{"reservation_id": "missing"}).Return(mockSingleResult)
+
+	_, err := repo.GetReservationByID("missing")
+
+	assert.Equal(t, utils.ErrReservationNotFound, err)
+	mockCollection.AssertExpectations(t)
+}
+
+func TestTransitionReservation(t *testing.T) {
+	mockCollection := new(MockCollection)
+	repo := repositories.ReservationRepository{Collection: mockCollection}
+
+	mockResult := &mongo.UpdateResult{MatchedCount: 1, ModifiedCount: 1}
+	mockCollection.On("UpdateOne", mock.Anything, bson.M{"reservation_id": "r-1", "status": models.ReservationStatusActive}, mock.Anything).Return(mockResult, nil)
+
+	result, err := repo.TransitionReservation("r-1", models.ReservationStatusActive, models.ReservationStatusConfirmed)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), result.MatchedCount)
+	mockCollection.AssertExpectations(t)
+}
+
+func TestGetExpiredReservations(t *testing.T) {
+	mockCollection := new(MockCollection)
+	repo := repositories.ReservationRepository{Collection: mockCollection}
+
+	now := time.Now()
+	cursor, err := mongo.NewCursorFromDocuments([]interface{}{models.Reservation{ReservationID: "r-1"}}, nil, nil)
+	assert.NoError(t, err)
+	mockCollection.On("Find", mock.Anything, bson.M{"status": models.ReservationStatusActive, "expires_at": bson.M{"$lte": now}}).Return(cursor, nil)
+
+	reservations, err := repo.GetExpiredReservations(now, 10)
+
+	assert.NoError(t, err)
+	assert.Len(t, reservations, 1)
+	mockCollection.AssertExpectations(t)
+}
+
+func TestMemoryReservationTransitions(t *testing.T) {
+	repo := repositories.NewMemoryReservationRepository()
+
+	now := time.Now()
+	repo.CreateReservation(&models.Reservation{ReservationID: "expired", Status: models.ReservationStatusActive, ExpiresAt: now.Add(-time.Minute)})
+	repo.CreateReservation(&models.Reservation{ReservationID: "live", Status: models.ReservationStatusActive, ExpiresAt: now.Add(time.Minute)})
+
+	expired, err := repo.GetExpiredReservations(now, 0)
+	assert.NoError(t, err)
+	if assert.Len(t, expired, 1) {
+		assert.Equal(t, "expired", expired[0].ReservationID)
+	}
+
+	result, _ := repo.TransitionReservation("expired", models.ReservationStatusActive, models.ReservationStatusExpired)
+	assert.Equal(t, int64(1), result.MatchedCount)
+	result, _ = repo.TransitionReservation("expired", models.ReservationStatusActive, models.ReservationStatusConfirmed)
+	assert.Equal(t, int64(0), result.MatchedCount)
+
+	_, err = repo.GetReservationByID("missing")
+	assert.Equal(t, utils.ErrReservationNotFound, err)
+}
@@ -0,0 +1,72 @@
+package repositories_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/YugenDev/global-mobility-test/internal/models"
+	"github.com/YugenDev/global-mobility-test/internal/repositories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestAdjustRatingIncrementsCounters(t *testing.T) {
+	mockCollection := new(MockCollection)
+	repo := repositories.ProductRepository{Collection: mockCollection}
+
+	mockCollection.On("UpdateOne", mock.Anything, bson.M{"product_id": "1"}, mock.MatchedBy(func(update bson.A) bool {
+		counters := update[0].(bson.M)["$set"].(bson.M)
+		_, average := update[1].(bson.M)["$set"].(bson.M)["rating_average"]
+		return counters["rating_count"] != nil && counters["rating_total"] != nil && average
+	})).Return(&mongo.UpdateResult{MatchedCount: 1}, nil)
+
+	result, err := repo.AdjustRating("1", 1, 4)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), result.MatchedCount)
+	mockCollection.AssertExpectations(t)
+}
+
+func TestTransitionReview(t *testing.T) {
+	mockCollection := new(MockCollection)
+	repo := repositories.ReviewRepository{Collection: mockCollection}
+
+	mockCollection.On("UpdateOne", mock.Anything, bson.M{"review_id": "r-1", "status": models.ReviewStatusPending}, mock.MatchedBy(func(update bson.M) bool {
+		return update["$set"].(bson.M)["status"] == models.ReviewStatusApproved
+	})).Return(&mongo.UpdateResult{MatchedCount: 1}, nil)
+
+	result, err := repo.TransitionReview("r-1", models.ReviewStatusPending, models.ReviewStatusApproved)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), result.MatchedCount)
+	mockCollection.AssertExpectations(t)
+}
+
+func TestMemoryReviewsPage(t *testing.T) {
+	repo := repositories.NewMemoryReviewRepository()
+
+	now := time.Now()
+	repo.CreateReview(&models.Review{ReviewID: "a", ProductID: "1", Status: models.ReviewStatusApproved, CreatedAt: now.Add(-time.Hour)})
+	repo.CreateReview(&models.Review{ReviewID: "b", ProductID: "1", Status: models.ReviewStatusApproved, CreatedAt: now})
+	repo.CreateReview(&models.Review{ReviewID: "c", ProductID: "1", Status: models.ReviewStatusPending, CreatedAt: now})
+	repo.CreateReview(&models.Review{ReviewID: "d", ProductID: "2", Status: models.ReviewStatusApproved, CreatedAt: now})
+
+	reviews, err := repo.GetReviews(models.ReviewQuery{ProductID: "1", Status: models.ReviewStatusApproved, Limit: 1})
+	assert.NoError(t, err)
+	if assert.Len(t, reviews, 1) {
+		assert.Equal(t, "b", reviews[0].ReviewID)
+	}
+
+	reviews, _ = repo.GetReviews(models.ReviewQuery{ProductID: "1", Status: models.ReviewStatusApproved, After: &models.ReviewCursor{CreatedAt: now, ReviewID: "b"}})
+	if assert.Len(t, reviews, 1) {
+		assert.Equal(t, "a", reviews[0].ReviewID)
+	}
+
+	reviews, _ = repo.GetReviews(models.ReviewQuery{Status: models.ReviewStatusApproved})
+	assert.Len(t, reviews, 3)
+
+	result, _ := repo.TransitionReview("c", models.ReviewStatusApproved, models.ReviewStatusRejected)
+	assert.Equal(t, int64(0), result.MatchedCount)
+}
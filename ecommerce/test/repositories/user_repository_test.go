@@ -0,0 +1,39 @@
+package repositories_test
+
+import (
+	"testing"
+
+	"github.com/YugenDev/global-mobility-test/internal/models"
+	"github.com/YugenDev/global-mobility-test/internal/repositories"
+	"github.com/YugenDev/global-mobility-test/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestCreateUserDuplicateEmail(t *testing.T) {
+	mockCollection := new(MockCollection)
+	repo := repositories.UserRepository{Collection: mockCollection}
+
+	duplicate := mongo.WriteException{WriteErrors: []mongo.WriteError{{Code: 11000}}}
+	mockCollection.On("InsertOne", mock.Anything, mock.Anything).Return(nil, duplicate)
+
+	err := repo.CreateUser(&models.User{UserID: "u-1", Email: "ada@example.com"})
+
+	assert.Equal(t, utils.ErrEmailTaken, err)
+	mockCollection.AssertExpectations(t)
+}
+
+func TestMemoryUsers(t *testing.T) {
+	repo := repositories.NewMemoryUserRepository()
+
+	assert.NoError(t, repo.CreateUser(&models.User{UserID: "u-1", Email: "ada@example.com"}))
+	assert.Equal(t, utils.ErrEmailTaken, repo.CreateUser(&models.User{UserID: "u-2", Email: "ada@example.com"}))
+
+	user, err := repo.GetUserByEmail("ada@example.com")
+	assert.NoError(t, err)
+	assert.Equal(t, "u-1", user.UserID)
+
+	_, err = repo.GetUserByID("u-2")
+	assert.Equal(t, utils.ErrUserNotFound, err)
+}
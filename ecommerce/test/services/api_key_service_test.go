@@ -0,0 +1,69 @@
+package services_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/YugenDev/global-mobility-test/internal/models"
+	"github.com/YugenDev/global-mobility-test/internal/repositories"
+	"github.com/YugenDev/global-mobility-test/internal/services"
+	"github.com/YugenDev/global-mobility-test/internal/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateAPIKeyStoresOnlyAHash(t *testing.T) {
+	repo := repositories.NewMemoryAPIKeyRepository()
+	service := services.NewAPIKeyService(repo)
+
+	created, err := service.Create(models.APIKeyRequest{Name: " warehouse ", Scopes: []string{models.PermissionStockAdjust, models.PermissionStockAdjust}}, "root@example.com")
+	assert.NoError(t, err)
+	assert.Equal(t, "warehouse", created.Name)
+	assert.Equal(t, []string{models.PermissionStockAdjust}, created.Scopes)
+
+	keys, _ := service.GetAll()
+	if assert.Len(t, keys, 1) {
+		assert.NotEmpty(t, keys[0].KeyHash)
+		assert.NotEqual(t, created.Key, keys[0].KeyHash)
+	}
+
+	_, err = service.Create(models.APIKeyRequest{Name: "warehouse"}, "")
+	assert.Equal(t, utils.ErrInvalidAPIKeyScope, err)
+	_, err = service.Create(models.APIKeyRequest{Scopes: []string{models.PermissionStockAdjust}}, "")
+	assert.Equal(t, utils.ErrAPIKeyNameRequired, err)
+}
+
+func TestAuthenticateAPIKey(t *testing.T) {
+	service := services.NewAPIKeyService(repositories.NewMemoryAPIKeyRepository())
+
+	created, _ := service.Create(models.APIKeyRequest{Name: "sync", Scopes: []string{models.PermissionProductsWrite}}, "")
+	identity, err := service.Authenticate(created.Key)
+	assert.NoError(t, err)
+	assert.Equal(t, created.KeyID, identity.APIKeyID)
+	assert.True(t, identity.Can(models.PermissionProductsWrite))
+	assert.False(t, identity.Can(models.PermissionStockAdjust))
+
+	_, err = service.Authenticate(created.Key + "x")
+	assert.Equal(t, utils.ErrInvalidAPIKey, err)
+
+	assert.NoError(t, service.Revoke(created.KeyID))
+	_, err = service.Authenticate(created.Key)
+	assert.Equal(t, utils.ErrInvalidAPIKey, err)
+	assert.Equal(t, utils.ErrAPIKeyNotFound, service.Revoke(created.KeyID))
+}
+
+func TestExpiredAPIKeyIsRejected(t *testing.T) {
+	repo := repositories.NewMemoryAPIKeyRepository()
+	service := services.NewAPIKeyService(repo)
+
+	expiresAt := time.Now().Add(time.Hour)
+	created, err := service.Create(models.APIKeyRequest{Name: "sync", Scopes: []string{models.PermissionProductsWrite}, ExpiresAt: &expiresAt}, "")
+	assert.NoError(t, err)
+
+	past := time.Now().Add(-time.Minute)
+	key := created.APIKey
+	key.ExpiresAt = &past
+	repo.CreateAPIKey(&key)
+
+	_, err = service.Authenticate(created.Key)
+	assert.Equal(t, utils.ErrInvalidAPIKey, err)
+}
@@ -0,0 +1,99 @@
+package services_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/YugenDev/global-mobility-test/internal/models"
+	"github.com/YugenDev/global-mobility-test/internal/repositories"
+	"github.com/YugenDev/global-mobility-test/internal/services"
+	"github.com/YugenDev/global-mobility-test/internal/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func newAuthService() *services.AuthService {
+	return services.NewAuthService(repositories.NewMemoryUserRepository(), strings.Repeat("k", 32), time.Minute, time.Hour)
+}
+
+func TestRegisterNormalizesEmail(t *testing.T) {
+	service := newAuthService()
+
+	user, err := service.Register(models.RegisterRequest{Email: "  Ada@Example.COM ", Password: "correct horse"})
+	assert.NoError(t, err)
+	assert.Equal(t, "ada@example.com", user.Email)
+	assert.NotEqual(t, "correct horse", user.PasswordHash)
+
+	_, err = service.Register(models.RegisterRequest{Email: "Ada <ada@example.com>", Password: "correct horse"})
+	assert.Equal(t, utils.ErrInvalidEmail, err)
+	_, err = service.Register(models.RegisterRequest{Email: "grace@example.com", Password: strings.Repeat("p", 73)})
+	assert.Equal(t, utils.ErrInvalidPassword, err)
+}
+
+func TestAuthenticateRejectsForeignAndExpiredTokens(t *testing.T) {
+	service := newAuthService()
+	service.Register(models.RegisterRequest{Email: "ada@example.com", Password: "correct horse"})
+
+	tokens, err := service.Login(models.LoginRequest{Email: "ADA@example.com", Password: "correct horse"})
+	assert.NoError(t, err)
+
+	identity, err := service.Authenticate(tokens.AccessToken)
+	assert.NoError(t, err)
+	assert.Equal(t, "ada@example.com", identity.Email)
+
+	other := newAuthService()
+	other.Secret = []byte(strings.Repeat("x", 32))
+	_, err = other.Authenticate(tokens.AccessToken)
+	assert.Equal(t, utils.ErrInvalidToken, err)
+
+	service.AccessTokenTTL = -time.Minute
+	tokens, _ = service.Login(models.LoginRequest{Email: "ada@example.com", Password: "correct horse"})
+	_, err = service.Authenticate(tokens.AccessToken)
+	assert.Equal(t, utils.ErrInvalidToken, err)
+}
+
+func TestRolesGrantPermissions(t *testing.T) {
+	service := newAuthService()
+
+	ada, _ := service.Register(models.RegisterRequest{Email: "ada@example.com", Password: "correct horse"})
+	tokens, _ := service.Login(models.LoginRequest{Email: "ada@example.com", Password: "correct horse"})
+	identity, _ := service.Authenticate(tokens.AccessToken)
+	assert.False(t, identity.Can(models.PermissionProductsWrite))
+
+	ada, err := service.SetRoles(ada.UserID, []string{models.RoleCatalogEditor, models.RoleCatalogEditor})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{models.RoleCatalogEditor}, ada.Roles)
+	_, err = service.SetRoles(ada.UserID, []string{"owner"})
+	assert.Equal(t, utils.ErrInvalidRole, err)
+
+	tokens, _ = service.Refresh(tokens.RefreshToken)
+	identity, _ = service.Authenticate(tokens.AccessToken)
+	assert.True(t, identity.Can(models.PermissionProductsWrite))
+	assert.True(t, identity.Can(models.PermissionStockAdjust))
+	assert.False(t, identity.Can(models.PermissionUsersManage))
+}
+
+func TestEnsureAdminNeverPromotesRegisteredAccounts(t *testing.T) {
+	service := newAuthService()
+
+	root, err := service.EnsureAdmin(" Root@Example.com", "super secret")
+	assert.NoError(t, err)
+	assert.Equal(t, "root@example.com", root.Email)
+	assert.Equal(t, []string{models.RoleAdmin}, root.Roles)
+
+	again, err := service.EnsureAdmin("root@example.com", "")
+	assert.NoError(t, err)
+	assert.Equal(t, root.UserID, again.UserID)
+	tokens, err := service.Login(models.LoginRequest{Email: "root@example.com", Password: "super secret"})
+	assert.NoError(t, err)
+	identity, _ := service.Authenticate(tokens.AccessToken)
+	assert.True(t, identity.Can(models.PermissionUsersManage))
+
+	squatter, err := service.Register(models.RegisterRequest{Email: "boss@example.com", Password: "correct horse"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{models.RoleViewer}, squatter.Roles)
+	_, err = service.EnsureAdmin("boss@example.com", "super secret")
+	assert.Equal(t, utils.ErrAdminEmailTaken, err)
+	_, err = service.EnsureAdmin("new@example.com", "short")
+	assert.Equal(t, utils.ErrInvalidPassword, err)
+}
@@ -0,0 +1,130 @@
+package services_test
+
+import (
+	"testing"
+
+	"github.com/YugenDev/global-mobility-test/internal/models"
+	"github.com/YugenDev/global-mobility-test/internal/services"
+	"github.com/YugenDev/global-mobility-test/internal/utils"
+	"github.com/YugenDev/global-mobility-test/test/testutil"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+// newStore is a memory store with two products in stock.
+func newStore(t *testing.T) *testutil.MemoryStore {
+	store := testutil.NewMemoryStore(t)
+	c := echo.New().NewContext(nil, nil)
+	store.Products.CreateProduct(c, &models.Product{ProductID: "shoes", Name: "Shoes", Description: "Running", Price: usd("50"), Stock: 3})
+	store.Products.CreateProduct(c, &models.Product{ProductID: "hat", Name: "Hat", Description: "Warm", Price: usd("10"), Stock: 10})
+	return store
+}
+
+func newCartService(t *testing.T) (*services.CartService, *services.ProductService) {
+	store := newStore(t)
+	return store.Carts, store.Products
+}
+
+func TestCartTotals(t *testing.T) {
+	service, productService := newCartService(t)
+	c := echo.New().NewContext(nil, nil)
+
+	cart, err := service.CreateCart(c)
+	assert.NoError(t, err)
+	assert.Empty(t, cart.Items)
+
+	cart, err = service.AddItem(c, cart.CartID, models.CartItemRequest{ProductID: "shoes", Quantity: 2})
+	assert.NoError(t, err)
+	cart, err = service.AddItem(c, cart.CartID, models.CartItemRequest{ProductID: "hat", Quantity: 1})
+	assert.NoError(t, err)
+	cart, err = service.AddItem(c, cart.CartID, models.CartItemRequest{ProductID: "hat", Quantity: 2})
+	assert.NoError(t, err)
+
+	assert.Len(t, cart.Items, 2)
+	assert.Equal(t, 5, cart.ItemCount)
+	assert.Equal(t, usd("130"), cart.Total)
+
+	productService.UpdateProduct(c, "shoes", &models.Product{Price: usd("80")})
+
+	cart, err = service.UpdateItem(c, cart.CartID, "shoes", "", 1)
+	assert.NoError(t, err)
+	assert.Equal(t, usd("50"), cart.Items[0].UnitPrice)
+	assert.Equal(t, usd("80"), cart.Total)
+
+	cart, err = service.RemoveItem(c, cart.CartID, "hat", "")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, cart.ItemCount)
+	assert.Equal(t, usd("50"), cart.Total)
+
+	stored, _ := service.GetCart(cart.CartID)
+	assert.Equal(t, cart, stored)
+}
+
+func TestCartRejectsMixedCurrencies(t *testing.T) {
+	service, productService := newCartService(t)
+	c := echo.New().NewContext(nil, nil)
+
+	euros, _ := models.ParseMoney("12.50", "EUR")
+	productService.CreateProduct(c, &models.Product{ProductID: "scarf", Name: "Scarf", Description: "Wool", Price: euros, Stock: 5})
+
+	cart, _ := service.CreateCart(c)
+	cart, err := service.AddItem(c, cart.CartID, models.CartItemRequest{ProductID: "scarf", Quantity: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, models.Money{Amount: 2500, Currency: "EUR"}, cart.Total)
+
+	_, err = service.AddItem(c, cart.CartID, models.CartItemRequest{ProductID: "hat", Quantity: 1})
+	assert.Equal(t, utils.ErrCurrencyMismatch, err)
+}
+
+func TestCartValidatesItems(t *testing.T) {
+	service, _ := newCartService(t)
+	c := echo.New().NewContext(nil, nil)
+
+	cart, _ := service.CreateCart(c)
+
+	_, err := service.AddItem(c, cart.CartID, models.CartItemRequest{ProductID: "shoes", Quantity: 4})
+	assert.Equal(t, utils.ErrInsufficientStock, err)
+
+	_, err = service.AddItem(c, cart.CartID, models.CartItemRequest{ProductID: "shoes", Quantity: 0})
+	assert.Equal(t, utils.ErrInvalidCartQuantity, err)
+
+	_, err = service.AddItem(c, cart.CartID, models.CartItemRequest{ProductID: "missing", Quantity: 1})
+	assert.Equal(t, utils.ErrNoProductsFound, err)
+
+	_, err = service.UpdateItem(c, cart.CartID, "hat", "", 1)
+	assert.Equal(t, utils.ErrCartItemNotFound, err)
+
+	_, err = service.AddItem(c, "missing", models.CartItemRequest{ProductID: "shoes", Quantity: 1})
+	assert.Equal(t, utils.ErrCartNotFound, err)
+}
+
+func TestCartVariants(t *testing.T) {
+	service, productService := newCartService(t)
+	c := echo.New().NewContext(nil, nil)
+
+	productService.CreateProduct(c, &models.Product{ProductID: "tee", Name: "Tee", Description: "Cotton", Price: usd("20"), Variants: []models.Variant{
+		{SKU: "TEE-S", Options: map[string]string{"size": "S"}, Stock: 1},
+		{SKU: "TEE-M", Options: map[string]string{"size": "M"}, Price: usdPtr("22"), Stock: 3},
+	}})
+
+	cart, _ := service.CreateCart(c)
+
+	_, err := service.AddItem(c, cart.CartID, models.CartItemRequest{ProductID: "tee", Quantity: 1})
+	assert.Equal(t, utils.ErrVariantRequired, err)
+	_, err = service.AddItem(c, cart.CartID, models.CartItemRequest{ProductID: "tee", SKU: "TEE-XL", Quantity: 1})
+	assert.Equal(t, utils.ErrVariantNotFound, err)
+	_, err = service.AddItem(c, cart.CartID, models.CartItemRequest{ProductID: "hat", SKU: "HAT-1", Quantity: 1})
+	assert.Equal(t, utils.ErrVariantNotFound, err)
+	_, err = service.AddItem(c, cart.CartID, models.CartItemRequest{ProductID: "tee", SKU: "TEE-S", Quantity: 2})
+	assert.Equal(t, utils.ErrInsufficientStock, err)
+
+	service.AddItem(c, cart.CartID, models.CartItemRequest{ProductID: "tee", SKU: "TEE-S", Quantity: 1})
+	cart, err = service.AddItem(c, cart.CartID, models.CartItemRequest{ProductID: "tee", SKU: "TEE-M", Quantity: 2})
+	assert.NoError(t, err)
+	assert.Len(t, cart.Items, 2)
+	assert.Equal(t, usd("64"), cart.Total)
+
+	cart, err = service.RemoveItem(c, cart.CartID, "tee", "TEE-S")
+	assert.NoError(t, err)
+	assert.Equal(t, "TEE-M", cart.Items[0].SKU)
+}
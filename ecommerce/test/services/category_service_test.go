@@ -0,0 +1,164 @@
+package services_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/YugenDev/global-mobility-test/internal/models"
+	"github.com/YugenDev/global-mobility-test/internal/services"
+	"github.com/YugenDev/global-mobility-test/internal/utils"
+	"github.com/YugenDev/global-mobility-test/test/testutil"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func newCategoryService(t *testing.T) (*services.CategoryService, *services.ProductService) {
+	store := testutil.NewMemoryStore(t)
+	return store.Categories, store.Products
+}
+
+func TestCategoryTreeAndSlugs(t *testing.T) {
+	service, _ := newCategoryService(t)
+
+	clothing := models.Category{Name: "Clothing & Shoes"}
+	assert.NoError(t, service.CreateCategory(&clothing))
+	assert.Equal(t, "clothing-shoes", clothing.Slug)
+
+	hats := models.Category{Name: "Hats", ParentID: clothing.CategoryID, Position: 2}
+	boots := models.Category{Name: "Boots", ParentID: clothing.CategoryID, Position: 1}
+	assert.NoError(t, service.CreateCategory(&hats))
+	assert.NoError(t, service.CreateCategory(&boots))
+
+	assert.Equal(t, utils.ErrCategorySlugExists, service.CreateCategory(&models.Category{Name: "Hats"}))
+	assert.Equal(t, utils.ErrInvalidCategorySlug, service.CreateCategory(&models.Category{Name: "Bags", Slug: "Bags!"}))
+	assert.Equal(t, utils.ErrParentCategoryNotFound, service.CreateCategory(&models.Category{Name: "Bags", ParentID: "missing"}))
+
+	tree, err := service.GetTree()
+	assert.NoError(t, err)
+	assert.Len(t, tree, 1)
+	assert.Equal(t, "Boots", tree[0].Children[0].Name)
+	assert.Equal(t, "Hats", tree[0].Children[1].Name)
+}
+
+func TestUpdateCategory_RejectsCycle(t *testing.T) {
+	service, _ := newCategoryService(t)
+
+	root := models.Category{Name: "Root"}
+	service.CreateCategory(&root)
+	child := models.Category{Name: "Child", ParentID: root.CategoryID}
+	service.CreateCategory(&child)
+
+	err := service.UpdateCategory(root.CategoryID, &models.Category{Name: "Root", ParentID: child.CategoryID})
+	assert.Equal(t, utils.ErrCategoryCycle, err)
+
+	err = service.UpdateCategory(root.CategoryID, &models.Category{Name: "Root", ParentID: root.CategoryID})
+	assert.Equal(t, utils.ErrCategoryCycle, err)
+
+	update := models.Category{Name: "Child"}
+	assert.NoError(t, service.UpdateCategory(child.CategoryID, &update))
+	stored, _ := service.GetByID(child.CategoryID)
+	assert.Empty(t, stored.ParentID)
+}
+
+func TestCategoryProducts_IncludeDescendants(t *testing.T) {
+	service, productService := newCategoryService(t)
+	c := echo.New().NewContext(nil, nil)
+
+	clothing := models.Category{Name: "Clothing"}
+	service.CreateCategory(&clothing)
+	shoes := models.Category{Name: "Shoes", ParentID: clothing.CategoryID}
+	service.CreateCategory(&shoes)
+
+	assert.NoError(t, productService.CreateProduct(c, &models.Product{ProductID: "1", Name: "Shirt", Description: "Cotton", Price: usd("20"), CategoryIDs: []string{clothing.CategoryID}}))
+	assert.NoError(t, productService.CreateProduct(c, &models.Product{ProductID: "2", Name: "Boot", Description: "Leather", Price: usd("90"), CategoryIDs: []string{shoes.CategoryID, shoes.CategoryID}}))
+	assert.Equal(t, utils.ErrUnknownCategory, productService.CreateProduct(c, &models.Product{ProductID: "3", Name: "Hat", Description: "Wool", Price: usd("15"), CategoryIDs: []string{"missing"}}))
+
+	boot, _ := productService.GetByID("2")
+	assert.Equal(t, []string{shoes.CategoryID}, boot.CategoryIDs)
+
+	page, err := service.GetProducts(clothing.CategoryID, false, models.ProductQuery{})
+	assert.NoError(t, err)
+	assert.Len(t, page.Products, 1)
+
+	page, err = service.GetProducts(clothing.CategoryID, true, models.ProductQuery{})
+	assert.NoError(t, err)
+	assert.Len(t, page.Products, 2)
+
+	_, err = service.GetProducts("missing", true, models.ProductQuery{})
+	assert.Equal(t, utils.ErrCategoryNotFound, err)
+}
+
+func TestDeleteCategory(t *testing.T) {
+	service, productService := newCategoryService(t)
+	c := echo.New().NewContext(nil, nil)
+
+	clothing := models.Category{Name: "Clothing"}
+	service.CreateCategory(&clothing)
+	shoes := models.Category{Name: "Shoes", ParentID: clothing.CategoryID}
+	service.CreateCategory(&shoes)
+	sale := models.Category{Name: "Sale"}
+	service.CreateCategory(&sale)
+
+	productService.CreateProduct(c, &models.Product{ProductID: "1", Name: "Boot", Description: "Leather", Price: usd("90"), CategoryIDs: []string{shoes.CategoryID}})
+	productService.DeleteProduct(c, "1", 0)
+
+	assert.Equal(t, utils.ErrCategoryHasChildren, service.DeleteCategory(c, clothing.CategoryID, ""))
+	assert.Equal(t, utils.ErrCategoryHasProducts, service.DeleteCategory(c, shoes.CategoryID, ""))
+	assert.Equal(t, utils.ErrInvalidReassignCategory, service.DeleteCategory(c, shoes.CategoryID, shoes.CategoryID))
+	assert.Equal(t, utils.ErrInvalidReassignCategory, service.DeleteCategory(c, shoes.CategoryID, "missing"))
+
+	assert.NoError(t, service.DeleteCategory(c, shoes.CategoryID, sale.CategoryID))
+	assert.NoError(t, service.DeleteCategory(c, clothing.CategoryID, ""))
+
+	productService.RestoreProduct(c, "1")
+	boot, _ := productService.GetByID("1")
+	assert.Equal(t, []string{sale.CategoryID}, boot.CategoryIDs)
+
+	history, _ := productService.GetHistory(models.AuditQuery{ProductID: "1"})
+	reassigned := history.Entries[1]
+	assert.Equal(t, models.AuditActionUpdate, reassigned.Action)
+	if assert.Len(t, reassigned.Changes, 1) {
+		assert.Equal(t, "category_ids", reassigned.Changes[0].Field)
+		assert.Equal(t, []string{sale.CategoryID}, reassigned.Changes[0].After)
+	}
+}
+
+func TestDeleteCategoryReassignsEveryPage(t *testing.T) {
+	service, productService := newCategoryService(t)
+	c := echo.New().NewContext(nil, nil)
+
+	shoes := models.Category{Name: "Shoes"}
+	service.CreateCategory(&shoes)
+	sale := models.Category{Name: "Sale"}
+	service.CreateCategory(&sale)
+
+	total := services.MaxPageLimit + 5
+	for i := 0; i < total; i++ {
+		productService.CreateProduct(c, &models.Product{ProductID: fmt.Sprintf("%d", i), Name: "Boot", Description: "Leather", Price: usd("90"), CategoryIDs: []string{shoes.CategoryID}})
+	}
+	productService.CreateProduct(c, &models.Product{ProductID: "other", Name: "Hat", Description: "Wool", Price: usd("20")})
+	productService.DeleteProduct(c, "0", 0)
+
+	assert.NoError(t, service.DeleteCategory(c, shoes.CategoryID, sale.CategoryID))
+
+	page, err := service.GetProducts(sale.CategoryID, false, models.ProductQuery{Limit: services.MaxPageLimit})
+	assert.NoError(t, err)
+	assert.Len(t, page.Products, services.MaxPageLimit)
+	assert.True(t, page.HasMore)
+
+	updates := func(id string) int {
+		history, err := productService.GetHistory(models.AuditQuery{ProductID: id})
+		assert.NoError(t, err)
+		count := 0
+		for _, entry := range history.Entries {
+			if entry.Action == models.AuditActionUpdate {
+				count++
+			}
+		}
+		return count
+	}
+	for i := 0; i < total; i++ {
+		assert.Equal(t, 1, updates(fmt.Sprintf("%d", i)))
+	}
+	assert.Equal(t, 0, updates("other"))
+}
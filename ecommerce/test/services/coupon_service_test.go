@@ -0,0 +1,128 @@
+package services_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/YugenDev/global-mobility-test/internal/models"
+	"github.com/YugenDev/global-mobility-test/internal/services"
+	"github.com/YugenDev/global-mobility-test/internal/utils"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func newCouponService(t *testing.T) (*services.CouponService, *services.OrderService, *services.ProductService) {
+	store := newStore(t)
+	return store.Coupons, store.Orders, store.Products
+}
+
+var shoesAndHats = []models.CartItemRequest{{ProductID: "shoes", Quantity: 1}, {ProductID: "hat", Quantity: 3}}
+
+func TestPercentageCoupon(t *testing.T) {
+	service, _, _ := newCouponService(t)
+
+	coupon := models.Coupon{Code: " save15 ", Type: models.CouponTypePercentage, Percent: 15}
+	assert.NoError(t, service.CreateCoupon(&coupon))
+	assert.Equal(t, "SAVE15", coupon.Code)
+
+	evaluation, err := service.Evaluate(models.CouponEvaluationRequest{Code: "save15", Items: shoesAndHats})
+	assert.NoError(t, err)
+	assert.Equal(t, usd("80"), evaluation.Subtotal)
+	assert.Equal(t, usd("12"), evaluation.Discount)
+	assert.Equal(t, usd("68"), evaluation.Total)
+	assert.Equal(t, usdPtr("7.50"), evaluation.Items[0].Discount)
+	assert.Equal(t, usdPtr("4.50"), evaluation.Items[1].Discount)
+}
+
+func TestFixedCouponIsSpreadAndCapped(t *testing.T) {
+	service, _, _ := newCouponService(t)
+
+	service.CreateCoupon(&models.Coupon{Code: "TENOFF", Type: models.CouponTypeFixed, Amount: usdPtr("10")})
+	service.CreateCoupon(&models.Coupon{Code: "HATS", Type: models.CouponTypeFixed, Amount: usdPtr("100"), ProductIDs: []string{"hat"}})
+
+	evaluation, err := service.Evaluate(models.CouponEvaluationRequest{Code: "TENOFF", Items: shoesAndHats})
+	assert.NoError(t, err)
+	assert.Equal(t, usdPtr("6.25"), evaluation.Items[0].Discount)
+	assert.Equal(t, usdPtr("3.75"), evaluation.Items[1].Discount)
+	assert.Equal(t, usd("70"), evaluation.Total)
+
+	evaluation, err = service.Evaluate(models.CouponEvaluationRequest{Code: "HATS", Items: shoesAndHats})
+	assert.NoError(t, err)
+	assert.Equal(t, usdPtr("0"), evaluation.Items[0].Discount)
+	assert.Equal(t, usd("30"), evaluation.Discount)
+	assert.Equal(t, usd("50"), evaluation.Total)
+}
+
+func TestBuyXGetYCoupon(t *testing.T) {
+	service, _, _ := newCouponService(t)
+
+	service.CreateCoupon(&models.Coupon{Code: "B2G1", Type: models.CouponTypeBuyXGetY, BuyQuantity: 2, GetQuantity: 1})
+
+	evaluation, err := service.Evaluate(models.CouponEvaluationRequest{Code: "B2G1", Items: shoesAndHats})
+	assert.NoError(t, err)
+	assert.Equal(t, usd("10"), evaluation.Discount)
+	assert.Equal(t, usdPtr("10"), evaluation.Items[1].Discount)
+
+	_, err = service.Evaluate(models.CouponEvaluationRequest{Code: "B2G1", Items: []models.CartItemRequest{{ProductID: "hat", Quantity: 2}}})
+	assert.Equal(t, utils.ErrCouponNotApplicable, err)
+}
+
+func TestCouponRules(t *testing.T) {
+	service, _, productService := newCouponService(t)
+	c := echo.New().NewContext(nil, nil)
+
+	clothing := models.Category{CategoryID: "clothing", Name: "Clothing", Slug: "clothing"}
+	headwear := models.Category{CategoryID: "headwear", Name: "Headwear", Slug: "headwear", ParentID: "clothing"}
+	productService.CategoryRepository.CreateCategory(&clothing)
+	productService.CategoryRepository.CreateCategory(&headwear)
+	productService.UpdateProduct(c, "hat", &models.Product{CategoryIDs: []string{"headwear"}})
+
+	assert.Equal(t, utils.ErrUnknownCategory, service.CreateCoupon(&models.Coupon{Code: "NOPE", Type: models.CouponTypePercentage, Percent: 5, CategoryIDs: []string{"missing"}}))
+	assert.Equal(t, utils.ErrInvalidCouponDiscount, service.CreateCoupon(&models.Coupon{Code: "NOPE", Type: models.CouponTypePercentage, Percent: 150}))
+
+	service.CreateCoupon(&models.Coupon{Code: "CLOTHES", Type: models.CouponTypePercentage, Percent: 50, CategoryIDs: []string{"clothing"}})
+	evaluation, err := service.Evaluate(models.CouponEvaluationRequest{Code: "CLOTHES", Items: shoesAndHats})
+	assert.NoError(t, err)
+	assert.Equal(t, usd("15"), evaluation.Discount)
+
+	service.CreateCoupon(&models.Coupon{Code: "BIGSPEND", Type: models.CouponTypePercentage, Percent: 10, MinSubtotal: usdPtr("100")})
+	_, err = service.Evaluate(models.CouponEvaluationRequest{Code: "BIGSPEND", Items: shoesAndHats})
+	assert.Equal(t, utils.ErrCouponMinimumNotMet, err)
+
+	later := time.Now().Add(time.Hour)
+	service.CreateCoupon(&models.Coupon{Code: "SOON", Type: models.CouponTypePercentage, Percent: 10, StartsAt: &later})
+	_, err = service.Evaluate(models.CouponEvaluationRequest{Code: "SOON", Items: shoesAndHats})
+	assert.Equal(t, utils.ErrCouponNotActive, err)
+
+	_, err = service.Evaluate(models.CouponEvaluationRequest{Code: "MISSING", Items: shoesAndHats})
+	assert.Equal(t, utils.ErrCouponNotFound, err)
+}
+
+func TestCheckoutRedeemsCoupon(t *testing.T) {
+	service, orderService, _ := newCouponService(t)
+	c := echo.New().NewContext(nil, nil)
+
+	coupon := models.Coupon{Code: "ONCE", Type: models.CouponTypeFixed, Amount: usdPtr("5"), UsageLimit: 1}
+	service.CreateCoupon(&coupon)
+
+	order, err := orderService.Checkout(c, models.CheckoutRequest{Items: shoesAndHats, CouponCode: "once"})
+	assert.NoError(t, err)
+	assert.Equal(t, coupon.CouponID, order.CouponID)
+	assert.Equal(t, usdPtr("5"), order.Discount)
+	assert.Equal(t, usd("75"), order.Total)
+
+	_, err = orderService.Checkout(c, models.CheckoutRequest{Items: shoesAndHats, CouponCode: "ONCE"})
+	assert.Equal(t, utils.ErrCouponUsageLimitReached, err)
+
+	_, err = orderService.UpdateStatus(c, order.OrderID, models.OrderStatusCancelled)
+	assert.NoError(t, err)
+
+	coupon, _ = service.GetByID(coupon.CouponID)
+	assert.Equal(t, 0, coupon.UsageCount)
+
+	_, err = orderService.Checkout(c, models.CheckoutRequest{Items: []models.CartItemRequest{{ProductID: "shoes", Quantity: 9}}, CouponCode: "ONCE"})
+	assert.Equal(t, utils.ErrInsufficientStock, err)
+
+	coupon, _ = service.GetByID(coupon.CouponID)
+	assert.Equal(t, 0, coupon.UsageCount)
+}
@@ -0,0 +1,111 @@
+package services_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/YugenDev/global-mobility-test/internal/models"
+	"github.com/YugenDev/global-mobility-test/internal/repositories"
+	"github.com/YugenDev/global-mobility-test/internal/services"
+	"github.com/YugenDev/global-mobility-test/internal/utils"
+	"github.com/YugenDev/global-mobility-test/test/testutil"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdateExchangeRates(t *testing.T) {
+	service := services.NewExchangeRateService(repositories.NewMemoryExchangeRateRepository())
+
+	rates := &models.ExchangeRates{
+		Base:     "usd",
+		Rates:    map[string]json.Number{"eur": "0.92"},
+		Rounding: map[string]models.RoundingRule{"chf": {Increment: 5}},
+	}
+	assert.NoError(t, service.UpdateRates(rates))
+
+	stored, err := service.GetRates()
+	assert.NoError(t, err)
+	assert.Equal(t, "USD", stored.Base)
+	assert.Equal(t, map[string]json.Number{"EUR": "0.92"}, stored.Rates)
+	assert.Equal(t, models.RoundingRule{Mode: models.RoundHalfUp, Increment: 5}, stored.Rounding["CHF"])
+	assert.False(t, stored.UpdatedAt.IsZero())
+
+	invalid := []struct {
+		rates models.ExchangeRates
+		err   error
+	}{
+		{models.ExchangeRates{Base: "XYZ"}, utils.ErrUnsupportedCurrency},
+		{models.ExchangeRates{Rates: map[string]json.Number{"XYZ": "1"}}, utils.ErrUnsupportedCurrency},
+		{models.ExchangeRates{Rates: map[string]json.Number{"EUR": "0"}}, utils.ErrInvalidExchangeRate},
+		{models.ExchangeRates{Rates: map[string]json.Number{"EUR": "abc"}}, utils.ErrInvalidExchangeRate},
+		{models.ExchangeRates{Rates: map[string]json.Number{"USD": "1"}}, utils.ErrInvalidExchangeRate},
+		{models.ExchangeRates{Rounding: map[string]models.RoundingRule{"EUR": {Mode: "nearest"}}}, utils.ErrInvalidRoundingRule},
+		{models.ExchangeRates{Rounding: map[string]models.RoundingRule{"EUR": {Increment: -1}}}, utils.ErrInvalidRoundingRule},
+	}
+	for _, tt := range invalid {
+		assert.Equal(t, tt.err, service.UpdateRates(&tt.rates))
+	}
+
+	stored, _ = service.GetRates()
+	assert.Equal(t, map[string]json.Number{"EUR": "0.92"}, stored.Rates)
+}
+
+func TestProductPriceIn(t *testing.T) {
+	rateRepo := repositories.NewMemoryExchangeRateRepository()
+	rateRepo.SaveRates(models.ExchangeRates{Base: "USD", Rates: map[string]json.Number{"EUR": "0.9", "GBP": "0.8"}})
+	service := services.NewProductService(repositories.NewMemoryProductRepository(), repositories.NewMemoryAuditRepository(), repositories.NewMemoryCategoryRepository(), rateRepo, repositories.NewMemoryPriceHistoryRepository())
+	c := echo.New().NewContext(nil, nil)
+
+	euros, _ := models.ParseMoney("17.99", "EUR")
+	product := &models.Product{ProductID: "tee", Name: "Tee", Description: "Cotton", Price: usd("20"), Prices: []models.Money{euros}, Variants: []models.Variant{
+		{SKU: "TEE-S", Options: map[string]string{"size": "S"}, Stock: 1},
+		{SKU: "TEE-XL", Options: map[string]string{"size": "XL"}, Price: usdPtr("25"), Stock: 1},
+	}}
+	assert.NoError(t, service.CreateProduct(c, product))
+
+	priced, err := service.PriceIn(*product, "eur")
+	assert.NoError(t, err)
+	assert.Equal(t, euros, priced.Price)
+	assert.Nil(t, priced.Variants[0].Price)
+	assert.Equal(t, &models.Money{Amount: 2250, Currency: "EUR"}, priced.Variants[1].Price)
+	assert.Equal(t, usdPtr("25"), product.Variants[1].Price)
+
+	priced, err = service.PriceIn(*product, "GBP")
+	assert.NoError(t, err)
+	assert.Equal(t, models.Money{Amount: 1600, Currency: "GBP"}, priced.Price)
+
+	_, err = service.PriceIn(*product, "JPY")
+	assert.Equal(t, utils.ErrExchangeRateNotFound, err)
+
+	page, err := service.GetAll(models.ProductQuery{Currency: "EUR"})
+	assert.NoError(t, err)
+	assert.Equal(t, euros, page.Products[0].Price)
+
+	_, err = service.GetAll(models.ProductQuery{Currency: "XYZ"})
+	assert.Equal(t, utils.ErrUnsupportedCurrency, err)
+}
+
+func TestProductPriceOverridesValidation(t *testing.T) {
+	service := testutil.NewProductService(repositories.NewMemoryProductRepository())
+	c := echo.New().NewContext(nil, nil)
+
+	euros, _ := models.ParseMoney("18", "EUR")
+	invalid := [][]models.Money{
+		{usd("18")},
+		{euros, euros},
+		{{Amount: 0, Currency: "EUR"}},
+	}
+	for _, prices := range invalid {
+		product := &models.Product{Name: "Tee", Description: "Cotton", Price: usd("20"), Prices: prices}
+		assert.Equal(t, utils.ErrInvalidPriceOverride, service.CreateProduct(c, product))
+	}
+
+	product := &models.Product{ProductID: "tee", Name: "Tee", Description: "Cotton", Price: usd("20"), Prices: []models.Money{euros}}
+	assert.NoError(t, service.CreateProduct(c, product))
+
+	assert.Equal(t, utils.ErrInvalidPriceOverride, service.UpdateProduct(c, "tee", &models.Product{Price: models.Money{Amount: 1900, Currency: "EUR"}}))
+
+	assert.NoError(t, service.UpdateProduct(c, "tee", &models.Product{Prices: []models.Money{}}))
+	updated, _ := service.GetByID("tee")
+	assert.Empty(t, updated.Prices)
+}
@@ -0,0 +1,59 @@
+package services_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/YugenDev/global-mobility-test/internal/repositories"
+	"github.com/YugenDev/global-mobility-test/internal/services"
+	"github.com/YugenDev/global-mobility-test/internal/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIdempotencyReplaysCompletedRequests(t *testing.T) {
+	service := services.NewIdempotencyService(repositories.NewMemoryIdempotencyRepository(), time.Hour)
+
+	_, replay, err := service.Begin("k", "a")
+	assert.NoError(t, err)
+	assert.False(t, replay)
+
+	_, _, err = service.Begin("k", "a")
+	assert.Equal(t, utils.ErrIdempotencyKeyInProgress, err)
+	_, _, err = service.Begin("k", "b")
+	assert.Equal(t, utils.ErrIdempotencyKeyReused, err)
+
+	assert.NoError(t, service.Complete("k", "a", 201, http.Header{"Content-Type": {"application/json"}, "Etag": {`"1"`}, "Location": {"/products/1"}}, []byte(`{"id":"1"}`)))
+	record, replay, err := service.Begin("k", "a")
+	assert.NoError(t, err)
+	assert.True(t, replay)
+	assert.Equal(t, 201, record.Status)
+	assert.Equal(t, `{"id":"1"}`, string(record.Body))
+	assert.Equal(t, "application/json", record.ContentType)
+	assert.Equal(t, `"1"`, record.ETag)
+	assert.Equal(t, "/products/1", record.Location)
+}
+
+func TestReleasedIdempotencyKeyCanBeReused(t *testing.T) {
+	service := services.NewIdempotencyService(repositories.NewMemoryIdempotencyRepository(), time.Hour)
+
+	service.Begin("k", "a")
+	service.Release("k")
+
+	_, replay, err := service.Begin("k", "b")
+	assert.NoError(t, err)
+	assert.False(t, replay)
+}
+
+func TestCompletingALostIdempotencyClaim(t *testing.T) {
+	service := services.NewIdempotencyService(repositories.NewMemoryIdempotencyRepository(), time.Hour)
+
+	service.Begin("k", "a")
+	service.Release("k")
+	assert.Equal(t, utils.ErrIdempotencyClaimLost, service.Complete("k", "a", 201, http.Header{}, nil))
+
+	service.Begin("k", "b")
+	assert.Equal(t, utils.ErrIdempotencyClaimLost, service.Complete("k", "a", 201, http.Header{}, nil))
+	_, _, err := service.Begin("k", "b")
+	assert.Equal(t, utils.ErrIdempotencyKeyInProgress, err)
+}
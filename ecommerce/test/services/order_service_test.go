@@ -0,0 +1,121 @@
+package services_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/YugenDev/global-mobility-test/internal/models"
+	"github.com/YugenDev/global-mobility-test/internal/services"
+	"github.com/YugenDev/global-mobility-test/internal/utils"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func newOrderService(t *testing.T) (*services.OrderService, *services.CartService, *services.ProductService) {
+	store := newStore(t)
+	return store.Orders, store.Carts, store.Products
+}
+
+func TestCheckoutCart(t *testing.T) {
+	service, cartService, productService := newOrderService(t)
+	c := echo.New().NewContext(nil, nil)
+
+	cart, _ := cartService.CreateCart(c)
+	cartService.AddItem(c, cart.CartID, models.CartItemRequest{ProductID: "shoes", Quantity: 2})
+	productService.UpdateProduct(c, "shoes", &models.Product{Price: usd("99")})
+
+	order, err := service.Checkout(c, models.CheckoutRequest{CartID: cart.CartID})
+	assert.NoError(t, err)
+	assert.Equal(t, models.OrderStatusPending, order.Status)
+	assert.Equal(t, usd("100"), order.Total)
+
+	product, _ := productService.GetByID("shoes")
+	assert.Equal(t, 1, product.Stock)
+
+	cart, _ = cartService.GetCart(cart.CartID)
+	assert.Empty(t, cart.Items)
+
+	_, err = service.Checkout(c, models.CheckoutRequest{CartID: cart.CartID})
+	assert.Equal(t, utils.ErrEmptyOrder, err)
+}
+
+func TestCheckoutItemsRollsBackOnInsufficientStock(t *testing.T) {
+	service, _, productService := newOrderService(t)
+	c := echo.New().NewContext(nil, nil)
+
+	_, err := service.Checkout(c, models.CheckoutRequest{Items: []models.CartItemRequest{
+		{ProductID: "hat", Quantity: 4},
+		{ProductID: "shoes", Quantity: 2},
+		{ProductID: "shoes", Quantity: 2},
+	}})
+	assert.Equal(t, utils.ErrInsufficientStock, err)
+
+	hat, _ := productService.GetByID("hat")
+	assert.Equal(t, 10, hat.Stock)
+
+	_, err = service.Checkout(c, models.CheckoutRequest{})
+	assert.Equal(t, utils.ErrInvalidCheckoutRequest, err)
+}
+
+func TestOrderStatusTransitions(t *testing.T) {
+	service, _, productService := newOrderService(t)
+	c := echo.New().NewContext(nil, nil)
+
+	order, err := service.Checkout(c, models.CheckoutRequest{Items: []models.CartItemRequest{{ProductID: "hat", Quantity: 3}}})
+	assert.NoError(t, err)
+
+	_, err = service.UpdateStatus(c, order.OrderID, models.OrderStatusShipped)
+	assert.Equal(t, utils.ErrInvalidOrderTransition, err)
+
+	_, err = service.UpdateStatus(c, order.OrderID, "lost")
+	assert.Equal(t, utils.ErrInvalidOrderStatus, err)
+
+	order, err = service.UpdateStatus(c, order.OrderID, models.OrderStatusPaid)
+	assert.NoError(t, err)
+	assert.Len(t, order.History, 2)
+
+	order, err = service.UpdateStatus(c, order.OrderID, models.OrderStatusCancelled)
+	assert.NoError(t, err)
+
+	hat, _ := productService.GetByID("hat")
+	assert.Equal(t, 10, hat.Stock)
+
+	_, err = service.UpdateStatus(c, order.OrderID, models.OrderStatusPaid)
+	assert.Equal(t, utils.ErrInvalidOrderTransition, err)
+
+	stored, _ := service.GetByID(order.OrderID)
+	assert.Equal(t, models.OrderStatusCancelled, stored.Status)
+	assert.WithinDuration(t, time.Now(), stored.UpdatedAt, time.Minute)
+}
+
+func TestCheckoutVariants(t *testing.T) {
+	service, _, productService := newOrderService(t)
+	c := echo.New().NewContext(nil, nil)
+
+	productService.CreateProduct(c, &models.Product{ProductID: "tee", Name: "Tee", Description: "Cotton", Price: usd("20"), Variants: []models.Variant{
+		{SKU: "TEE-S", Options: map[string]string{"size": "S"}, Stock: 2},
+		{SKU: "TEE-M", Options: map[string]string{"size": "M"}, Price: usdPtr("22"), Stock: 3},
+	}})
+
+	order, err := service.Checkout(c, models.CheckoutRequest{Items: []models.CartItemRequest{
+		{ProductID: "tee", SKU: "TEE-M", Quantity: 2},
+		{ProductID: "tee", SKU: "TEE-S", Quantity: 1},
+	}})
+	assert.NoError(t, err)
+	assert.Equal(t, usd("64"), order.Total)
+
+	tee, _ := productService.GetByID("tee")
+	medium, _ := tee.Variant("TEE-M")
+	assert.Equal(t, 1, medium.Stock)
+	assert.Equal(t, 2, tee.Stock)
+
+	service.UpdateStatus(c, order.OrderID, models.OrderStatusCancelled)
+
+	tee, _ = productService.GetByID("tee")
+	medium, _ = tee.Variant("TEE-M")
+	assert.Equal(t, 3, medium.Stock)
+	assert.Equal(t, 5, tee.Stock)
+
+	_, err = service.Checkout(c, models.CheckoutRequest{Items: []models.CartItemRequest{{ProductID: "tee", Quantity: 1}}})
+	assert.Equal(t, utils.ErrVariantRequired, err)
+}
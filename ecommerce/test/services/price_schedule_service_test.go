@@ -0,0 +1,196 @@
+package services_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/YugenDev/global-mobility-test/internal/models"
+	"github.com/YugenDev/global-mobility-test/internal/repositories"
+	"github.com/YugenDev/global-mobility-test/internal/services"
+	"github.com/YugenDev/global-mobility-test/internal/utils"
+	"github.com/YugenDev/global-mobility-test/test/testutil"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func newPriceScheduleService(t *testing.T) (*services.PriceScheduleService, *services.ProductService) {
+	productService := testutil.NewProductService(repositories.NewMemoryProductRepository())
+	product := &models.Product{ProductID: "1", Name: "Shirt", Description: "Cotton", Price: usd("20.00"), Stock: 5}
+	assert.NoError(t, productService.CreateProduct(echo.New().NewContext(nil, nil), product))
+
+	return services.NewPriceScheduleService(repositories.NewMemoryPriceScheduleRepository(), productService), productService
+}
+
+func TestCreatePriceScheduleValidation(t *testing.T) {
+	service, _ := newPriceScheduleService(t)
+	c := echo.New().NewContext(nil, nil)
+
+	now := time.Now()
+	friday := now.Add(24 * time.Hour)
+	monday := friday.Add(72 * time.Hour)
+	past := now.Add(-time.Hour)
+
+	tests := []struct {
+		name    string
+		id      string
+		request models.PriceScheduleRequest
+		err     error
+	}{
+		{"missing start", "1", models.PriceScheduleRequest{Price: usd("15.00"), EndsAt: &monday}, utils.ErrInvalidPriceSchedule},
+		{"end before start", "1", models.PriceScheduleRequest{Price: usd("15.00"), StartsAt: monday, EndsAt: &friday}, utils.ErrInvalidPriceSchedule},
+		{"end in the past", "1", models.PriceScheduleRequest{Price: usd("15.00"), StartsAt: past.Add(-time.Hour), EndsAt: &past}, utils.ErrInvalidPriceSchedule},
+		{"zero price", "1", models.PriceScheduleRequest{StartsAt: friday, EndsAt: &monday}, utils.ErrProductPriceInvalid},
+		{"other currency", "1", models.PriceScheduleRequest{Price: models.Money{Amount: 1500, Currency: "EUR"}, StartsAt: friday, EndsAt: &monday}, utils.ErrCurrencyMismatch},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.Create(c, tt.id, tt.request)
+			assert.Equal(t, tt.err, err)
+		})
+	}
+
+	schedule, err := service.Create(c, "1", models.PriceScheduleRequest{Price: usd("15.00"), StartsAt: friday, EndsAt: &monday})
+	assert.NoError(t, err)
+	assert.Equal(t, models.PriceScheduleStatusPending, schedule.Status)
+
+	inside := friday.Add(time.Hour)
+	_, err = service.Create(c, "1", models.PriceScheduleRequest{Price: usd("12.00"), StartsAt: inside})
+	assert.Equal(t, utils.ErrPriceScheduleOverlap, err)
+
+	after := monday.Add(24 * time.Hour)
+	_, err = service.Create(c, "1", models.PriceScheduleRequest{Price: usd("12.00"), StartsAt: monday, EndsAt: &after})
+	assert.NoError(t, err, "a window may start when another ends")
+}
+
+func TestProcessDueAppliesAndRevertsPrice(t *testing.T) {
+	service, productService := newPriceScheduleService(t)
+	c := echo.New().NewContext(nil, nil)
+
+	now := time.Now()
+	start := now.Add(time.Hour)
+	end := now.Add(3 * time.Hour)
+	schedule, err := service.Create(c, "1", models.PriceScheduleRequest{Price: usd("15.00"), StartsAt: start, EndsAt: &end})
+	assert.NoError(t, err)
+
+	processed, err := service.ProcessDue(now)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, processed)
+
+	processed, err = service.ProcessDue(start)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, processed)
+
+	product, _ := productService.GetByID("1")
+	assert.Equal(t, usd("15.00"), product.Price)
+
+	processed, err = service.ProcessDue(end)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, processed)
+
+	product, _ = productService.GetByID("1")
+	assert.Equal(t, usd("20.00"), product.Price)
+
+	schedules, _ := service.GetByProductID("1")
+	assert.Equal(t, models.PriceScheduleStatusEnded, schedules[0].Status)
+
+	history, err := productService.GetPriceHistory(models.PriceHistoryQuery{ProductID: "1"})
+	assert.NoError(t, err)
+	if assert.Len(t, history.Entries, 3) {
+		assert.Equal(t, usd("20.00"), history.Entries[0].Price)
+		assert.Equal(t, models.PriceSourceSchedule, history.Entries[0].Source)
+		assert.Equal(t, schedule.ScheduleID, history.Entries[0].ScheduleID)
+		assert.Equal(t, usd("15.00"), history.Entries[1].Price)
+		assert.Equal(t, usd("20.00"), history.Entries[2].Price)
+		assert.Equal(t, models.PriceSourceManual, history.Entries[2].Source)
+	}
+}
+
+func TestProcessDueKeepsManualPriceChange(t *testing.T) {
+	service, productService := newPriceScheduleService(t)
+	c := echo.New().NewContext(nil, nil)
+
+	now := time.Now()
+	end := now.Add(time.Hour)
+	_, err := service.Create(c, "1", models.PriceScheduleRequest{Price: usd("15.00"), StartsAt: now, EndsAt: &end})
+	assert.NoError(t, err)
+
+	_, err = service.ProcessDue(now)
+	assert.NoError(t, err)
+
+	assert.NoError(t, productService.UpdateProduct(c, "1", &models.Product{Price: usd("18.00")}))
+
+	_, err = service.ProcessDue(end)
+	assert.NoError(t, err)
+
+	product, _ := productService.GetByID("1")
+	assert.Equal(t, usd("18.00"), product.Price)
+}
+
+func TestProcessDueWithoutEndIsPermanent(t *testing.T) {
+	service, productService := newPriceScheduleService(t)
+	c := echo.New().NewContext(nil, nil)
+
+	now := time.Now()
+	_, err := service.Create(c, "1", models.PriceScheduleRequest{Price: usd("25.00"), StartsAt: now})
+	assert.NoError(t, err)
+
+	processed, err := service.ProcessDue(now)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, processed)
+
+	schedules, _ := service.GetByProductID("1")
+	assert.Equal(t, models.PriceScheduleStatusEnded, schedules[0].Status)
+
+	product, _ := productService.GetByID("1")
+	assert.Equal(t, usd("25.00"), product.Price)
+}
+
+func TestProcessDueCancelsScheduleForDeletedProduct(t *testing.T) {
+	service, productService := newPriceScheduleService(t)
+	c := echo.New().NewContext(nil, nil)
+
+	now := time.Now()
+	_, err := service.Create(c, "1", models.PriceScheduleRequest{Price: usd("15.00"), StartsAt: now})
+	assert.NoError(t, err)
+	assert.NoError(t, productService.DeleteProduct(c, "1", 0))
+
+	processed, err := service.ProcessDue(now)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, processed)
+
+	schedules, _ := service.GetByProductID("1")
+	assert.Equal(t, models.PriceScheduleStatusCancelled, schedules[0].Status)
+}
+
+func TestCancelActivePriceScheduleRevertsPrice(t *testing.T) {
+	service, productService := newPriceScheduleService(t)
+	c := echo.New().NewContext(nil, nil)
+
+	now := time.Now()
+	end := now.Add(time.Hour)
+	later := end.Add(time.Hour)
+	pending, _ := service.Create(c, "1", models.PriceScheduleRequest{Price: usd("12.00"), StartsAt: end, EndsAt: &later})
+	active, _ := service.Create(c, "1", models.PriceScheduleRequest{Price: usd("15.00"), StartsAt: now, EndsAt: &end})
+	_, err := service.ProcessDue(now)
+	assert.NoError(t, err)
+
+	_, err = service.Cancel(c, "2", active.ScheduleID)
+	assert.Equal(t, utils.ErrPriceScheduleNotFound, err)
+
+	cancelled, err := service.Cancel(c, "1", active.ScheduleID)
+	assert.NoError(t, err)
+	assert.Equal(t, models.PriceScheduleStatusCancelled, cancelled.Status)
+
+	product, _ := productService.GetByID("1")
+	assert.Equal(t, usd("20.00"), product.Price)
+
+	_, err = service.Cancel(c, "1", active.ScheduleID)
+	assert.Equal(t, utils.ErrPriceScheduleFinished, err)
+
+	_, err = service.Cancel(c, "1", pending.ScheduleID)
+	assert.NoError(t, err)
+
+	product, _ = productService.GetByID("1")
+	assert.Equal(t, usd("20.00"), product.Price)
+}
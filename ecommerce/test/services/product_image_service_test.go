@@ -0,0 +1,83 @@
+package services_test
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/YugenDev/global-mobility-test/internal/models"
+	"github.com/YugenDev/global-mobility-test/internal/repositories"
+	"github.com/YugenDev/global-mobility-test/internal/services"
+	"github.com/YugenDev/global-mobility-test/internal/utils"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+var pngHeader = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+func newProductImageService(t *testing.T) *services.ProductImageService {
+	repo := repositories.NewMemoryProductRepository()
+	repo.CreateProduct(echo.New().NewContext(nil, nil), &models.Product{ProductID: "1", Name: "Shoes", Price: usd("10")})
+	return services.NewProductImageService(repo, repositories.NewMemoryAuditRepository(), repositories.NewLocalBlobStore(t.TempDir()), 64)
+}
+
+func TestAddImage_Validation(t *testing.T) {
+	service := newProductImageService(t)
+	c := echo.New().NewContext(nil, nil)
+
+	_, err := service.AddImage(c, "1", 0, models.ImageUpload{Content: strings.NewReader("plain text")})
+	assert.Equal(t, utils.ErrUnsupportedImageType, err)
+
+	_, err = service.AddImage(c, "1", 0, models.ImageUpload{ContentType: "image/jpeg", Content: bytes.NewReader(pngHeader)})
+	assert.Equal(t, utils.ErrUnsupportedImageType, err)
+
+	_, err = service.AddImage(c, "1", 0, models.ImageUpload{Content: bytes.NewReader(append(pngHeader, make([]byte, 64)...))})
+	assert.Equal(t, utils.ErrImageTooLarge, err)
+
+	_, err = service.AddImage(c, "1", 0, models.ImageUpload{Content: bytes.NewReader(nil)})
+	assert.Equal(t, utils.ErrImageRequired, err)
+}
+
+func TestProductImageLifecycle(t *testing.T) {
+	service := newProductImageService(t)
+	c := echo.New().NewContext(nil, nil)
+
+	product, err := service.AddImage(c, "1", 0, models.ImageUpload{ContentType: "image/png", AltText: "Front", Content: bytes.NewReader(pngHeader)})
+	assert.NoError(t, err)
+	front := product.Images[0]
+	assert.Equal(t, "image/png", front.ContentType)
+	assert.Equal(t, "/products/1/images/"+front.ImageID, front.URL)
+
+	product, err = service.AddImage(c, "1", 0, models.ImageUpload{AltText: "Back", Content: bytes.NewReader(pngHeader)})
+	assert.NoError(t, err)
+	back := product.Images[1]
+	assert.Equal(t, 1, back.Position)
+
+	_, err = service.ReorderImages(c, "1", 0, []string{back.ImageID})
+	assert.Equal(t, utils.ErrInvalidImageOrder, err)
+	_, err = service.ReorderImages(c, "1", 0, []string{back.ImageID, back.ImageID})
+	assert.Equal(t, utils.ErrInvalidImageOrder, err)
+
+	product, err = service.ReorderImages(c, "1", 0, []string{back.ImageID, front.ImageID})
+	assert.NoError(t, err)
+	assert.Equal(t, back.ImageID, product.Images[0].ImageID)
+	assert.Equal(t, 1, product.Images[1].Position)
+
+	image, content, err := service.OpenImage("1", front.ImageID)
+	assert.NoError(t, err)
+	data, _ := io.ReadAll(content)
+	content.Close()
+	assert.Equal(t, pngHeader, data)
+	assert.Equal(t, "Front", image.AltText)
+
+	product, err = service.DeleteImage(c, "1", back.ImageID, 0)
+	assert.NoError(t, err)
+	assert.Len(t, product.Images, 1)
+	assert.Equal(t, 0, product.Images[0].Position)
+
+	_, _, err = service.OpenImage("1", back.ImageID)
+	assert.Equal(t, utils.ErrImageNotFound, err)
+	_, err = service.DeleteImage(c, "1", back.ImageID, 0)
+	assert.Equal(t, utils.ErrImageNotFound, err)
+}
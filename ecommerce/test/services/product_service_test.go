@@ -1,18 +1,39 @@
 package services_test
 
 import (
+	"context"
 	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/YugenDev/global-mobility-test/internal/models"
+	"github.com/YugenDev/global-mobility-test/internal/repositories"
 	"github.com/YugenDev/global-mobility-test/internal/services"
 	"github.com/YugenDev/global-mobility-test/internal/utils"
+	"github.com/YugenDev/global-mobility-test/test/testutil"
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"go.mongodb.org/mongo-driver/mongo"
 )
 
+// usd parses a USD amount such as "29.99", failing on malformed input.
+func usd(amount string) models.Money {
+	money, err := models.ParseMoney(amount, "USD")
+	if err != nil {
+		panic(err)
+	}
+	return money
+}
+
+func usdPtr(amount string) *models.Money {
+	money := usd(amount)
+	return &money
+}
+
 type MockProductRepository struct {
 	mock.Mock
 }
@@ -27,8 +48,8 @@ func (m *MockProductRepository) GetProductByID(id string) (models.Product, error
 	return args.Get(0).(models.Product), args.Error(1)
 }
 
-func (m *MockProductRepository) GetAllProducts() ([]models.Product, error) {
-	args := m.Called()
+func (m *MockProductRepository) GetAllProducts(query models.ProductQuery) ([]models.Product, error) {
+	args := m.Called(query)
 	return args.Get(0).([]models.Product), args.Error(1)
 }
 
@@ -37,17 +58,108 @@ func (m *MockProductRepository) UpdateProduct(c echo.Context, id string, product
 	return args.Get(0).(*mongo.UpdateResult), args.Error(1)
 }
 
-func (m *MockProductRepository) DeleteProduct(c echo.Context, id string) (*mongo.DeleteResult, error) {
-	args := m.Called(c, id)
+func (m *MockProductRepository) DeleteProduct(c echo.Context, id string, version int) (*mongo.DeleteResult, error) {
+	args := m.Called(c, id, version)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*mongo.DeleteResult), args.Error(1)
 }
 
+func (m *MockProductRepository) BulkWriteProducts(c echo.Context, writes []models.ProductWrite) ([]error, error) {
+	args := m.Called(c, writes)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]error), args.Error(1)
+}
+
+func (m *MockProductRepository) AdjustStock(c echo.Context, id string, delta int) (models.Product, error) {
+	args := m.Called(c, id, delta)
+	return args.Get(0).(models.Product), args.Error(1)
+}
+
+func (m *MockProductRepository) ReserveStock(c echo.Context, id string, quantity int) (models.Product, error) {
+	args := m.Called(c, id, quantity)
+	return args.Get(0).(models.Product), args.Error(1)
+}
+
+func (m *MockProductRepository) ReleaseStock(c echo.Context, id string, quantity int) (*mongo.UpdateResult, error) {
+	args := m.Called(c, id, quantity)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*mongo.UpdateResult), args.Error(1)
+}
+
+func (m *MockProductRepository) CommitReservedStock(c echo.Context, id string, quantity int) (models.Product, error) {
+	args := m.Called(c, id, quantity)
+	return args.Get(0).(models.Product), args.Error(1)
+}
+
+func (m *MockProductRepository) AdjustVariantStock(c echo.Context, id string, sku string, delta int) (models.Product, error) {
+	args := m.Called(c, id, sku, delta)
+	return args.Get(0).(models.Product), args.Error(1)
+}
+
+func (m *MockProductRepository) UpdateVariants(c echo.Context, id string, version int, variants []models.Variant) (*mongo.UpdateResult, error) {
+	args := m.Called(c, id, version, variants)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*mongo.UpdateResult), args.Error(1)
+}
+
+func (m *MockProductRepository) UpdateImages(c echo.Context, id string, version int, images []models.ProductImage) (*mongo.UpdateResult, error) {
+	args := m.Called(c, id, version, images)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*mongo.UpdateResult), args.Error(1)
+}
+
+func (m *MockProductRepository) AdjustRating(id string, count int, total int) (*mongo.UpdateResult, error) {
+	args := m.Called(id, count, total)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*mongo.UpdateResult), args.Error(1)
+}
+
+func (m *MockProductRepository) ReassignCategory(id string, from string, to string) (models.Product, error) {
+	args := m.Called(id, from, to)
+	return args.Get(0).(models.Product), args.Error(1)
+}
+
+func (m *MockProductRepository) SearchProducts(query models.ProductSearchQuery) ([]models.ProductSearchResult, error) {
+	args := m.Called(query)
+	return args.Get(0).([]models.ProductSearchResult), args.Error(1)
+}
+
+func (m *MockProductRepository) EnsureTextIndex() error {
+	args := m.Called()
+	return args.Error(0)
+}
+
+func (m *MockProductRepository) RestoreProduct(c echo.Context, id string) (*mongo.UpdateResult, error) {
+	args := m.Called(c, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*mongo.UpdateResult), args.Error(1)
+}
+
+func (m *MockProductRepository) PurgeDeletedProducts(before time.Time) ([]models.Product, error) {
+	args := m.Called(before)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Product), args.Error(1)
+}
+
 func TestCreateProduct(t *testing.T) {
 	mockRepo := new(MockProductRepository)
-	service := services.NewProductService(mockRepo)
+	service := testutil.NewProductService(mockRepo)
 
 	tests := []struct {
 		name         string
@@ -60,7 +172,7 @@ func TestCreateProduct(t *testing.T) {
 			product: &models.Product{
 				Name:        "Test Product",
 				Description: "Test Description",
-				Price:       100,
+				Price:       usd("100"),
 				Stock:       10,
 			},
 			mockBehavior: func() {
@@ -72,7 +184,7 @@ func TestCreateProduct(t *testing.T) {
 			name: "Missing Name",
 			product: &models.Product{
 				Description: "Test Description",
-				Price:       100,
+				Price:       usd("100"),
 				Stock:       10,
 			},
 			mockBehavior: func() {},
@@ -82,7 +194,7 @@ func TestCreateProduct(t *testing.T) {
 			name: "Missing Description",
 			product: &models.Product{
 				Name:  "Test Product",
-				Price: 100,
+				Price: usd("100"),
 				Stock: 10,
 			},
 			mockBehavior: func() {},
@@ -93,7 +205,7 @@ func TestCreateProduct(t *testing.T) {
 			product: &models.Product{
 				Name:        "Test Product",
 				Description: "Test Description",
-				Price:       -10,
+				Price:       usd("-10"),
 				Stock:       10,
 			},
 			mockBehavior: func() {},
@@ -104,7 +216,7 @@ func TestCreateProduct(t *testing.T) {
 			product: &models.Product{
 				Name:        "Test Product",
 				Description: "Test Description",
-				Price:       100,
+				Price:       usd("100"),
 				Stock:       -5,
 			},
 			mockBehavior: func() {},
@@ -124,21 +236,21 @@ func TestCreateProduct(t *testing.T) {
 
 func TestGetAll(t *testing.T) {
 	mockRepo := new(MockProductRepository)
-	service := services.NewProductService(mockRepo)
+	service := testutil.NewProductService(mockRepo)
 
 	tests := []struct {
 		name         string
 		mockBehavior func()
 		expectedErr  error
-		expectedData []models.Product
+		expectedData models.ProductPage
 	}{
 		{
 			name: "No Products Found",
 			mockBehavior: func() {
-				mockRepo.On("GetAllProducts").Return([]models.Product{}, utils.ErrNoProductsFound)
+				mockRepo.On("GetAllProducts", mock.Anything).Return([]models.Product{}, utils.ErrNoProductsFound)
 			},
 			expectedErr:  utils.ErrNoProductsFound,
-			expectedData: nil,
+			expectedData: models.ProductPage{},
 		},
 		{
 			name: "Products Found",
@@ -147,10 +259,12 @@ func TestGetAll(t *testing.T) {
 					{ProductID: "1", Name: "Product 1"},
 					{ProductID: "2", Name: "Product 2"},
 				}
-				mockRepo.On("GetAllProducts").Return(products, nil)
+				mockRepo.On("GetAllProducts", mock.Anything).Return(products, nil)
+			},
+			expectedErr: nil,
+			expectedData: models.ProductPage{
+				Products: []models.Product{{ProductID: "1", Name: "Product 1"}, {ProductID: "2", Name: "Product 2"}},
 			},
-			expectedErr:  nil,
-			expectedData: []models.Product{{ProductID: "1", Name: "Product 1"}, {ProductID: "2", Name: "Product 2"}},
 		},
 	}
 
@@ -159,7 +273,7 @@ func TestGetAll(t *testing.T) {
 			mockRepo.Mock = mock.Mock{} // Reset mock
 			tt.mockBehavior()
 
-			products, err := service.GetAll()
+			products, err := service.GetAll(models.ProductQuery{})
 			assert.Equal(t, tt.expectedErr, err)
 			assert.Equal(t, tt.expectedData, products)
 			mockRepo.AssertExpectations(t)
@@ -169,7 +283,7 @@ func TestGetAll(t *testing.T) {
 
 func TestGetByID(t *testing.T) {
 	mockRepo := new(MockProductRepository)
-	service := services.NewProductService(mockRepo)
+	service := testutil.NewProductService(mockRepo)
 
 	tests := []struct {
 		name         string
@@ -222,7 +336,7 @@ func TestGetByID(t *testing.T) {
 
 func TestUpdateProduct(t *testing.T) {
 	mockRepo := new(MockProductRepository)
-	service := services.NewProductService(mockRepo)
+	service := testutil.NewProductService(mockRepo)
 
 	tests := []struct {
 		name         string
@@ -237,12 +351,12 @@ func TestUpdateProduct(t *testing.T) {
 			product: &models.Product{
 				Name:        "Updated Product",
 				Description: "Updated Description",
-				Price:       200,
+				Price:       usd("200"),
 				Stock:       20,
 			},
 			mockBehavior: func() {
 				mockRepo.On("GetProductByID", "123").Return(models.Product{Name: "Product 1"}, nil)
-				mockRepo.On("UpdateProduct", mock.Anything, "123", mock.Anything).Return(&mongo.UpdateResult{}, nil)
+				mockRepo.On("UpdateProduct", mock.Anything, "123", mock.Anything).Return(&mongo.UpdateResult{MatchedCount: 1}, nil)
 			},
 			expectedErr: nil,
 		},
@@ -252,7 +366,7 @@ func TestUpdateProduct(t *testing.T) {
 			product: &models.Product{
 				Name:        "Updated Product",
 				Description: "Updated Description",
-				Price:       200,
+				Price:       usd("200"),
 				Stock:       20,
 			},
 			mockBehavior: func() {},
@@ -262,7 +376,7 @@ func TestUpdateProduct(t *testing.T) {
 			name: "Invalid Price",
 			id:   "123",
 			product: &models.Product{
-				Price: -10,
+				Price: usd("-10"),
 			},
 			mockBehavior: func() {
 				mockRepo.On("GetProductByID", "123").Return(models.Product{Name: "Product 1"}, nil)
@@ -294,7 +408,7 @@ func TestUpdateProduct(t *testing.T) {
 
 func TestDeleteProduct(t *testing.T) {
 	mockRepo := new(MockProductRepository)
-	service := services.NewProductService(mockRepo)
+	service := testutil.NewProductService(mockRepo)
 
 	tests := []struct {
 		name         string
@@ -307,7 +421,7 @@ func TestDeleteProduct(t *testing.T) {
 			productID: "valid-id",
 			mockBehavior: func() {
 				mockRepo.On("GetProductByID", "valid-id").Return(models.Product{ProductID: "valid-id"}, nil)
-				mockRepo.On("DeleteProduct", mock.Anything, "valid-id").Return(&mongo.DeleteResult{DeletedCount: 1}, nil)
+				mockRepo.On("DeleteProduct", mock.Anything, "valid-id", mock.Anything).Return(&mongo.DeleteResult{DeletedCount: 1}, nil)
 			},
 			expectedErr: nil,
 		},
@@ -327,7 +441,7 @@ func TestDeleteProduct(t *testing.T) {
 			tt.mockBehavior()
 
 			c := echo.New().NewContext(nil, nil)
-			err := service.DeleteProduct(c, tt.productID)
+			err := service.DeleteProduct(c, tt.productID, 0)
 
 			assert.Equal(t, tt.expectedErr, err)
 			mockRepo.AssertExpectations(t)
@@ -336,7 +450,7 @@ func TestDeleteProduct(t *testing.T) {
 }
 func TestCreateProductWithExistingID(t *testing.T) {
 	mockRepo := new(MockProductRepository)
-	service := services.NewProductService(mockRepo)
+	service := testutil.NewProductService(mockRepo)
 
 	tests := []struct {
 		name         string
@@ -350,7 +464,7 @@ func TestCreateProductWithExistingID(t *testing.T) {
 				ProductID:   "existing-id",
 				Name:        "Test Product",
 				Description: "Test Description",
-				Price:       100,
+				Price:       usd("100"),
 				Stock:       10,
 			},
 			mockBehavior: func() {
@@ -378,7 +492,7 @@ func TestCreateProductWithExistingID(t *testing.T) {
 }
 func TestUpdateProductWithInvalidID(t *testing.T) {
 	mockRepo := new(MockProductRepository)
-	service := services.NewProductService(mockRepo)
+	service := testutil.NewProductService(mockRepo)
 
 	tests := []struct {
 		name         string
@@ -419,7 +533,7 @@ func TestUpdateProductWithInvalidID(t *testing.T) {
 }
 func TestCreateProductEmptyID(t *testing.T) {
 	mockRepo := new(MockProductRepository)
-	service := services.NewProductService(mockRepo)
+	service := testutil.NewProductService(mockRepo)
 
 	tests := []struct {
 		name         string
@@ -432,7 +546,7 @@ func TestCreateProductEmptyID(t *testing.T) {
 			product: &models.Product{
 				Name:        "Test Product",
 				Description: "Test Description",
-				Price:       100,
+				Price:       usd("100"),
 				Stock:       10,
 			},
 			mockBehavior: func() {
@@ -460,7 +574,7 @@ func TestCreateProductEmptyID(t *testing.T) {
 }
 func TestGetByIDEmptyID(t *testing.T) {
 	mockRepo := new(MockProductRepository)
-	service := services.NewProductService(mockRepo)
+	service := testutil.NewProductService(mockRepo)
 
 	tests := []struct {
 		name         string
@@ -487,7 +601,7 @@ func TestGetByIDEmptyID(t *testing.T) {
 }
 func TestDeleteProductEmptyProductID(t *testing.T) {
 	mockRepo := new(MockProductRepository)
-	service := services.NewProductService(mockRepo)
+	service := testutil.NewProductService(mockRepo)
 
 	tests := []struct {
 		name         string
@@ -514,7 +628,7 @@ func TestDeleteProductEmptyProductID(t *testing.T) {
 			tt.mockBehavior()
 
 			c := echo.New().NewContext(nil, nil)
-			err := service.DeleteProduct(c, tt.productID)
+			err := service.DeleteProduct(c, tt.productID, 0)
 
 			assert.Equal(t, tt.expectedErr, err)
 			mockRepo.AssertExpectations(t)
@@ -523,7 +637,7 @@ func TestDeleteProductEmptyProductID(t *testing.T) {
 }
 func TestEmptyID(t *testing.T) {
 	mockRepo := new(MockProductRepository)
-	service := services.NewProductService(mockRepo)
+	service := testutil.NewProductService(mockRepo)
 
 	tests := []struct {
 		name         string
@@ -545,7 +659,7 @@ func TestEmptyID(t *testing.T) {
 			tt.mockBehavior()
 
 			c := echo.New().NewContext(nil, nil)
-			err := service.DeleteProduct(c, tt.productID)
+			err := service.DeleteProduct(c, tt.productID, 0)
 
 			assert.Equal(t, tt.expectedErr, err)
 			mockRepo.AssertExpectations(t)
@@ -554,7 +668,7 @@ func TestEmptyID(t *testing.T) {
 }
 func TestGetByIDWithError(t *testing.T) {
 	mockRepo := new(MockProductRepository)
-	service := services.NewProductService(mockRepo)
+	service := testutil.NewProductService(mockRepo)
 
 	tests := []struct {
 		name         string
@@ -592,7 +706,7 @@ func TestGetByIDWithError(t *testing.T) {
 }
 func TestCreateProductWithRepositoryError(t *testing.T) {
 	mockRepo := new(MockProductRepository)
-	service := services.NewProductService(mockRepo)
+	service := testutil.NewProductService(mockRepo)
 
 	tests := []struct {
 		name         string
@@ -605,7 +719,7 @@ func TestCreateProductWithRepositoryError(t *testing.T) {
 			product: &models.Product{
 				Name:        "Test Product",
 				Description: "Test Description",
-				Price:       100,
+				Price:       usd("100"),
 				Stock:       10,
 			},
 			mockBehavior: func() {
@@ -633,7 +747,7 @@ func TestCreateProductWithRepositoryError(t *testing.T) {
 }
 func TestUpdateProductWithRepositoryError(t *testing.T) {
 	mockRepo := new(MockProductRepository)
-	service := services.NewProductService(mockRepo)
+	service := testutil.NewProductService(mockRepo)
 
 	tests := []struct {
 		name         string
@@ -647,7 +761,7 @@ func TestUpdateProductWithRepositoryError(t *testing.T) {
 			id:   "test-id",
 			product: &models.Product{
 				Name:  "Updated Product",
-				Price: 100,
+				Price: usd("100"),
 			},
 			mockBehavior: func() {
 				mockRepo.On("GetProductByID", "test-id").Return(models.Product{
@@ -692,7 +806,7 @@ func TestUpdateProductWithRepositoryError(t *testing.T) {
 }
 func TestDeleteProductWithRepositoryError(t *testing.T) {
 	mockRepo := new(MockProductRepository)
-	service := services.NewProductService(mockRepo)
+	service := testutil.NewProductService(mockRepo)
 
 	tests := []struct {
 		name         string
@@ -707,7 +821,7 @@ func TestDeleteProductWithRepositoryError(t *testing.T) {
 				mockRepo.On("GetProductByID", "test-id").Return(models.Product{
 					ProductID: "test-id",
 				}, nil)
-				mockRepo.On("DeleteProduct", mock.Anything, "test-id").Return(
+				mockRepo.On("DeleteProduct", mock.Anything, "test-id", mock.Anything).Return(
 					nil,
 					errors.New("repository error"),
 				)
@@ -722,7 +836,7 @@ func TestDeleteProductWithRepositoryError(t *testing.T) {
 			tt.mockBehavior()
 
 			c := echo.New().NewContext(nil, nil)
-			err := service.DeleteProduct(c, tt.productID)
+			err := service.DeleteProduct(c, tt.productID, 0)
 
 			assert.Equal(t, tt.expectedErr.Error(), err.Error())
 			mockRepo.AssertExpectations(t)
@@ -731,24 +845,24 @@ func TestDeleteProductWithRepositoryError(t *testing.T) {
 }
 func TestGetAllWithRepositoryError(t *testing.T) {
 	mockRepo := new(MockProductRepository)
-	service := services.NewProductService(mockRepo)
+	service := testutil.NewProductService(mockRepo)
 
 	tests := []struct {
 		name         string
 		mockBehavior func()
 		expectedErr  error
-		expectedData []models.Product
+		expectedData models.ProductPage
 	}{
 		{
 			name: "Repository Error",
 			mockBehavior: func() {
-				mockRepo.On("GetAllProducts").Return(
+				mockRepo.On("GetAllProducts", mock.Anything).Return(
 					[]models.Product{},
 					errors.New("repository error"),
 				)
 			},
 			expectedErr:  errors.New("repository error"),
-			expectedData: nil,
+			expectedData: models.ProductPage{},
 		},
 	}
 
@@ -757,7 +871,7 @@ func TestGetAllWithRepositoryError(t *testing.T) {
 			mockRepo.Mock = mock.Mock{} // Reset mock
 			tt.mockBehavior()
 
-			products, err := service.GetAll()
+			products, err := service.GetAll(models.ProductQuery{})
 			assert.Equal(t, tt.expectedErr.Error(), err.Error())
 			assert.Equal(t, tt.expectedData, products)
 			mockRepo.AssertExpectations(t)
@@ -766,7 +880,7 @@ func TestGetAllWithRepositoryError(t *testing.T) {
 }
 func TestUpdateProductPartialFields(t *testing.T) {
 	mockRepo := new(MockProductRepository)
-	service := services.NewProductService(mockRepo)
+	service := testutil.NewProductService(mockRepo)
 
 	tests := []struct {
 		name         string
@@ -786,16 +900,16 @@ func TestUpdateProductPartialFields(t *testing.T) {
 					ProductID:   "test-id",
 					Name:        "Original Name",
 					Description: "Original Description",
-					Price:       100,
+					Price:       usd("100"),
 					Stock:       10,
 				}
 				mockRepo.On("GetProductByID", "test-id").Return(existingProduct, nil)
 				mockRepo.On("UpdateProduct", mock.Anything, "test-id", mock.MatchedBy(func(p *models.Product) bool {
 					return p.Name == "Updated Name" &&
 						p.Description == "Original Description" &&
-						p.Price == 100 &&
+						p.Price == usd("100") &&
 						p.Stock == 10
-				})).Return(&mongo.UpdateResult{}, nil)
+				})).Return(&mongo.UpdateResult{MatchedCount: 1}, nil)
 			},
 			expectedErr: nil,
 		},
@@ -810,16 +924,16 @@ func TestUpdateProductPartialFields(t *testing.T) {
 					ProductID:   "test-id",
 					Name:        "Original Name",
 					Description: "Original Description",
-					Price:       100,
+					Price:       usd("100"),
 					Stock:       10,
 				}
 				mockRepo.On("GetProductByID", "test-id").Return(existingProduct, nil)
 				mockRepo.On("UpdateProduct", mock.Anything, "test-id", mock.MatchedBy(func(p *models.Product) bool {
 					return p.Name == "Original Name" &&
 						p.Description == "Updated Description" &&
-						p.Price == 100 &&
+						p.Price == usd("100") &&
 						p.Stock == 10
-				})).Return(&mongo.UpdateResult{}, nil)
+				})).Return(&mongo.UpdateResult{MatchedCount: 1}, nil)
 			},
 			expectedErr: nil,
 		},
@@ -840,7 +954,7 @@ func TestUpdateProductPartialFields(t *testing.T) {
 }
 func TestUpdateProductMultipleFields(t *testing.T) {
 	mockRepo := new(MockProductRepository)
-	service := services.NewProductService(mockRepo)
+	service := testutil.NewProductService(mockRepo)
 
 	tests := []struct {
 		name         string
@@ -855,7 +969,7 @@ func TestUpdateProductMultipleFields(t *testing.T) {
 			product: &models.Product{
 				Name:        "Updated Name",
 				Description: "Updated Description",
-				Price:       200,
+				Price:       usd("200"),
 				Stock:       20,
 			},
 			mockBehavior: func() {
@@ -863,16 +977,16 @@ func TestUpdateProductMultipleFields(t *testing.T) {
 					ProductID:   "test-id",
 					Name:        "Original Name",
 					Description: "Original Description",
-					Price:       100,
+					Price:       usd("100"),
 					Stock:       10,
 				}
 				mockRepo.On("GetProductByID", "test-id").Return(existingProduct, nil)
 				mockRepo.On("UpdateProduct", mock.Anything, "test-id", mock.MatchedBy(func(p *models.Product) bool {
 					return p.Name == "Updated Name" &&
 						p.Description == "Updated Description" &&
-						p.Price == 200 &&
+						p.Price == usd("200") &&
 						p.Stock == 20
-				})).Return(&mongo.UpdateResult{}, nil)
+				})).Return(&mongo.UpdateResult{MatchedCount: 1}, nil)
 			},
 			expectedErr: nil,
 		},
@@ -893,7 +1007,7 @@ func TestUpdateProductMultipleFields(t *testing.T) {
 }
 func TestUpdateProductPriceAndStockValidations(t *testing.T) {
 	mockRepo := new(MockProductRepository)
-	service := services.NewProductService(mockRepo)
+	service := testutil.NewProductService(mockRepo)
 
 	tests := []struct {
 		name         string
@@ -906,7 +1020,7 @@ func TestUpdateProductPriceAndStockValidations(t *testing.T) {
 			name: "Update Price and Stock Within Valid Range",
 			id:   "test-id",
 			product: &models.Product{
-				Price: 150.50,
+				Price: usd("150.50"),
 				Stock: 25,
 			},
 			mockBehavior: func() {
@@ -914,16 +1028,16 @@ func TestUpdateProductPriceAndStockValidations(t *testing.T) {
 					ProductID:   "test-id",
 					Name:        "Original Name",
 					Description: "Original Description",
-					Price:       100,
+					Price:       usd("100"),
 					Stock:       10,
 				}
 				mockRepo.On("GetProductByID", "test-id").Return(existingProduct, nil)
 				mockRepo.On("UpdateProduct", mock.Anything, "test-id", mock.MatchedBy(func(p *models.Product) bool {
 					return p.Name == "Original Name" &&
 						p.Description == "Original Description" &&
-						p.Price == 150.50 &&
+						p.Price == usd("150.50") &&
 						p.Stock == 25
-				})).Return(&mongo.UpdateResult{}, nil)
+				})).Return(&mongo.UpdateResult{MatchedCount: 1}, nil)
 			},
 			expectedErr: nil,
 		},
@@ -931,13 +1045,13 @@ func TestUpdateProductPriceAndStockValidations(t *testing.T) {
 			name: "Update With Invalid Price",
 			id:   "test-id",
 			product: &models.Product{
-				Price: -50,
+				Price: usd("-50"),
 				Stock: 25,
 			},
 			mockBehavior: func() {
 				mockRepo.On("GetProductByID", "test-id").Return(models.Product{
 					ProductID: "test-id",
-					Price:     100,
+					Price:     usd("100"),
 					Stock:     10,
 				}, nil)
 			},
@@ -947,13 +1061,13 @@ func TestUpdateProductPriceAndStockValidations(t *testing.T) {
 			name: "Update With Invalid Stock",
 			id:   "test-id",
 			product: &models.Product{
-				Price: 150.50,
+				Price: usd("150.50"),
 				Stock: -10,
 			},
 			mockBehavior: func() {
 				mockRepo.On("GetProductByID", "test-id").Return(models.Product{
 					ProductID: "test-id",
-					Price:     100,
+					Price:     usd("100"),
 					Stock:     10,
 				}, nil)
 			},
@@ -976,7 +1090,7 @@ func TestUpdateProductPriceAndStockValidations(t *testing.T) {
 }
 func TestUpdateProductFieldValidations(t *testing.T) {
 	mockRepo := new(MockProductRepository)
-	service := services.NewProductService(mockRepo)
+	service := testutil.NewProductService(mockRepo)
 
 	tests := []struct {
 		name         string
@@ -996,16 +1110,16 @@ func TestUpdateProductFieldValidations(t *testing.T) {
 					ProductID:   "test-id",
 					Name:        "Original Name",
 					Description: "Original Description",
-					Price:       100,
+					Price:       usd("100"),
 					Stock:       10,
 				}
 				mockRepo.On("GetProductByID", "test-id").Return(existingProduct, nil)
 				mockRepo.On("UpdateProduct", mock.Anything, "test-id", mock.MatchedBy(func(p *models.Product) bool {
 					return p.Name == "Original Name" &&
 						p.Description == "Original Description" &&
-						p.Price == 100 &&
+						p.Price == usd("100") &&
 						p.Stock == 10
-				})).Return(&mongo.UpdateResult{}, nil)
+				})).Return(&mongo.UpdateResult{MatchedCount: 1}, nil)
 			},
 			expectedErr: nil,
 		},
@@ -1014,24 +1128,24 @@ func TestUpdateProductFieldValidations(t *testing.T) {
 			id:   "test-id",
 			product: &models.Product{
 				Name:  "New Name",
-				Price: 0, // Zero price should not update
-				Stock: 0, // Zero stock should not update
+				Price: models.Money{}, // Zero price should not update
+				Stock: 0,              // Zero stock should not update
 			},
 			mockBehavior: func() {
 				existingProduct := models.Product{
 					ProductID:   "test-id",
 					Name:        "Original Name",
 					Description: "Original Description",
-					Price:       100,
+					Price:       usd("100"),
 					Stock:       10,
 				}
 				mockRepo.On("GetProductByID", "test-id").Return(existingProduct, nil)
 				mockRepo.On("UpdateProduct", mock.Anything, "test-id", mock.MatchedBy(func(p *models.Product) bool {
 					return p.Name == "New Name" &&
 						p.Description == "Original Description" &&
-						p.Price == 100 &&
+						p.Price == usd("100") &&
 						p.Stock == 10
-				})).Return(&mongo.UpdateResult{}, nil)
+				})).Return(&mongo.UpdateResult{MatchedCount: 1}, nil)
 			},
 			expectedErr: nil,
 		},
@@ -1052,7 +1166,7 @@ func TestUpdateProductFieldValidations(t *testing.T) {
 }
 func TestGeneralRepositoryErrorPropagation(t *testing.T) {
 	mockRepo := new(MockProductRepository)
-	service := services.NewProductService(mockRepo)
+	service := testutil.NewProductService(mockRepo)
 
 	tests := []struct {
 		name         string
@@ -1065,11 +1179,11 @@ func TestGeneralRepositoryErrorPropagation(t *testing.T) {
 			name:      "GetAll Repository Error Propagation",
 			operation: "GetAll",
 			setup: func(c echo.Context) error {
-				_, err := service.GetAll()
+				_, err := service.GetAll(models.ProductQuery{})
 				return err
 			},
 			mockBehavior: func() {
-				mockRepo.On("GetAllProducts").Return(
+				mockRepo.On("GetAllProducts", mock.Anything).Return(
 					[]models.Product{},
 					errors.New("database connection error"),
 				)
@@ -1099,7 +1213,7 @@ func TestGeneralRepositoryErrorPropagation(t *testing.T) {
 				return service.CreateProduct(c, &models.Product{
 					Name:        "Test Product",
 					Description: "Test Description",
-					Price:       100,
+					Price:       usd("100"),
 					Stock:       10,
 				})
 			},
@@ -1119,11 +1233,11 @@ func TestGeneralRepositoryErrorPropagation(t *testing.T) {
 					models.Product{ProductID: "test-id"},
 					nil,
 				)
-				mockRepo.On("DeleteProduct", mock.Anything, "test-id").Return(
+				mockRepo.On("DeleteProduct", mock.Anything, "test-id", mock.Anything).Return(
 					nil,
 					errors.New("database connection error"),
 				)
-				return service.DeleteProduct(c, "test-id")
+				return service.DeleteProduct(c, "test-id", 0)
 			},
 			mockBehavior: func() {},
 			expectedErr:  errors.New("database connection error"),
@@ -1143,3 +1257,358 @@ func TestGeneralRepositoryErrorPropagation(t *testing.T) {
 		})
 	}
 }
+
+func TestGetAllPagination(t *testing.T) {
+	mockRepo := new(MockProductRepository)
+	service := testutil.NewProductService(mockRepo)
+
+	createdAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	products := []models.Product{
+		{ProductID: "1", CreatedAt: createdAt},
+		{ProductID: "2", CreatedAt: createdAt},
+		{ProductID: "3", CreatedAt: createdAt},
+	}
+	mockRepo.On("GetAllProducts", mock.MatchedBy(func(q models.ProductQuery) bool {
+		return q.Limit == 3 && q.After == nil
+	})).Return(products, nil)
+
+	page, err := service.GetAll(models.ProductQuery{Limit: 2})
+	assert.NoError(t, err)
+	assert.True(t, page.HasMore)
+	assert.Len(t, page.Products, 2)
+	assert.NotEmpty(t, page.NextCursor)
+
+	mockRepo.On("GetAllProducts", mock.MatchedBy(func(q models.ProductQuery) bool {
+		return q.After != nil && q.After.ProductID == "2" && q.After.CreatedAt.Equal(createdAt)
+	})).Return(products[2:], nil)
+
+	page, err = service.GetAll(models.ProductQuery{Limit: 2, Cursor: page.NextCursor})
+	assert.NoError(t, err)
+	assert.False(t, page.HasMore)
+	assert.Empty(t, page.NextCursor)
+	assert.Equal(t, "3", page.Products[0].ProductID)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGetAllInvalidQuery(t *testing.T) {
+	mockRepo := new(MockProductRepository)
+	service := testutil.NewProductService(mockRepo)
+
+	_, err := service.GetAll(models.ProductQuery{Limit: services.MaxPageLimit + 1})
+	assert.Equal(t, utils.ErrInvalidPageLimit, err)
+
+	_, err = service.GetAll(models.ProductQuery{Cursor: "not a cursor"})
+	assert.Equal(t, utils.ErrInvalidCursor, err)
+	mockRepo.AssertNotCalled(t, "GetAllProducts", mock.Anything)
+}
+
+func TestGetAllInvalidSortAndFilter(t *testing.T) {
+	mockRepo := new(MockProductRepository)
+	service := testutil.NewProductService(mockRepo)
+
+	_, err := service.GetAll(models.ProductQuery{Sort: "stock"})
+	assert.Equal(t, utils.ErrInvalidSortField, err)
+
+	minPrice, maxPrice := usd("20"), usd("10")
+	_, err = service.GetAll(models.ProductQuery{Filter: models.ProductFilter{MinPrice: &minPrice, MaxPrice: &maxPrice}})
+	assert.Equal(t, utils.ErrInvalidPriceRange, err)
+
+	cursor := utils.EncodeCursor(models.ProductCursor{Sort: "price", ProductID: "1"})
+	_, err = service.GetAll(models.ProductQuery{Sort: "-price", Cursor: cursor})
+	assert.Equal(t, utils.ErrInvalidCursor, err)
+	mockRepo.AssertNotCalled(t, "GetAllProducts", mock.Anything)
+}
+
+func TestSearchProducts(t *testing.T) {
+	mockRepo := new(MockProductRepository)
+	service := testutil.NewProductService(mockRepo)
+
+	results := []models.ProductSearchResult{
+		{Product: models.Product{ProductID: "1"}, Score: 3},
+		{Product: models.Product{ProductID: "2"}, Score: 2},
+		{Product: models.Product{ProductID: "3"}, Score: 1},
+	}
+	mockRepo.On("SearchProducts", models.ProductSearchQuery{Text: "shoes", Limit: 3}).Return(results, nil)
+
+	page, err := service.SearchProducts(models.ProductSearchQuery{Text: "shoes", Limit: 2})
+	assert.NoError(t, err)
+	assert.Len(t, page.Results, 2)
+	assert.True(t, page.HasMore)
+
+	mockRepo.On("SearchProducts", mock.MatchedBy(func(q models.ProductSearchQuery) bool {
+		return q.Offset == 2
+	})).Return(results[2:], nil)
+
+	page, err = service.SearchProducts(models.ProductSearchQuery{Text: "shoes", Limit: 2, Cursor: page.NextCursor})
+	assert.NoError(t, err)
+	assert.Len(t, page.Results, 1)
+	assert.False(t, page.HasMore)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestSearchProductsInvalidQuery(t *testing.T) {
+	mockRepo := new(MockProductRepository)
+	service := testutil.NewProductService(mockRepo)
+
+	_, err := service.SearchProducts(models.ProductSearchQuery{Text: "  "})
+	assert.Equal(t, utils.ErrSearchQueryRequired, err)
+
+	cursor := utils.EncodeCursor(models.ProductSearchCursor{Text: "hats", Offset: 20})
+	_, err = service.SearchProducts(models.ProductSearchQuery{Text: "shoes", Cursor: cursor})
+	assert.Equal(t, utils.ErrInvalidCursor, err)
+	mockRepo.AssertNotCalled(t, "SearchProducts", mock.Anything)
+}
+
+func TestEnsureSearchIndex(t *testing.T) {
+	mockRepo := new(MockProductRepository)
+	service := testutil.NewProductService(mockRepo)
+
+	mockRepo.On("EnsureTextIndex").Return(errors.New("index error"))
+
+	assert.EqualError(t, service.EnsureSearchIndex(), "index error")
+	mockRepo.AssertExpectations(t)
+}
+
+func TestUpdateProductVersionMismatch(t *testing.T) {
+	tests := []struct {
+		name         string
+		product      *models.Product
+		mockBehavior func(*MockProductRepository)
+		expectedErr  error
+	}{
+		{
+			name:    "Stale If-Match Version",
+			product: &models.Product{Name: "New", Version: 1},
+			mockBehavior: func(m *MockProductRepository) {
+				m.On("GetProductByID", "test-id").Return(models.Product{ProductID: "test-id", Version: 2}, nil)
+			},
+			expectedErr: utils.ErrProductVersionMismatch,
+		},
+		{
+			name:    "Concurrent Write",
+			product: &models.Product{Name: "New"},
+			mockBehavior: func(m *MockProductRepository) {
+				m.On("GetProductByID", "test-id").Return(models.Product{ProductID: "test-id", Version: 2}, nil)
+				m.On("UpdateProduct", mock.Anything, "test-id", mock.MatchedBy(func(p *models.Product) bool {
+					return p.Version == 2
+				})).Return(&mongo.UpdateResult{MatchedCount: 0}, nil)
+			},
+			expectedErr: utils.ErrProductVersionMismatch,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockProductRepository)
+			tt.mockBehavior(mockRepo)
+			service := testutil.NewProductService(mockRepo)
+
+			err := service.UpdateProduct(echo.New().NewContext(nil, nil), "test-id", tt.product)
+			assert.Equal(t, tt.expectedErr, err)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDeleteProductVersionMismatch(t *testing.T) {
+	mockRepo := new(MockProductRepository)
+	service := testutil.NewProductService(mockRepo)
+
+	mockRepo.On("GetProductByID", "test-id").Return(models.Product{ProductID: "test-id", Version: 2}, nil)
+
+	err := service.DeleteProduct(echo.New().NewContext(nil, nil), "test-id", 1)
+	assert.Equal(t, utils.ErrProductVersionMismatch, err)
+	mockRepo.AssertNotCalled(t, "DeleteProduct", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestRestoreProduct(t *testing.T) {
+	tests := []struct {
+		name         string
+		mockBehavior func(*MockProductRepository)
+		expectedErr  error
+	}{
+		{
+			name: "Restored",
+			mockBehavior: func(m *MockProductRepository) {
+				m.On("RestoreProduct", mock.Anything, "test-id").Return(&mongo.UpdateResult{MatchedCount: 1}, nil)
+				m.On("GetProductByID", "test-id").Return(models.Product{ProductID: "test-id", Version: 3}, nil)
+			},
+		},
+		{
+			name: "Not In Trash",
+			mockBehavior: func(m *MockProductRepository) {
+				m.On("RestoreProduct", mock.Anything, "test-id").Return(&mongo.UpdateResult{}, nil)
+			},
+			expectedErr: utils.ErrProductNotInTrash,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockProductRepository)
+			tt.mockBehavior(mockRepo)
+			service := testutil.NewProductService(mockRepo)
+
+			_, err := service.RestoreProduct(echo.New().NewContext(nil, nil), "test-id")
+			assert.Equal(t, tt.expectedErr, err)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPurgeDeletedProducts(t *testing.T) {
+	mockRepo := new(MockProductRepository)
+	service := testutil.NewProductService(mockRepo)
+
+	blobStore := repositories.NewLocalBlobStore(t.TempDir())
+
+	_, err := service.PurgeDeletedProducts(0, blobStore)
+	assert.Equal(t, utils.ErrInvalidTrashRetention, err)
+
+	for _, key := range []string{"products/1/a.png", "products/1/b.png", "products/3/c.png"} {
+		assert.NoError(t, blobStore.Put(context.Background(), key, strings.NewReader("image")))
+	}
+	mockRepo.On("PurgeDeletedProducts", mock.MatchedBy(func(before time.Time) bool {
+		return time.Since(before) >= 24*time.Hour
+	})).Return([]models.Product{
+		{ProductID: "1", Images: []models.ProductImage{{Key: "products/1/a.png"}, {Key: "products/1/b.png"}}},
+		{ProductID: "2"},
+	}, nil)
+
+	purged, err := service.PurgeDeletedProducts(24*time.Hour, blobStore)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), purged)
+	mockRepo.AssertExpectations(t)
+
+	_, err = blobStore.Open(context.Background(), "products/1/a.png")
+	assert.Equal(t, utils.ErrBlobNotFound, err)
+	_, err = blobStore.Open(context.Background(), "products/1/b.png")
+	assert.Equal(t, utils.ErrBlobNotFound, err)
+	content, err := blobStore.Open(context.Background(), "products/3/c.png")
+	if assert.NoError(t, err) {
+		content.Close()
+	}
+}
+
+func TestGetTrashDoesNotRequireResults(t *testing.T) {
+	mockRepo := new(MockProductRepository)
+	service := testutil.NewProductService(mockRepo)
+
+	mockRepo.On("GetAllProducts", mock.MatchedBy(func(q models.ProductQuery) bool {
+		return q.Trashed
+	})).Return([]models.Product(nil), nil)
+
+	page, err := service.GetTrash(models.ProductQuery{})
+	assert.NoError(t, err)
+	assert.Empty(t, page.Products)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestProductWritesAreAudited(t *testing.T) {
+	mockRepo := new(MockProductRepository)
+	auditRepo := repositories.NewMemoryAuditRepository()
+	service := services.NewProductService(mockRepo, auditRepo, repositories.NewMemoryCategoryRepository(), repositories.NewMemoryExchangeRateRepository(), repositories.NewMemoryPriceHistoryRepository())
+
+	req := httptest.NewRequest(http.MethodPut, "/products/test-id", nil)
+	req.Header.Set(utils.HeaderActor, "merchandiser@example.com")
+	req.Header.Set(echo.HeaderXRequestID, "req-1")
+	c := echo.New().NewContext(req, httptest.NewRecorder())
+
+	mockRepo.On("GetProductByID", "test-id").Return(models.Product{ProductID: "test-id", Name: "Shoes", Price: usd("10"), Version: 1}, nil)
+	mockRepo.On("UpdateProduct", mock.Anything, "test-id", mock.Anything).Return(&mongo.UpdateResult{MatchedCount: 1}, nil)
+
+	err := service.UpdateProduct(c, "test-id", &models.Product{Price: usd("12")})
+	assert.NoError(t, err)
+
+	page, err := service.GetHistory(models.AuditQuery{ProductID: "test-id"})
+	assert.NoError(t, err)
+	if assert.Len(t, page.Entries, 1) {
+		entry := page.Entries[0]
+		assert.Equal(t, models.AuditActionUpdate, entry.Action)
+		assert.Equal(t, "merchandiser@example.com", entry.Actor)
+		assert.Equal(t, "req-1", entry.RequestID)
+		assert.Equal(t, []models.FieldChange{{Field: "price", Before: usd("10"), After: usd("12")}}, entry.Changes)
+	}
+}
+
+func TestGetHistoryInvalidQuery(t *testing.T) {
+	service := testutil.NewProductService(new(MockProductRepository))
+
+	_, err := service.GetHistory(models.AuditQuery{})
+	assert.Equal(t, utils.ErrProductIDRequired, err)
+
+	_, err = service.GetHistory(models.AuditQuery{ProductID: "test-id", Cursor: "bad cursor"})
+	assert.Equal(t, utils.ErrInvalidCursor, err)
+}
+
+func TestBulkProducts(t *testing.T) {
+	mockRepo := new(MockProductRepository)
+	auditRepo := repositories.NewMemoryAuditRepository()
+	service := services.NewProductService(mockRepo, auditRepo, repositories.NewMemoryCategoryRepository(), repositories.NewMemoryExchangeRateRepository(), repositories.NewMemoryPriceHistoryRepository())
+	c := echo.New().NewContext(nil, nil)
+
+	mockRepo.On("GetProductByID", "new").Return(models.Product{}, mongo.ErrNoDocuments)
+	mockRepo.On("GetProductByID", "existing").Return(models.Product{ProductID: "existing", Name: "Shoes", Description: "Shoes", Price: usd("10"), Version: 2}, nil)
+	mockRepo.On("GetProductByID", "missing").Return(models.Product{}, mongo.ErrNoDocuments)
+	mockRepo.On("BulkWriteProducts", mock.Anything, mock.MatchedBy(func(writes []models.ProductWrite) bool {
+		return len(writes) == 2 && writes[0].Product.ProductID == "new" && writes[1].Product.Price == usd("12")
+	})).Return([]error{nil, nil}, nil)
+
+	response, err := service.BulkProducts(c, []models.BulkOperation{
+		{Op: models.BulkOpCreate, Product: &models.Product{ProductID: "new", Name: "Hat", Description: "Warm", Price: usd("5")}},
+		{Op: models.BulkOpCreate, Product: &models.Product{Name: "No description", Price: usd("5")}},
+		{Op: models.BulkOpUpdate, ProductID: "existing", Version: 2, Product: &models.Product{Price: usd("12")}},
+		{Op: models.BulkOpDelete, ProductID: "existing"},
+		{Op: models.BulkOpDelete, ProductID: "missing"},
+		{Op: "upsert"},
+	})
+
+	assert.NoError(t, err)
+	if assert.Len(t, response.Results, 6) {
+		assert.Equal(t, models.BulkStatusCreated, response.Results[0].Status)
+		assert.Equal(t, "invalid_product", response.Results[1].Code)
+		assert.Equal(t, models.BulkStatusUpdated, response.Results[2].Status)
+		assert.Equal(t, "duplicate_id", response.Results[3].Code)
+		assert.Equal(t, "not_found", response.Results[4].Code)
+		assert.Equal(t, "invalid_operation", response.Results[5].Code)
+	}
+
+	history, _ := service.GetHistory(models.AuditQuery{ProductID: "existing"})
+	assert.Len(t, history.Entries, 1)
+}
+
+func TestBulkProductsInvalidBatch(t *testing.T) {
+	service := testutil.NewProductService(new(MockProductRepository))
+	c := echo.New().NewContext(nil, nil)
+
+	_, err := service.BulkProducts(c, nil)
+	assert.Equal(t, utils.ErrBulkOperationsRequired, err)
+
+	_, err = service.BulkProducts(c, make([]models.BulkOperation, models.MaxBulkOperations+1))
+	assert.Equal(t, utils.ErrTooManyBulkOperations, err)
+}
+
+func TestAdjustStock(t *testing.T) {
+	mockRepo := new(MockProductRepository)
+	auditRepo := repositories.NewMemoryAuditRepository()
+	service := services.NewProductService(mockRepo, auditRepo, repositories.NewMemoryCategoryRepository(), repositories.NewMemoryExchangeRateRepository(), repositories.NewMemoryPriceHistoryRepository())
+	c := echo.New().NewContext(nil, nil)
+
+	_, err := service.AdjustStock(c, "test-id", 0)
+	assert.Equal(t, utils.ErrInvalidStockDelta, err)
+
+	mockRepo.On("AdjustStock", mock.Anything, "test-id", -2).Return(models.Product{ProductID: "test-id", Stock: 3, Version: 2}, nil)
+	product, err := service.AdjustStock(c, "test-id", -2)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, product.Stock)
+
+	page, _ := service.GetHistory(models.AuditQuery{ProductID: "test-id"})
+	if assert.Len(t, page.Entries, 1) {
+		assert.Equal(t, models.AuditActionStock, page.Entries[0].Action)
+		assert.Equal(t, []models.FieldChange{{Field: "stock", Before: 5, After: 3}}, page.Entries[0].Changes)
+	}
+
+	mockRepo.On("AdjustStock", mock.Anything, "test-id", -10).Return(models.Product{}, utils.ErrInsufficientStock)
+	_, err = service.AdjustStock(c, "test-id", -10)
+	assert.Equal(t, utils.ErrInsufficientStock, err)
+}
@@ -0,0 +1,112 @@
+package services_test
+
+import (
+	"testing"
+
+	"github.com/YugenDev/global-mobility-test/internal/models"
+	"github.com/YugenDev/global-mobility-test/internal/repositories"
+	"github.com/YugenDev/global-mobility-test/internal/services"
+	"github.com/YugenDev/global-mobility-test/internal/utils"
+	"github.com/YugenDev/global-mobility-test/test/testutil"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func newVariantProductService() *services.ProductService {
+	service := testutil.NewProductService(repositories.NewMemoryProductRepository())
+	service.CreateProduct(echo.New().NewContext(nil, nil), &models.Product{
+		ProductID:   "tee",
+		Name:        "Tee",
+		Description: "Cotton",
+		Price:       usd("20"),
+		Variants: []models.Variant{
+			{SKU: "TEE-S", Options: map[string]string{"size": "S"}, Stock: 2},
+			{SKU: "TEE-M", Options: map[string]string{"size": "M"}, Price: usdPtr("22"), Stock: 3},
+		},
+	})
+	return service
+}
+
+func TestCreateProduct_VariantStock(t *testing.T) {
+	service := newVariantProductService()
+	c := echo.New().NewContext(nil, nil)
+
+	product, err := service.GetByID("tee")
+	assert.NoError(t, err)
+	assert.Equal(t, 5, product.Stock)
+
+	duplicate := &models.Product{Name: "Cap", Description: "Wool", Price: usd("10"), Variants: []models.Variant{
+		{SKU: "CAP-1", Options: map[string]string{"color": "red"}},
+		{SKU: "CAP-2", Options: map[string]string{"color": "red"}},
+	}}
+	assert.Equal(t, utils.ErrDuplicateVariantOptions, service.CreateProduct(c, duplicate))
+
+	missingSKU := &models.Product{Name: "Cap", Description: "Wool", Price: usd("10"), Variants: []models.Variant{
+		{Options: map[string]string{"color": "red"}},
+	}}
+	assert.Equal(t, utils.ErrVariantSKURequired, service.CreateProduct(c, missingSKU))
+
+	euros, _ := models.ParseMoney("9", "EUR")
+	otherCurrency := &models.Product{Name: "Cap", Description: "Wool", Price: usd("10"), Variants: []models.Variant{
+		{SKU: "CAP-1", Options: map[string]string{"color": "red"}, Price: &euros},
+	}}
+	assert.Equal(t, utils.ErrCurrencyMismatch, service.CreateProduct(c, otherCurrency))
+}
+
+func TestVariantLifecycle(t *testing.T) {
+	service := newVariantProductService()
+	c := echo.New().NewContext(nil, nil)
+
+	product, err := service.AddVariant(c, "tee", 0, models.Variant{SKU: "TEE-L", Options: map[string]string{"size": "L"}, Stock: 4})
+	assert.NoError(t, err)
+	assert.Len(t, product.Variants, 3)
+	assert.Equal(t, 9, product.Stock)
+
+	_, err = service.AddVariant(c, "tee", 0, models.Variant{SKU: "TEE-L", Options: map[string]string{"size": "XL"}})
+	assert.Equal(t, utils.ErrVariantSKUExists, err)
+
+	_, err = service.AddVariant(c, "tee", product.Version-1, models.Variant{SKU: "TEE-XL", Options: map[string]string{"size": "XL"}})
+	assert.Equal(t, utils.ErrProductVersionMismatch, err)
+
+	product, err = service.UpdateVariant(c, "tee", "TEE-L", 0, models.Variant{Price: usdPtr("25"), Stock: 1})
+	assert.NoError(t, err)
+	variant, _ := product.Variant("TEE-L")
+	assert.Equal(t, usdPtr("25"), variant.Price)
+	assert.Equal(t, 6, product.Stock)
+
+	_, err = service.UpdateVariant(c, "tee", "TEE-L", 0, models.Variant{SKU: "TEE-XL"})
+	assert.Equal(t, utils.ErrVariantSKUCannotBeChanged, err)
+
+	product, err = service.RemoveVariant(c, "tee", "TEE-S", 0)
+	assert.NoError(t, err)
+	assert.Len(t, product.Variants, 2)
+	assert.Equal(t, 4, product.Stock)
+
+	_, err = service.RemoveVariant(c, "tee", "TEE-S", 0)
+	assert.Equal(t, utils.ErrVariantNotFound, err)
+}
+
+func TestAdjustVariantStock(t *testing.T) {
+	service := newVariantProductService()
+	c := echo.New().NewContext(nil, nil)
+
+	product, err := service.AdjustVariantStock(c, "tee", "TEE-S", -2)
+	assert.NoError(t, err)
+	variant, _ := product.Variant("TEE-S")
+	assert.Equal(t, 0, variant.Stock)
+	assert.Equal(t, 3, product.Stock)
+
+	_, err = service.AdjustVariantStock(c, "tee", "TEE-S", -1)
+	assert.Equal(t, utils.ErrInsufficientStock, err)
+
+	_, err = service.AdjustStock(c, "tee", 5)
+	assert.Equal(t, utils.ErrProductHasVariants, err)
+
+	err = service.UpdateProduct(c, "tee", &models.Product{Stock: 10})
+	assert.Equal(t, utils.ErrProductHasVariants, err)
+
+	page, _ := service.GetHistory(models.AuditQuery{ProductID: "tee"})
+	assert.Equal(t, models.AuditActionStock, page.Entries[0].Action)
+	assert.Equal(t, "stock", page.Entries[0].Changes[0].Field)
+	assert.Equal(t, "variants", page.Entries[0].Changes[1].Field)
+}
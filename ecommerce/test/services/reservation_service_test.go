@@ -0,0 +1,185 @@
+package services_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/YugenDev/global-mobility-test/internal/models"
+	"github.com/YugenDev/global-mobility-test/internal/repositories"
+	"github.com/YugenDev/global-mobility-test/internal/services"
+	"github.com/YugenDev/global-mobility-test/internal/utils"
+	"github.com/YugenDev/global-mobility-test/test/testutil"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func newReservationService(ttl time.Duration) (*services.ReservationService, *repositories.MemoryProductRepository) {
+	productRepo := repositories.NewMemoryProductRepository()
+	productRepo.CreateProduct(echo.New().NewContext(nil, nil), &models.Product{ProductID: "1", Stock: 5})
+	return services.NewReservationService(productRepo, repositories.NewMemoryReservationRepository(), ttl), productRepo
+}
+
+func TestReserveAndConfirm(t *testing.T) {
+	service, productRepo := newReservationService(time.Minute)
+	c := echo.New().NewContext(nil, nil)
+
+	_, err := service.Reserve(c, "1", 0)
+	assert.Equal(t, utils.ErrInvalidReservationQuantity, err)
+
+	reservation, err := service.Reserve(c, "1", 3)
+	assert.NoError(t, err)
+	assert.Equal(t, models.ReservationStatusActive, reservation.Status)
+
+	_, err = service.Reserve(c, "1", 3)
+	assert.Equal(t, utils.ErrInsufficientStock, err)
+
+	confirmed, err := service.Confirm(c, reservation.ReservationID)
+	assert.NoError(t, err)
+	assert.Equal(t, models.ReservationStatusConfirmed, confirmed.Status)
+
+	product, _ := productRepo.GetProductByID("1")
+	assert.Equal(t, 2, product.Stock)
+	assert.Equal(t, 0, product.Reserved)
+
+	_, err = service.Cancel(c, reservation.ReservationID)
+	assert.Equal(t, utils.ErrReservationNotActive, err)
+}
+
+func TestUpdateProductRejectsStockBelowReserved(t *testing.T) {
+	service, productRepo := newReservationService(time.Minute)
+	productService := testutil.NewProductService(productRepo)
+	c := echo.New().NewContext(nil, nil)
+
+	service.Reserve(c, "1", 4)
+	stale, _ := productRepo.GetProductByID("1")
+
+	err := productService.UpdateProduct(c, "1", &models.Product{Stock: 3})
+	assert.Equal(t, utils.ErrStockBelowReserved, err)
+	assert.NoError(t, productService.UpdateProduct(c, "1", &models.Product{Stock: 4}))
+
+	// A write based on a read from before the reservation must not land either.
+	stale.Stock = 3
+	result, err := productRepo.UpdateProduct(c, "1", &stale)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), result.MatchedCount)
+
+	product, _ := productRepo.GetProductByID("1")
+	assert.Equal(t, 4, product.Stock)
+	assert.Equal(t, 4, product.Reserved)
+}
+
+func TestCancelReservationReleasesStock(t *testing.T) {
+	service, productRepo := newReservationService(time.Minute)
+	c := echo.New().NewContext(nil, nil)
+
+	reservation, _ := service.Reserve(c, "1", 5)
+
+	_, err := service.Cancel(c, reservation.ReservationID)
+	assert.NoError(t, err)
+
+	product, _ := productRepo.GetProductByID("1")
+	assert.Equal(t, 5, product.Available())
+}
+
+func TestReleaseExpiredReservations(t *testing.T) {
+	service, productRepo := newReservationService(time.Minute)
+	c := echo.New().NewContext(nil, nil)
+
+	reservation, _ := service.Reserve(c, "1", 4)
+
+	released, err := service.ReleaseExpired(time.Now())
+	assert.NoError(t, err)
+	assert.Equal(t, 0, released)
+
+	released, err = service.ReleaseExpired(time.Now().Add(2 * time.Minute))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, released)
+
+	product, _ := productRepo.GetProductByID("1")
+	assert.Equal(t, 0, product.Reserved)
+
+	_, err = service.Confirm(c, reservation.ReservationID)
+	assert.Equal(t, utils.ErrReservationNotActive, err)
+}
+
+func TestConfirmExpiredReservation(t *testing.T) {
+	service, _ := newReservationService(-time.Second)
+	c := echo.New().NewContext(nil, nil)
+
+	reservation, _ := service.Reserve(c, "1", 1)
+
+	_, err := service.Confirm(c, reservation.ReservationID)
+	assert.Equal(t, utils.ErrReservationExpired, err)
+}
+
+func TestReservationsBelongToTheirOwner(t *testing.T) {
+	service, productRepo := newReservationService(time.Minute)
+	as := func(identity models.Identity) echo.Context {
+		c := echo.New().NewContext(nil, nil)
+		c.Set(utils.ContextKeyIdentity, identity)
+		return c
+	}
+	ada := as(models.Identity{UserID: "ada"})
+	grace := as(models.Identity{UserID: "grace"})
+	warehouse := as(models.Identity{UserID: "ada", APIKeyID: "warehouse"})
+
+	reservation, err := service.Reserve(ada, "1", 2)
+	assert.NoError(t, err)
+	assert.Equal(t, "user:ada", reservation.OwnerID)
+
+	for _, other := range []echo.Context{grace, warehouse} {
+		_, err = service.GetByID(other, reservation.ReservationID)
+		assert.Equal(t, utils.ErrReservationNotFound, err)
+		_, err = service.Confirm(other, reservation.ReservationID)
+		assert.Equal(t, utils.ErrReservationNotFound, err)
+		_, err = service.Cancel(other, reservation.ReservationID)
+		assert.Equal(t, utils.ErrReservationNotFound, err)
+	}
+	product, _ := productRepo.GetProductByID("1")
+	assert.Equal(t, 2, product.Reserved)
+
+	_, err = service.Cancel(ada, reservation.ReservationID)
+	assert.NoError(t, err)
+}
+
+func TestReserveReleasesStockWhenReservationIsNotStored(t *testing.T) {
+	mockRepo := new(MockProductRepository)
+	service := services.NewReservationService(mockRepo, failingReservationRepository{}, time.Minute)
+	c := echo.New().NewContext(nil, nil)
+
+	mockRepo.On("ReserveStock", mock.Anything, "1", 2).Return(models.Product{}, nil)
+	mockRepo.On("ReleaseStock", mock.Anything, "1", 2).Return(&mongo.UpdateResult{MatchedCount: 1}, nil)
+
+	_, err := service.Reserve(c, "1", 2)
+
+	assert.Equal(t, utils.ErrDatabaseNotInitialized, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestConfirmReactivatesReservationWhenCommitFails(t *testing.T) {
+	mockRepo := new(MockProductRepository)
+	reservationRepo := repositories.NewMemoryReservationRepository()
+	service := services.NewReservationService(mockRepo, reservationRepo, time.Minute)
+	c := echo.New().NewContext(nil, nil)
+
+	mockRepo.On("ReserveStock", mock.Anything, "1", 2).Return(models.Product{}, nil)
+	mockRepo.On("CommitReservedStock", mock.Anything, "1", 2).Return(models.Product{}, utils.ErrDatabaseNotInitialized)
+
+	reservation, _ := service.Reserve(c, "1", 2)
+	_, err := service.Confirm(c, reservation.ReservationID)
+	assert.Equal(t, utils.ErrDatabaseNotInitialized, err)
+
+	stored, _ := service.GetByID(c, reservation.ReservationID)
+	assert.Equal(t, models.ReservationStatusActive, stored.Status)
+	mockRepo.AssertExpectations(t)
+}
+
+type failingReservationRepository struct {
+	repositories.IReservationRepository
+}
+
+func (failingReservationRepository) CreateReservation(reservation *models.Reservation) error {
+	return utils.ErrDatabaseNotInitialized
+}
@@ -0,0 +1,81 @@
+package services_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/YugenDev/global-mobility-test/internal/models"
+	"github.com/YugenDev/global-mobility-test/internal/repositories"
+	"github.com/YugenDev/global-mobility-test/internal/services"
+	"github.com/YugenDev/global-mobility-test/internal/utils"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func newReviewService() (*services.ReviewService, *repositories.MemoryProductRepository) {
+	productRepo := repositories.NewMemoryProductRepository()
+	productRepo.CreateProduct(echo.New().NewContext(nil, nil), &models.Product{ProductID: "1", Name: "Shirt", Description: "Cotton", Price: usd("20")})
+	return services.NewReviewService(repositories.NewMemoryReviewRepository(), productRepo), productRepo
+}
+
+func TestCreateReviewValidation(t *testing.T) {
+	service, _ := newReviewService()
+
+	assert.Equal(t, utils.ErrInvalidReviewRating, service.Create("1", &models.Review{Rating: 6, Text: "Great"}))
+	assert.Equal(t, utils.ErrReviewTextRequired, service.Create("1", &models.Review{Rating: 5, Text: "  "}))
+	assert.Equal(t, utils.ErrReviewTextTooLong, service.Create("1", &models.Review{Rating: 5, Text: strings.Repeat("a", 5001)}))
+	assert.Equal(t, utils.ErrNoProductsFound, service.Create("missing", &models.Review{Rating: 5, Text: "Great"}))
+
+	review := models.Review{Rating: 5, Text: " Great ", Status: models.ReviewStatusApproved}
+	assert.NoError(t, service.Create("1", &review))
+	assert.Equal(t, models.ReviewStatusPending, review.Status)
+	assert.Equal(t, "Great", review.Text)
+}
+
+func TestModerationKeepsRatingInStep(t *testing.T) {
+	service, productRepo := newReviewService()
+
+	five := models.Review{Rating: 5, Text: "Great"}
+	two := models.Review{Rating: 2, Text: "Shrank"}
+	service.Create("1", &five)
+	service.Create("1", &two)
+
+	_, err := service.Moderate(five.ReviewID, models.ReviewStatusPending)
+	assert.Equal(t, utils.ErrInvalidReviewStatus, err)
+
+	service.Moderate(five.ReviewID, models.ReviewStatusApproved)
+	service.Moderate(five.ReviewID, models.ReviewStatusApproved)
+	review, err := service.Moderate(two.ReviewID, models.ReviewStatusApproved)
+	assert.NoError(t, err)
+	assert.Equal(t, models.ReviewStatusApproved, review.Status)
+
+	product, _ := productRepo.GetProductByID("1")
+	assert.Equal(t, 2, product.RatingCount)
+	assert.Equal(t, 3.5, product.RatingAverage)
+
+	service.Moderate(two.ReviewID, models.ReviewStatusRejected)
+	product, _ = productRepo.GetProductByID("1")
+	assert.Equal(t, 1, product.RatingCount)
+	assert.Equal(t, 5.0, product.RatingAverage)
+
+	page, err := service.GetReviews(models.ReviewQuery{ProductID: "1", Status: models.ReviewStatusApproved})
+	assert.NoError(t, err)
+	assert.Len(t, page.Reviews, 1)
+}
+
+func TestModerationRollsBackWhenRatingFails(t *testing.T) {
+	reviewRepo := repositories.NewMemoryReviewRepository()
+	productRepo := new(MockProductRepository)
+	service := services.NewReviewService(reviewRepo, productRepo)
+
+	reviewRepo.CreateReview(&models.Review{ReviewID: "r-1", ProductID: "1", Rating: 4, Status: models.ReviewStatusPending})
+	productRepo.On("AdjustRating", "1", 1, 4).Return(nil, mongo.ErrClientDisconnected)
+
+	_, err := service.Moderate("r-1", models.ReviewStatusApproved)
+	assert.Equal(t, mongo.ErrClientDisconnected, err)
+
+	review, _ := reviewRepo.GetReviewByID("r-1")
+	assert.Equal(t, models.ReviewStatusPending, review.Status)
+	productRepo.AssertExpectations(t)
+}
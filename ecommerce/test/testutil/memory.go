@@ -0,0 +1,163 @@
+// Package testutil builds the services and servers the tests run against,
+// wired on memory repositories the way cmd/main.go wires them for
+// STORAGE_DRIVER=memory.
+package testutil
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/YugenDev/global-mobility-test/internal/handlers"
+	"github.com/YugenDev/global-mobility-test/internal/models"
+	"github.com/YugenDev/global-mobility-test/internal/repositories"
+	"github.com/YugenDev/global-mobility-test/internal/routes"
+	"github.com/YugenDev/global-mobility-test/internal/services"
+	"github.com/YugenDev/global-mobility-test/internal/utils"
+	"github.com/labstack/echo/v4"
+)
+
+// Settings of the memory store. They are small so tests can hit the limits.
+const (
+	MaxImageSize    = 1024
+	ReservationTTL  = time.Minute
+	CartTTL         = time.Hour
+	IdempotencyTTL  = time.Hour
+	AccessTokenTTL  = time.Minute
+	RefreshTokenTTL = time.Hour
+)
+
+// JWTSecret signs the tokens of the memory store's auth service.
+var JWTSecret = strings.Repeat("s", 32)
+
+// MemoryStore is every service of the application on memory repositories.
+type MemoryStore struct {
+	ProductRepository *repositories.MemoryProductRepository
+
+	Products       *services.ProductService
+	Images         *services.ProductImageService
+	Reservations   *services.ReservationService
+	PriceSchedules *services.PriceScheduleService
+	Carts          *services.CartService
+	Coupons        *services.CouponService
+	Orders         *services.OrderService
+	Categories     *services.CategoryService
+	ExchangeRates  *services.ExchangeRateService
+	Reviews        *services.ReviewService
+	Auth           *services.AuthService
+	APIKeys        *services.APIKeyService
+	Idempotency    *services.IdempotencyService
+}
+
+// NewMemoryStore wires a fresh store. Image files go to a temporary
+// directory of t.
+func NewMemoryStore(t testing.TB) *MemoryStore {
+	productRepo := repositories.NewMemoryProductRepository()
+	productService := NewProductService(productRepo)
+	cartService := services.NewCartService(repositories.NewMemoryCartRepository(), productService, CartTTL)
+	couponService := services.NewCouponService(repositories.NewMemoryCouponRepository(), productService.CategoryRepository, productService, cartService)
+
+	return &MemoryStore{
+		ProductRepository: productRepo,
+
+		Products:       productService,
+		Images:         services.NewProductImageService(productRepo, productService.AuditRepository, repositories.NewLocalBlobStore(t.TempDir()), MaxImageSize),
+		Reservations:   services.NewReservationService(productRepo, repositories.NewMemoryReservationRepository(), ReservationTTL),
+		PriceSchedules: services.NewPriceScheduleService(repositories.NewMemoryPriceScheduleRepository(), productService),
+		Carts:          cartService,
+		Coupons:        couponService,
+		Orders:         services.NewOrderService(repositories.NewMemoryOrderRepository(), productService, cartService, couponService),
+		Categories:     services.NewCategoryService(productService.CategoryRepository, productRepo, productService.AuditRepository, productService),
+		ExchangeRates:  services.NewExchangeRateService(productService.ExchangeRateRepository),
+		Reviews:        services.NewReviewService(repositories.NewMemoryReviewRepository(), productRepo),
+		Auth:           services.NewAuthService(repositories.NewMemoryUserRepository(), JWTSecret, AccessTokenTTL, RefreshTokenTTL),
+		APIKeys:        services.NewAPIKeyService(repositories.NewMemoryAPIKeyRepository()),
+		Idempotency:    services.NewIdempotencyService(repositories.NewMemoryIdempotencyRepository(), IdempotencyTTL),
+	}
+}
+
+// NewProductService builds a product service on repo and memory versions of
+// the repositories it reads alongside it.
+func NewProductService(repo repositories.IProductRepository) *services.ProductService {
+	return services.NewProductService(repo, repositories.NewMemoryAuditRepository(), repositories.NewMemoryCategoryRepository(), repositories.NewMemoryExchangeRateRepository(), repositories.NewMemoryPriceHistoryRepository())
+}
+
+// Server is an echo server with every route of the application registered
+// on a memory store.
+type Server struct {
+	*echo.Echo
+	Store *MemoryStore
+}
+
+// NewMemoryServer serves a fresh memory store to an admin that is signed in
+// on every request.
+func NewMemoryServer(t testing.TB) *Server {
+	return NewMemoryStore(t).Server(SignedIn)
+}
+
+// Server registers every route on a new echo server behind middleware.
+func (s *MemoryStore) Server(middleware ...echo.MiddlewareFunc) *Server {
+	e := echo.New()
+	e.Use(middleware...)
+
+	routes.AuthRoutes(e, handlers.NewAuthHandler(s.Auth))
+	routes.APIKeyRoutes(e, handlers.NewAPIKeyHandler(s.APIKeys))
+	routes.ProductRoutes(e, handlers.NewProductHandler(s.Products))
+	routes.ProductImageRoutes(e, handlers.NewProductImageHandler(s.Images, MaxImageSize))
+	routes.PriceScheduleRoutes(e, handlers.NewPriceScheduleHandler(s.PriceSchedules))
+	routes.ReviewRoutes(e, handlers.NewReviewHandler(s.Reviews))
+	routes.ReservationRoutes(e, handlers.NewReservationHandler(s.Reservations))
+	routes.CartRoutes(e, handlers.NewCartHandler(s.Carts))
+	routes.OrderRoutes(e, handlers.NewOrderHandler(s.Orders))
+	routes.CouponRoutes(e, handlers.NewCouponHandler(s.Coupons))
+	routes.CategoryRoutes(e, handlers.NewCategoryHandler(s.Categories))
+	routes.ExchangeRateRoutes(e, handlers.NewExchangeRateHandler(s.ExchangeRates))
+
+	return &Server{Echo: e, Store: s}
+}
+
+// Send serves a JSON request and returns the response. headers are name,
+// value pairs.
+func (s *Server) Send(method, path, body string, headers ...string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	for i := 0; i+1 < len(headers); i += 2 {
+		req.Header.Set(headers[i], headers[i+1])
+	}
+	return s.Serve(req)
+}
+
+// Serve serves req and returns the response.
+func (s *Server) Serve(req *http.Request) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+	return rec
+}
+
+// SignedIn makes every request come from an authenticated admin.
+func SignedIn(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		c.Set(utils.ContextKeyIdentity, models.Identity{
+			UserID:      "test-user",
+			Email:       "tester@example.com",
+			Roles:       []string{models.RoleAdmin},
+			Permissions: models.PermissionsFor([]string{models.RoleAdmin}),
+		})
+		return next(c)
+	}
+}
+
+// Unlimited is a rate limiter that never limits.
+func Unlimited() services.IRateLimiter {
+	return services.NewRateLimiter(repositories.NewMemoryRateLimitStore(), models.RateLimit{}, nil)
+}
+
+// Bearer is the Authorization header pair for token, for Send.
+func Bearer(token string) []string {
+	if token == "" {
+		return nil
+	}
+	return []string{echo.HeaderAuthorization, "Bearer " + token}
+}
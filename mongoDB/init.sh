@@ -20,6 +20,63 @@ db.createCollection("products", {
   validator: { \$jsonSchema: $(cat /docker-entrypoint-initdb.d/schema.json) }
 });
 
+db.products.createIndex({ product_id: 1 }, { unique: true });
+db.products.createIndex({ created_at: 1, product_id: 1 });
+db.products.createIndex({ "price.amount": 1, product_id: 1 });
+db.products.createIndex({ name: 1, product_id: 1 });
+db.products.createIndex({ deleted_at: 1 });
+db.products.createIndex({ category_ids: 1 });
+
+db.createCollection("product_audit");
+db.product_audit.createIndex({ product_id: 1, created_at: -1, audit_id: -1 });
+
+db.createCollection("price_history");
+db.price_history.createIndex({ product_id: 1, effective_at: -1, entry_id: -1 });
+
+db.createCollection("price_schedules");
+db.price_schedules.createIndex({ schedule_id: 1 }, { unique: true });
+db.price_schedules.createIndex({ product_id: 1, starts_at: 1 });
+db.price_schedules.createIndex({ status: 1, starts_at: 1 });
+db.price_schedules.createIndex({ status: 1, ends_at: 1 });
+
+db.createCollection("reservations");
+db.reservations.createIndex({ reservation_id: 1 }, { unique: true });
+db.reservations.createIndex({ status: 1, expires_at: 1 });
+
+db.createCollection("carts");
+db.carts.createIndex({ cart_id: 1 }, { unique: true });
+db.carts.createIndex({ expires_at: 1 }, { expireAfterSeconds: 0 });
+
+db.createCollection("orders");
+db.orders.createIndex({ order_id: 1 }, { unique: true });
+
+db.createCollection("categories");
+db.categories.createIndex({ category_id: 1 }, { unique: true });
+db.categories.createIndex({ slug: 1 }, { unique: true });
+db.categories.createIndex({ parent_id: 1 });
+
+db.createCollection("coupons");
+db.coupons.createIndex({ coupon_id: 1 }, { unique: true });
+db.coupons.createIndex({ code: 1 }, { unique: true });
+
+db.createCollection("reviews");
+db.reviews.createIndex({ review_id: 1 }, { unique: true });
+db.reviews.createIndex({ product_id: 1, status: 1, created_at: -1, review_id: -1 });
+db.reviews.createIndex({ status: 1, created_at: -1, review_id: -1 });
+
+db.createCollection("users");
+db.users.createIndex({ user_id: 1 }, { unique: true });
+db.users.createIndex({ email: 1 }, { unique: true });
+
+db.createCollection("api_keys");
+db.api_keys.createIndex({ key_id: 1 }, { unique: true });
+db.api_keys.createIndex({ key_hash: 1 }, { unique: true });
+db.api_keys.createIndex({ created_at: -1, key_id: 1 });
+
+db.createCollection("idempotency_keys");
+db.idempotency_keys.createIndex({ key: 1 }, { unique: true });
+db.idempotency_keys.createIndex({ expires_at: 1 }, { expireAfterSeconds: 0 });
+
 EOF
 
 echo "Colección creada con éxito."
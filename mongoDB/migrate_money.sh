@@ -0,0 +1,79 @@
+#!/bin/bash
+
+# Converts prices stored as plain numbers into {amount, currency} documents,
+# with the amount in minor units, and installs the current product schema.
+# Documents that are already converted are left alone, so it is safe to run
+# more than once.
+
+MONGO_USER=${MONGO_INITDB_ROOT_USERNAME}
+MONGO_PASS=${MONGO_INITDB_ROOT_PASSWORD}
+MONGO_DB=${MONGO_INITDB_DATABASE:-ecommerce}
+MONGO_HOST=localhost
+MONGO_PORT=27017
+SCHEMA_PATH=${SCHEMA_PATH:-/docker-entrypoint-initdb.d/schema.json}
+PRICE_CURRENCY=${PRICE_CURRENCY:-USD}
+PRICE_SCALE=${PRICE_SCALE:-100}
+
+mongosh --host $MONGO_HOST --port $MONGO_PORT -u $MONGO_USER -p $MONGO_PASS --authenticationDatabase admin <<EOF
+use $MONGO_DB;
+
+function toMoney(field) {
+  return {
+    amount: { \$toLong: { \$round: [{ \$multiply: [field, $PRICE_SCALE] }, 0] } },
+    currency: "$PRICE_CURRENCY"
+  };
+}
+
+db.runCommand({
+  collMod: "products",
+  validator: { \$jsonSchema: $(cat $SCHEMA_PATH) },
+  validationLevel: "moderate"
+});
+
+printjson(db.products.updateMany(
+  { price: { \$type: "number" } },
+  [{ \$set: { price: toMoney("\$price") } }]
+));
+
+printjson(db.products.updateMany(
+  { "variants.price": { \$type: "number" } },
+  [{ \$set: { variants: { \$map: {
+    input: "\$variants",
+    as: "variant",
+    in: { \$cond: [
+      { \$isNumber: "\$\$variant.price" },
+      { \$mergeObjects: ["\$\$variant", { price: toMoney("\$\$variant.price") }] },
+      "\$\$variant"
+    ] }
+  } } } }]
+));
+
+db.runCommand({ collMod: "products", validationLevel: "strict" });
+
+try {
+  db.products.dropIndex({ price: 1, product_id: 1 });
+} catch (e) {
+  print("Old price index not found");
+}
+db.products.createIndex({ "price.amount": 1, product_id: 1 });
+
+["carts", "orders"].forEach(function (name) {
+  printjson(db[name].updateMany(
+    { total: { \$type: "number" } },
+    [{ \$set: {
+      total: toMoney("\$total"),
+      items: { \$map: {
+        input: "\$items",
+        as: "item",
+        in: { \$mergeObjects: ["\$\$item", {
+          unit_price: toMoney("\$\$item.unit_price"),
+          line_total: toMoney("\$\$item.line_total")
+        }] }
+      } }
+    } }]
+  ));
+});
+
+EOF
+
+echo "Price migration finished."